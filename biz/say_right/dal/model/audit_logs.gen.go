@@ -0,0 +1,33 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameAuditLog = "audit_logs"
+
+// AuditLog mapped from table <audit_logs>
+type AuditLog struct {
+	ID         int32     `gorm:"column:id;primaryKey" json:"id"`
+	ActorID    int32     `gorm:"column:actor_id;not null" json:"actor_id"`
+	ActorType  string    `gorm:"column:actor_type;not null;default:'user'" json:"actor_type"`
+	Action     string    `gorm:"column:action;not null" json:"action"`
+	TargetType string    `gorm:"column:target_type;not null" json:"target_type"`
+	TargetID   string    `gorm:"column:target_id;not null" json:"target_id"`
+	BeforeData string    `gorm:"column:before_data" json:"before_data"`
+	AfterData  string    `gorm:"column:after_data" json:"after_data"`
+	Detail     string    `gorm:"column:detail;not null" json:"detail"`
+	IP         string    `gorm:"column:ip;not null" json:"ip"`
+	UserAgent  string    `gorm:"column:user_agent;not null" json:"user_agent"`
+	RequestID  string    `gorm:"column:request_id;not null" json:"request_id"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName AuditLog's table name
+func (*AuditLog) TableName() string {
+	return TableNameAuditLog
+}
@@ -0,0 +1,33 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameBillingEvent = "billing_events"
+
+// BillingEvent mapped from table <billing_events>
+type BillingEvent struct {
+	ID             int32     `gorm:"column:id;primaryKey" json:"id"`
+	EventID        string    `gorm:"column:event_id;not null" json:"event_id"`
+	EventType      string    `gorm:"column:event_type;not null" json:"event_type"`
+	UserID         int32     `gorm:"column:user_id;not null" json:"user_id"`
+	Email          string    `gorm:"column:email;not null" json:"email"`
+	TransactionID  string    `gorm:"column:transaction_id;not null" json:"transaction_id"`
+	SubscriptionID string    `gorm:"column:subscription_id;not null" json:"subscription_id"`
+	Status         string    `gorm:"column:status;not null" json:"status"`
+	Amount         string    `gorm:"column:amount;not null" json:"amount"`
+	Currency       string    `gorm:"column:currency;not null" json:"currency"`
+	Payload        string    `gorm:"column:payload;not null" json:"payload"`
+	OccurredAt     time.Time `gorm:"column:occurred_at" json:"occurred_at"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName BillingEvent's table name
+func (*BillingEvent) TableName() string {
+	return TableNameBillingEvent
+}
@@ -12,14 +12,18 @@ const TableNameCategory = "categories"
 
 // Category mapped from table <categories>
 type Category struct {
-	ID          int32     `gorm:"column:id;primaryKey" json:"id"`
-	Name        string    `gorm:"column:name;not null" json:"name"`
-	Description string    `gorm:"column:description" json:"description"`
-	SortOrder   int32     `gorm:"column:sort_order;not null" json:"sort_order"`
-	Icon        string    `gorm:"column:icon" json:"icon"`
-	IsActive    int32     `gorm:"column:is_active;not null;default:1" json:"is_active"`
-	CreatedAt   time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	ID          int32      `gorm:"column:id;primaryKey" json:"id"`
+	Name        string     `gorm:"column:name;not null" json:"name"`
+	Slug        string     `gorm:"column:slug;not null" json:"slug"`
+	ParentID    *int32     `gorm:"column:parent_id" json:"parent_id"`
+	Description string     `gorm:"column:description" json:"description"`
+	SortOrder   int32      `gorm:"column:sort_order;not null" json:"sort_order"`
+	Icon        string     `gorm:"column:icon" json:"icon"`
+	IsActive    int32      `gorm:"column:is_active;not null;default:1" json:"is_active"`
+	PublishAt   *time.Time `gorm:"column:publish_at" json:"publish_at"`
+	UnpublishAt *time.Time `gorm:"column:unpublish_at" json:"unpublish_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
 // TableName Category's table name
@@ -0,0 +1,27 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameCategoryTranslation = "category_translations"
+
+// CategoryTranslation mapped from table <category_translations>
+type CategoryTranslation struct {
+	ID          int32     `gorm:"column:id;primaryKey" json:"id"`
+	CategoryID  int32     `gorm:"column:category_id;not null" json:"category_id"`
+	Locale      string    `gorm:"column:locale;not null" json:"locale"`
+	Name        string    `gorm:"column:name;not null" json:"name"`
+	Description string    `gorm:"column:description;not null" json:"description"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName CategoryTranslation's table name
+func (*CategoryTranslation) TableName() string {
+	return TableNameCategoryTranslation
+}
@@ -0,0 +1,19 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+const TableNameCollectionItem = "collection_items"
+
+// CollectionItem mapped from table <collection_items>
+type CollectionItem struct {
+	CollectionID int32 `gorm:"column:collection_id;primaryKey" json:"collection_id"`
+	TemplateID   int32 `gorm:"column:template_id;primaryKey" json:"template_id"`
+	SortOrder    int32 `gorm:"column:sort_order;not null" json:"sort_order"`
+}
+
+// TableName CollectionItem's table name
+func (*CollectionItem) TableName() string {
+	return TableNameCollectionItem
+}
@@ -0,0 +1,28 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameCollection = "collections"
+
+// Collection mapped from table <collections>
+type Collection struct {
+	ID          int32     `gorm:"column:id;primaryKey" json:"id"`
+	Slug        string    `gorm:"column:slug;not null" json:"slug"`
+	Title       string    `gorm:"column:title;not null" json:"title"`
+	Description string    `gorm:"column:description;not null" json:"description"`
+	SortOrder   int32     `gorm:"column:sort_order;not null" json:"sort_order"`
+	IsActive    int32     `gorm:"column:is_active;not null;default:1" json:"is_active"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName Collection's table name
+func (*Collection) TableName() string {
+	return TableNameCollection
+}
@@ -0,0 +1,34 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameGenerationUsage = "generation_usages"
+
+// GenerationUsage mapped from table <generation_usages>
+type GenerationUsage struct {
+	ID           int32     `gorm:"column:id;primaryKey" json:"id"`
+	UserID       int32     `gorm:"column:user_id;not null" json:"user_id"`
+	TemplateID   int32     `gorm:"column:template_id;not null" json:"template_id"`
+	Kind         string    `gorm:"column:kind;not null;default:'generate'" json:"kind"`
+	Provider     string    `gorm:"column:provider;not null" json:"provider"`
+	Model        string    `gorm:"column:model;not null" json:"model"`
+	Tone         string    `gorm:"column:tone;not null" json:"tone"`
+	Status       string    `gorm:"column:status;not null" json:"status"`
+	InputTokens  int32     `gorm:"column:input_tokens;not null" json:"input_tokens"`
+	OutputTokens int32     `gorm:"column:output_tokens;not null" json:"output_tokens"`
+	CostMicros   int64     `gorm:"column:cost_micros;not null" json:"cost_micros"`
+	LatencyMs    int32     `gorm:"column:latency_ms;not null" json:"latency_ms"`
+	Error        string    `gorm:"column:error;not null" json:"error"`
+	CreatedAt    time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName GenerationUsage's table name
+func (*GenerationUsage) TableName() string {
+	return TableNameGenerationUsage
+}
@@ -0,0 +1,28 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameOrganizationInvitation = "organization_invitations"
+
+// OrganizationInvitation mapped from table <organization_invitations>
+type OrganizationInvitation struct {
+	ID             int32     `gorm:"column:id;primaryKey" json:"id"`
+	OrganizationID int32     `gorm:"column:organization_id;not null" json:"organization_id"`
+	Email          string    `gorm:"column:email;not null" json:"email"`
+	Role           string    `gorm:"column:role;not null" json:"role"`
+	Token          string    `gorm:"column:token;not null" json:"token"`
+	InvitedBy      int32     `gorm:"column:invited_by;not null" json:"invited_by"`
+	ExpiresAt      time.Time `gorm:"column:expires_at;not null" json:"expires_at"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName OrganizationInvitation's table name
+func (*OrganizationInvitation) TableName() string {
+	return TableNameOrganizationInvitation
+}
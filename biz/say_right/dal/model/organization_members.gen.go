@@ -0,0 +1,24 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameOrganizationMember = "organization_members"
+
+// OrganizationMember mapped from table <organization_members>
+type OrganizationMember struct {
+	OrganizationID int32     `gorm:"column:organization_id;primaryKey" json:"organization_id"`
+	UserID         int32     `gorm:"column:user_id;primaryKey" json:"user_id"`
+	Role           string    `gorm:"column:role;not null" json:"role"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName OrganizationMember's table name
+func (*OrganizationMember) TableName() string {
+	return TableNameOrganizationMember
+}
@@ -0,0 +1,30 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameOrganization = "organizations"
+
+// Organization mapped from table <organizations>
+type Organization struct {
+	ID                  int32      `gorm:"column:id;primaryKey" json:"id"`
+	Name                string     `gorm:"column:name;not null" json:"name"`
+	Seats               int32      `gorm:"column:seats;not null" json:"seats"`
+	SubscriptionID      string     `gorm:"column:subscription_id;not null" json:"subscription_id"`
+	SubscriptionStatus  string     `gorm:"column:subscription_status;not null" json:"subscription_status"`
+	CurrentPeriodEnd    *time.Time `gorm:"column:current_period_end" json:"current_period_end"`
+	CheckoutToken       string     `gorm:"column:checkout_token;type:TEXT" json:"checkout_token"`
+	SubscriptionEventAt *time.Time `gorm:"column:subscription_event_at" json:"subscription_event_at"`
+	CreatedAt           time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt           time.Time  `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName Organization's table name
+func (*Organization) TableName() string {
+	return TableNameOrganization
+}
@@ -0,0 +1,31 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameReferral = "referrals"
+
+// Referral mapped from table <referrals>
+type Referral struct {
+	ID            int32      `gorm:"column:id;primaryKey" json:"id"`
+	ReferrerID    int32      `gorm:"column:referrer_id;not null" json:"referrer_id"`
+	RefereeID     int32      `gorm:"column:referee_id;not null" json:"referee_id"`
+	Status        string     `gorm:"column:status;not null" json:"status"`
+	Reason        string     `gorm:"column:reason;not null" json:"reason"`
+	SignupIP      string     `gorm:"column:signup_ip;not null" json:"signup_ip"`
+	RewardDays    int32      `gorm:"column:reward_days;not null" json:"reward_days"`
+	TransactionID string     `gorm:"column:transaction_id;not null" json:"transaction_id"`
+	RewardedAt    *time.Time `gorm:"column:rewarded_at" json:"rewarded_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName Referral's table name
+func (*Referral) TableName() string {
+	return TableNameReferral
+}
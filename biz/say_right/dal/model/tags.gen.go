@@ -0,0 +1,27 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameTag = "tags"
+
+// Tag mapped from table <tags>
+type Tag struct {
+	ID        int32     `gorm:"column:id;primaryKey" json:"id"`
+	Slug      string    `gorm:"column:slug;not null" json:"slug"`
+	Name      string    `gorm:"column:name;not null" json:"name"`
+	Color     string    `gorm:"column:color;not null" json:"color"`
+	Labels    string    `gorm:"column:labels;not null" json:"labels"`
+	CreatedAt time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName Tag's table name
+func (*Tag) TableName() string {
+	return TableNameTag
+}
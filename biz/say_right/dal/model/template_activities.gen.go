@@ -0,0 +1,27 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameTemplateActivity = "template_activities"
+
+// TemplateActivity mapped from table <template_activities>
+type TemplateActivity struct {
+	UserID       int32      `gorm:"column:user_id;primaryKey" json:"user_id"`
+	TemplateID   int32      `gorm:"column:template_id;primaryKey" json:"template_id"`
+	ViewCount    int32      `gorm:"column:view_count;not null" json:"view_count"`
+	CopyCount    int32      `gorm:"column:copy_count;not null" json:"copy_count"`
+	LastViewedAt *time.Time `gorm:"column:last_viewed_at" json:"last_viewed_at"`
+	LastCopiedAt *time.Time `gorm:"column:last_copied_at" json:"last_copied_at"`
+	LastUsedAt   time.Time  `gorm:"column:last_used_at;not null" json:"last_used_at"`
+}
+
+// TableName TemplateActivity's table name
+func (*TemplateActivity) TableName() string {
+	return TableNameTemplateActivity
+}
@@ -0,0 +1,25 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameTemplateCopy = "template_copies"
+
+// TemplateCopy mapped from table <template_copies>
+type TemplateCopy struct {
+	ID         int32     `gorm:"column:id;primaryKey" json:"id"`
+	UserID     int32     `gorm:"column:user_id;not null" json:"user_id"`
+	TemplateID int32     `gorm:"column:template_id;not null" json:"template_id"`
+	Tone       string    `gorm:"column:tone;not null" json:"tone"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName TemplateCopy's table name
+func (*TemplateCopy) TableName() string {
+	return TableNameTemplateCopy
+}
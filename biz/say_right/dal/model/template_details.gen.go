@@ -21,6 +21,8 @@ type TemplateDetail struct {
 	ReplyFirm     string    `gorm:"column:reply_firm;not null" json:"reply_firm"`
 	WhenNotToUse  string    `gorm:"column:when_not_to_use;not null" json:"when_not_to_use"`
 	BestPractices string    `gorm:"column:best_practices;not null" json:"best_practices"`
+	Variables     string    `gorm:"column:variables;not null;default:'[]'" json:"variables"`
+	Prompt        string    `gorm:"column:prompt;not null" json:"prompt"`
 	CreatedAt     time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt     time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
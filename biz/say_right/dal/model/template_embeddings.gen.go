@@ -0,0 +1,28 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameTemplateEmbedding = "template_embeddings"
+
+// TemplateEmbedding mapped from table <template_embeddings>
+type TemplateEmbedding struct {
+	ID          int32     `gorm:"column:id;primaryKey" json:"id"`
+	TemplateID  int32     `gorm:"column:template_id;not null" json:"template_id"`
+	Model       string    `gorm:"column:model;not null" json:"model"`
+	ContentHash string    `gorm:"column:content_hash;not null" json:"content_hash"`
+	Dimensions  int32     `gorm:"column:dimensions;not null" json:"dimensions"`
+	Vector      []byte    `gorm:"column:vector;not null" json:"vector"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName TemplateEmbedding's table name
+func (*TemplateEmbedding) TableName() string {
+	return TableNameTemplateEmbedding
+}
@@ -0,0 +1,23 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameTemplateFavorite = "template_favorites"
+
+// TemplateFavorite mapped from table <template_favorites>
+type TemplateFavorite struct {
+	UserID     int32     `gorm:"column:user_id;primaryKey" json:"user_id"`
+	TemplateID int32     `gorm:"column:template_id;primaryKey" json:"template_id"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName TemplateFavorite's table name
+func (*TemplateFavorite) TableName() string {
+	return TableNameTemplateFavorite
+}
@@ -0,0 +1,31 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameTemplateRating = "template_ratings"
+
+// TemplateRating mapped from table <template_ratings>
+type TemplateRating struct {
+	ID            int32      `gorm:"column:id;primaryKey" json:"id"`
+	UserID        int32      `gorm:"column:user_id;not null" json:"user_id"`
+	TemplateID    int32      `gorm:"column:template_id;not null" json:"template_id"`
+	Tone          string     `gorm:"column:tone;not null" json:"tone"`
+	Vote          int32      `gorm:"column:vote;not null" json:"vote"`
+	Comment       string     `gorm:"column:comment;not null" json:"comment"`
+	CommentStatus string     `gorm:"column:comment_status;not null" json:"comment_status"`
+	ModeratedBy   int32      `gorm:"column:moderated_by;not null" json:"moderated_by"`
+	ModeratedAt   *time.Time `gorm:"column:moderated_at" json:"moderated_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName TemplateRating's table name
+func (*TemplateRating) TableName() string {
+	return TableNameTemplateRating
+}
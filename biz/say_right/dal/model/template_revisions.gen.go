@@ -0,0 +1,27 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameTemplateRevision = "template_revisions"
+
+// TemplateRevision mapped from table <template_revisions>
+type TemplateRevision struct {
+	ID         int32     `gorm:"column:id;primaryKey" json:"id"`
+	TemplateID int32     `gorm:"column:template_id;not null" json:"template_id"`
+	Status     string    `gorm:"column:status;not null" json:"status"`
+	Snapshot   string    `gorm:"column:snapshot;not null" json:"snapshot"`
+	AuthorID   int32     `gorm:"column:author_id;not null" json:"author_id"`
+	Note       string    `gorm:"column:note;not null" json:"note"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName TemplateRevision's table name
+func (*TemplateRevision) TableName() string {
+	return TableNameTemplateRevision
+}
@@ -0,0 +1,32 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameTemplateShare = "template_shares"
+
+// TemplateShare mapped from table <template_shares>
+type TemplateShare struct {
+	ID             int32      `gorm:"column:id;primaryKey" json:"id"`
+	Token          string     `gorm:"column:token;not null" json:"token"`
+	UserID         int32      `gorm:"column:user_id;not null" json:"user_id"`
+	TemplateID     int32      `gorm:"column:template_id;not null" json:"template_id"`
+	Locale         string     `gorm:"column:locale;not null" json:"locale"`
+	Tone           string     `gorm:"column:tone;not null" json:"tone"`
+	VariableValues string     `gorm:"column:variable_values;not null" json:"variable_values"`
+	ViewCount      int32      `gorm:"column:view_count;not null" json:"view_count"`
+	LastViewedAt   *time.Time `gorm:"column:last_viewed_at" json:"last_viewed_at"`
+	ExpiresAt      *time.Time `gorm:"column:expires_at" json:"expires_at"`
+	RevokedAt      *time.Time `gorm:"column:revoked_at" json:"revoked_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName TemplateShare's table name
+func (*TemplateShare) TableName() string {
+	return TableNameTemplateShare
+}
@@ -0,0 +1,24 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameTemplateSlugRedirect = "template_slug_redirects"
+
+// TemplateSlugRedirect mapped from table <template_slug_redirects>
+type TemplateSlugRedirect struct {
+	ID         int32     `gorm:"column:id;primaryKey" json:"id"`
+	OldSlug    string    `gorm:"column:old_slug;not null" json:"old_slug"`
+	TemplateID int32     `gorm:"column:template_id;not null" json:"template_id"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName TemplateSlugRedirect's table name
+func (*TemplateSlugRedirect) TableName() string {
+	return TableNameTemplateSlugRedirect
+}
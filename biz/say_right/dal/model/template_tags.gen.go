@@ -0,0 +1,19 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+const TableNameTemplateTag = "template_tags"
+
+// TemplateTag mapped from table <template_tags>
+type TemplateTag struct {
+	TemplateID int32 `gorm:"column:template_id;primaryKey" json:"template_id"`
+	TagID      int32 `gorm:"column:tag_id;primaryKey" json:"tag_id"`
+	SortOrder  int32 `gorm:"column:sort_order;not null" json:"sort_order"`
+}
+
+// TableName TemplateTag's table name
+func (*TemplateTag) TableName() string {
+	return TableNameTemplateTag
+}
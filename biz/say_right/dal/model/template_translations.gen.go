@@ -0,0 +1,34 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameTemplateTranslation = "template_translations"
+
+// TemplateTranslation mapped from table <template_translations>
+type TemplateTranslation struct {
+	ID            int32     `gorm:"column:id;primaryKey" json:"id"`
+	TemplateID    int32     `gorm:"column:template_id;not null" json:"template_id"`
+	Locale        string    `gorm:"column:locale;not null" json:"locale"`
+	Title         string    `gorm:"column:title;not null" json:"title"`
+	Description   string    `gorm:"column:description;not null" json:"description"`
+	Headline      string    `gorm:"column:headline;not null" json:"headline"`
+	Summary       string    `gorm:"column:summary;not null" json:"summary"`
+	ReplySoft     string    `gorm:"column:reply_soft;not null" json:"reply_soft"`
+	ReplyNeutral  string    `gorm:"column:reply_neutral;not null" json:"reply_neutral"`
+	ReplyFirm     string    `gorm:"column:reply_firm;not null" json:"reply_firm"`
+	WhenNotToUse  string    `gorm:"column:when_not_to_use;not null" json:"when_not_to_use"`
+	BestPractices string    `gorm:"column:best_practices;not null" json:"best_practices"`
+	CreatedAt     time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName TemplateTranslation's table name
+func (*TemplateTranslation) TableName() string {
+	return TableNameTemplateTranslation
+}
@@ -0,0 +1,39 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+const TableNameUserTemplate = "user_templates"
+
+// UserTemplate mapped from table <user_templates>
+type UserTemplate struct {
+	ID               int32     `gorm:"column:id;primaryKey" json:"id"`
+	OwnerID          int32     `gorm:"column:owner_id;not null" json:"owner_id"`
+	OrganizationID   *int32    `gorm:"column:organization_id" json:"organization_id"`
+	SourceTemplateID *int32    `gorm:"column:source_template_id" json:"source_template_id"`
+	SourceLocale     string    `gorm:"column:source_locale;not null" json:"source_locale"`
+	Title            string    `gorm:"column:title;not null" json:"title"`
+	Description      string    `gorm:"column:description;not null" json:"description"`
+	TagsText         string    `gorm:"column:tags_text;not null" json:"tags_text"`
+	Headline         string    `gorm:"column:headline;not null" json:"headline"`
+	Summary          string    `gorm:"column:summary;not null" json:"summary"`
+	ReplySoft        string    `gorm:"column:reply_soft;not null" json:"reply_soft"`
+	ReplyNeutral     string    `gorm:"column:reply_neutral;not null" json:"reply_neutral"`
+	ReplyFirm        string    `gorm:"column:reply_firm;not null" json:"reply_firm"`
+	WhenNotToUse     string    `gorm:"column:when_not_to_use;not null" json:"when_not_to_use"`
+	BestPractices    string    `gorm:"column:best_practices;not null" json:"best_practices"`
+	Variables        string    `gorm:"column:variables;not null;default:'[]'" json:"variables"`
+	SourceSnapshot   string    `gorm:"column:source_snapshot;not null" json:"source_snapshot"`
+	CreatedAt        time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt        time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName UserTemplate's table name
+func (*UserTemplate) TableName() string {
+	return TableNameUserTemplate
+}
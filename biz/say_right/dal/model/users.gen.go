@@ -12,14 +12,19 @@ const TableNameUser = "users"
 
 // User mapped from table <users>
 type User struct {
-	ID              int32     `gorm:"column:id;primaryKey" json:"id"`
-	Email           string    `gorm:"column:email;not null" json:"email"`
-	EmailNorm       string    `gorm:"column:email_norm;not null" json:"email_norm"`
-	EmailVerifiedAt time.Time `gorm:"column:email_verified_at" json:"email_verified_at"`
-	Status          int32     `gorm:"column:status;not null;default:1" json:"status"`
-	IsPro           int32     `gorm:"column:is_pro;not null" json:"is_pro"`
-	CreatedAt       time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt       time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	ID              int32      `gorm:"column:id;primaryKey" json:"id"`
+	Email           string     `gorm:"column:email;not null" json:"email"`
+	EmailNorm       string     `gorm:"column:email_norm;not null" json:"email_norm"`
+	EmailVerifiedAt time.Time  `gorm:"column:email_verified_at" json:"email_verified_at"`
+	Status          int32      `gorm:"column:status;not null;default:1" json:"status"`
+	IsPro           int32      `gorm:"column:is_pro;not null" json:"is_pro"`
+	TeamPro         int32      `gorm:"column:team_pro;not null" json:"team_pro"`
+	ProUntil        *time.Time `gorm:"column:pro_until" json:"pro_until"`
+	Role            string     `gorm:"column:role;not null;default:'user'" json:"role"`
+	Locale          string     `gorm:"column:locale;not null" json:"locale"`
+	ReferralCode    *string    `gorm:"column:referral_code" json:"referral_code"`
+	CreatedAt       time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
 // TableName User's table name
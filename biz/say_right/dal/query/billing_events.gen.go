@@ -0,0 +1,431 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newBillingEvent(db *gorm.DB, opts ...gen.DOOption) billingEvent {
+	_billingEvent := billingEvent{}
+
+	_billingEvent.billingEventDo.UseDB(db, opts...)
+	_billingEvent.billingEventDo.UseModel(&model.BillingEvent{})
+
+	tableName := _billingEvent.billingEventDo.TableName()
+	_billingEvent.ALL = field.NewAsterisk(tableName)
+	_billingEvent.ID = field.NewInt32(tableName, "id")
+	_billingEvent.EventID = field.NewString(tableName, "event_id")
+	_billingEvent.EventType = field.NewString(tableName, "event_type")
+	_billingEvent.UserID = field.NewInt32(tableName, "user_id")
+	_billingEvent.Email = field.NewString(tableName, "email")
+	_billingEvent.TransactionID = field.NewString(tableName, "transaction_id")
+	_billingEvent.SubscriptionID = field.NewString(tableName, "subscription_id")
+	_billingEvent.Status = field.NewString(tableName, "status")
+	_billingEvent.Amount = field.NewString(tableName, "amount")
+	_billingEvent.Currency = field.NewString(tableName, "currency")
+	_billingEvent.Payload = field.NewString(tableName, "payload")
+	_billingEvent.OccurredAt = field.NewTime(tableName, "occurred_at")
+	_billingEvent.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_billingEvent.fillFieldMap()
+
+	return _billingEvent
+}
+
+type billingEvent struct {
+	billingEventDo
+
+	ALL            field.Asterisk
+	ID             field.Int32
+	EventID        field.String
+	EventType      field.String
+	UserID         field.Int32
+	Email          field.String
+	TransactionID  field.String
+	SubscriptionID field.String
+	Status         field.String
+	Amount         field.String
+	Currency       field.String
+	Payload        field.String
+	OccurredAt     field.Time
+	CreatedAt      field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (b billingEvent) Table(newTableName string) *billingEvent {
+	b.billingEventDo.UseTable(newTableName)
+	return b.updateTableName(newTableName)
+}
+
+func (b billingEvent) As(alias string) *billingEvent {
+	b.billingEventDo.DO = *(b.billingEventDo.As(alias).(*gen.DO))
+	return b.updateTableName(alias)
+}
+
+func (b *billingEvent) updateTableName(table string) *billingEvent {
+	b.ALL = field.NewAsterisk(table)
+	b.ID = field.NewInt32(table, "id")
+	b.EventID = field.NewString(table, "event_id")
+	b.EventType = field.NewString(table, "event_type")
+	b.UserID = field.NewInt32(table, "user_id")
+	b.Email = field.NewString(table, "email")
+	b.TransactionID = field.NewString(table, "transaction_id")
+	b.SubscriptionID = field.NewString(table, "subscription_id")
+	b.Status = field.NewString(table, "status")
+	b.Amount = field.NewString(table, "amount")
+	b.Currency = field.NewString(table, "currency")
+	b.Payload = field.NewString(table, "payload")
+	b.OccurredAt = field.NewTime(table, "occurred_at")
+	b.CreatedAt = field.NewTime(table, "created_at")
+
+	b.fillFieldMap()
+
+	return b
+}
+
+func (b *billingEvent) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := b.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (b *billingEvent) fillFieldMap() {
+	b.fieldMap = make(map[string]field.Expr, 13)
+	b.fieldMap["id"] = b.ID
+	b.fieldMap["event_id"] = b.EventID
+	b.fieldMap["event_type"] = b.EventType
+	b.fieldMap["user_id"] = b.UserID
+	b.fieldMap["email"] = b.Email
+	b.fieldMap["transaction_id"] = b.TransactionID
+	b.fieldMap["subscription_id"] = b.SubscriptionID
+	b.fieldMap["status"] = b.Status
+	b.fieldMap["amount"] = b.Amount
+	b.fieldMap["currency"] = b.Currency
+	b.fieldMap["payload"] = b.Payload
+	b.fieldMap["occurred_at"] = b.OccurredAt
+	b.fieldMap["created_at"] = b.CreatedAt
+}
+
+func (b billingEvent) clone(db *gorm.DB) billingEvent {
+	b.billingEventDo.ReplaceConnPool(db.Statement.ConnPool)
+	return b
+}
+
+func (b billingEvent) replaceDB(db *gorm.DB) billingEvent {
+	b.billingEventDo.ReplaceDB(db)
+	return b
+}
+
+type billingEventDo struct{ gen.DO }
+
+type IBillingEventDo interface {
+	gen.SubQuery
+	Debug() IBillingEventDo
+	WithContext(ctx context.Context) IBillingEventDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() IBillingEventDo
+	WriteDB() IBillingEventDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) IBillingEventDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) IBillingEventDo
+	Not(conds ...gen.Condition) IBillingEventDo
+	Or(conds ...gen.Condition) IBillingEventDo
+	Select(conds ...field.Expr) IBillingEventDo
+	Where(conds ...gen.Condition) IBillingEventDo
+	Order(conds ...field.Expr) IBillingEventDo
+	Distinct(cols ...field.Expr) IBillingEventDo
+	Omit(cols ...field.Expr) IBillingEventDo
+	Join(table schema.Tabler, on ...field.Expr) IBillingEventDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) IBillingEventDo
+	RightJoin(table schema.Tabler, on ...field.Expr) IBillingEventDo
+	Group(cols ...field.Expr) IBillingEventDo
+	Having(conds ...gen.Condition) IBillingEventDo
+	Limit(limit int) IBillingEventDo
+	Offset(offset int) IBillingEventDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) IBillingEventDo
+	Unscoped() IBillingEventDo
+	Create(values ...*model.BillingEvent) error
+	CreateInBatches(values []*model.BillingEvent, batchSize int) error
+	Save(values ...*model.BillingEvent) error
+	First() (*model.BillingEvent, error)
+	Take() (*model.BillingEvent, error)
+	Last() (*model.BillingEvent, error)
+	Find() ([]*model.BillingEvent, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.BillingEvent, err error)
+	FindInBatches(result *[]*model.BillingEvent, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.BillingEvent) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) IBillingEventDo
+	Assign(attrs ...field.AssignExpr) IBillingEventDo
+	Joins(fields ...field.RelationField) IBillingEventDo
+	Preload(fields ...field.RelationField) IBillingEventDo
+	FirstOrInit() (*model.BillingEvent, error)
+	FirstOrCreate() (*model.BillingEvent, error)
+	FindByPage(offset int, limit int) (result []*model.BillingEvent, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) IBillingEventDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (b billingEventDo) Debug() IBillingEventDo {
+	return b.withDO(b.DO.Debug())
+}
+
+func (b billingEventDo) WithContext(ctx context.Context) IBillingEventDo {
+	return b.withDO(b.DO.WithContext(ctx))
+}
+
+func (b billingEventDo) ReadDB() IBillingEventDo {
+	return b.Clauses(dbresolver.Read)
+}
+
+func (b billingEventDo) WriteDB() IBillingEventDo {
+	return b.Clauses(dbresolver.Write)
+}
+
+func (b billingEventDo) Session(config *gorm.Session) IBillingEventDo {
+	return b.withDO(b.DO.Session(config))
+}
+
+func (b billingEventDo) Clauses(conds ...clause.Expression) IBillingEventDo {
+	return b.withDO(b.DO.Clauses(conds...))
+}
+
+func (b billingEventDo) Returning(value interface{}, columns ...string) IBillingEventDo {
+	return b.withDO(b.DO.Returning(value, columns...))
+}
+
+func (b billingEventDo) Not(conds ...gen.Condition) IBillingEventDo {
+	return b.withDO(b.DO.Not(conds...))
+}
+
+func (b billingEventDo) Or(conds ...gen.Condition) IBillingEventDo {
+	return b.withDO(b.DO.Or(conds...))
+}
+
+func (b billingEventDo) Select(conds ...field.Expr) IBillingEventDo {
+	return b.withDO(b.DO.Select(conds...))
+}
+
+func (b billingEventDo) Where(conds ...gen.Condition) IBillingEventDo {
+	return b.withDO(b.DO.Where(conds...))
+}
+
+func (b billingEventDo) Order(conds ...field.Expr) IBillingEventDo {
+	return b.withDO(b.DO.Order(conds...))
+}
+
+func (b billingEventDo) Distinct(cols ...field.Expr) IBillingEventDo {
+	return b.withDO(b.DO.Distinct(cols...))
+}
+
+func (b billingEventDo) Omit(cols ...field.Expr) IBillingEventDo {
+	return b.withDO(b.DO.Omit(cols...))
+}
+
+func (b billingEventDo) Join(table schema.Tabler, on ...field.Expr) IBillingEventDo {
+	return b.withDO(b.DO.Join(table, on...))
+}
+
+func (b billingEventDo) LeftJoin(table schema.Tabler, on ...field.Expr) IBillingEventDo {
+	return b.withDO(b.DO.LeftJoin(table, on...))
+}
+
+func (b billingEventDo) RightJoin(table schema.Tabler, on ...field.Expr) IBillingEventDo {
+	return b.withDO(b.DO.RightJoin(table, on...))
+}
+
+func (b billingEventDo) Group(cols ...field.Expr) IBillingEventDo {
+	return b.withDO(b.DO.Group(cols...))
+}
+
+func (b billingEventDo) Having(conds ...gen.Condition) IBillingEventDo {
+	return b.withDO(b.DO.Having(conds...))
+}
+
+func (b billingEventDo) Limit(limit int) IBillingEventDo {
+	return b.withDO(b.DO.Limit(limit))
+}
+
+func (b billingEventDo) Offset(offset int) IBillingEventDo {
+	return b.withDO(b.DO.Offset(offset))
+}
+
+func (b billingEventDo) Scopes(funcs ...func(gen.Dao) gen.Dao) IBillingEventDo {
+	return b.withDO(b.DO.Scopes(funcs...))
+}
+
+func (b billingEventDo) Unscoped() IBillingEventDo {
+	return b.withDO(b.DO.Unscoped())
+}
+
+func (b billingEventDo) Create(values ...*model.BillingEvent) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return b.DO.Create(values)
+}
+
+func (b billingEventDo) CreateInBatches(values []*model.BillingEvent, batchSize int) error {
+	return b.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (b billingEventDo) Save(values ...*model.BillingEvent) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return b.DO.Save(values)
+}
+
+func (b billingEventDo) First() (*model.BillingEvent, error) {
+	if result, err := b.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.BillingEvent), nil
+	}
+}
+
+func (b billingEventDo) Take() (*model.BillingEvent, error) {
+	if result, err := b.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.BillingEvent), nil
+	}
+}
+
+func (b billingEventDo) Last() (*model.BillingEvent, error) {
+	if result, err := b.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.BillingEvent), nil
+	}
+}
+
+func (b billingEventDo) Find() ([]*model.BillingEvent, error) {
+	result, err := b.DO.Find()
+	return result.([]*model.BillingEvent), err
+}
+
+func (b billingEventDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.BillingEvent, err error) {
+	buf := make([]*model.BillingEvent, 0, batchSize)
+	err = b.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (b billingEventDo) FindInBatches(result *[]*model.BillingEvent, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return b.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (b billingEventDo) Attrs(attrs ...field.AssignExpr) IBillingEventDo {
+	return b.withDO(b.DO.Attrs(attrs...))
+}
+
+func (b billingEventDo) Assign(attrs ...field.AssignExpr) IBillingEventDo {
+	return b.withDO(b.DO.Assign(attrs...))
+}
+
+func (b billingEventDo) Joins(fields ...field.RelationField) IBillingEventDo {
+	for _, _f := range fields {
+		b = *b.withDO(b.DO.Joins(_f))
+	}
+	return &b
+}
+
+func (b billingEventDo) Preload(fields ...field.RelationField) IBillingEventDo {
+	for _, _f := range fields {
+		b = *b.withDO(b.DO.Preload(_f))
+	}
+	return &b
+}
+
+func (b billingEventDo) FirstOrInit() (*model.BillingEvent, error) {
+	if result, err := b.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.BillingEvent), nil
+	}
+}
+
+func (b billingEventDo) FirstOrCreate() (*model.BillingEvent, error) {
+	if result, err := b.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.BillingEvent), nil
+	}
+}
+
+func (b billingEventDo) FindByPage(offset int, limit int) (result []*model.BillingEvent, count int64, err error) {
+	result, err = b.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = b.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (b billingEventDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = b.Count()
+	if err != nil {
+		return
+	}
+
+	err = b.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (b billingEventDo) Scan(result interface{}) (err error) {
+	return b.DO.Scan(result)
+}
+
+func (b billingEventDo) Delete(models ...*model.BillingEvent) (result gen.ResultInfo, err error) {
+	return b.DO.Delete(models)
+}
+
+func (b *billingEventDo) withDO(do gen.Dao) *billingEventDo {
+	b.DO = *do.(*gen.DO)
+	return b
+}
@@ -30,10 +30,14 @@ func newCategory(db *gorm.DB, opts ...gen.DOOption) category {
 	_category.ALL = field.NewAsterisk(tableName)
 	_category.ID = field.NewInt32(tableName, "id")
 	_category.Name = field.NewString(tableName, "name")
+	_category.Slug = field.NewString(tableName, "slug")
+	_category.ParentID = field.NewInt32(tableName, "parent_id")
 	_category.Description = field.NewString(tableName, "description")
 	_category.SortOrder = field.NewInt32(tableName, "sort_order")
 	_category.Icon = field.NewString(tableName, "icon")
 	_category.IsActive = field.NewInt32(tableName, "is_active")
+	_category.PublishAt = field.NewTime(tableName, "publish_at")
+	_category.UnpublishAt = field.NewTime(tableName, "unpublish_at")
 	_category.CreatedAt = field.NewTime(tableName, "created_at")
 	_category.UpdatedAt = field.NewTime(tableName, "updated_at")
 
@@ -48,10 +52,14 @@ type category struct {
 	ALL         field.Asterisk
 	ID          field.Int32
 	Name        field.String
+	Slug        field.String
+	ParentID    field.Int32
 	Description field.String
 	SortOrder   field.Int32
 	Icon        field.String
 	IsActive    field.Int32
+	PublishAt   field.Time
+	UnpublishAt field.Time
 	CreatedAt   field.Time
 	UpdatedAt   field.Time
 
@@ -72,10 +80,14 @@ func (c *category) updateTableName(table string) *category {
 	c.ALL = field.NewAsterisk(table)
 	c.ID = field.NewInt32(table, "id")
 	c.Name = field.NewString(table, "name")
+	c.Slug = field.NewString(table, "slug")
+	c.ParentID = field.NewInt32(table, "parent_id")
 	c.Description = field.NewString(table, "description")
 	c.SortOrder = field.NewInt32(table, "sort_order")
 	c.Icon = field.NewString(table, "icon")
 	c.IsActive = field.NewInt32(table, "is_active")
+	c.PublishAt = field.NewTime(table, "publish_at")
+	c.UnpublishAt = field.NewTime(table, "unpublish_at")
 	c.CreatedAt = field.NewTime(table, "created_at")
 	c.UpdatedAt = field.NewTime(table, "updated_at")
 
@@ -94,13 +106,17 @@ func (c *category) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (c *category) fillFieldMap() {
-	c.fieldMap = make(map[string]field.Expr, 8)
+	c.fieldMap = make(map[string]field.Expr, 12)
 	c.fieldMap["id"] = c.ID
 	c.fieldMap["name"] = c.Name
+	c.fieldMap["slug"] = c.Slug
+	c.fieldMap["parent_id"] = c.ParentID
 	c.fieldMap["description"] = c.Description
 	c.fieldMap["sort_order"] = c.SortOrder
 	c.fieldMap["icon"] = c.Icon
 	c.fieldMap["is_active"] = c.IsActive
+	c.fieldMap["publish_at"] = c.PublishAt
+	c.fieldMap["unpublish_at"] = c.UnpublishAt
 	c.fieldMap["created_at"] = c.CreatedAt
 	c.fieldMap["updated_at"] = c.UpdatedAt
 }
@@ -0,0 +1,407 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newCategoryTranslation(db *gorm.DB, opts ...gen.DOOption) categoryTranslation {
+	_categoryTranslation := categoryTranslation{}
+
+	_categoryTranslation.categoryTranslationDo.UseDB(db, opts...)
+	_categoryTranslation.categoryTranslationDo.UseModel(&model.CategoryTranslation{})
+
+	tableName := _categoryTranslation.categoryTranslationDo.TableName()
+	_categoryTranslation.ALL = field.NewAsterisk(tableName)
+	_categoryTranslation.ID = field.NewInt32(tableName, "id")
+	_categoryTranslation.CategoryID = field.NewInt32(tableName, "category_id")
+	_categoryTranslation.Locale = field.NewString(tableName, "locale")
+	_categoryTranslation.Name = field.NewString(tableName, "name")
+	_categoryTranslation.Description = field.NewString(tableName, "description")
+	_categoryTranslation.CreatedAt = field.NewTime(tableName, "created_at")
+	_categoryTranslation.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_categoryTranslation.fillFieldMap()
+
+	return _categoryTranslation
+}
+
+type categoryTranslation struct {
+	categoryTranslationDo
+
+	ALL         field.Asterisk
+	ID          field.Int32
+	CategoryID  field.Int32
+	Locale      field.String
+	Name        field.String
+	Description field.String
+	CreatedAt   field.Time
+	UpdatedAt   field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (c categoryTranslation) Table(newTableName string) *categoryTranslation {
+	c.categoryTranslationDo.UseTable(newTableName)
+	return c.updateTableName(newTableName)
+}
+
+func (c categoryTranslation) As(alias string) *categoryTranslation {
+	c.categoryTranslationDo.DO = *(c.categoryTranslationDo.As(alias).(*gen.DO))
+	return c.updateTableName(alias)
+}
+
+func (c *categoryTranslation) updateTableName(table string) *categoryTranslation {
+	c.ALL = field.NewAsterisk(table)
+	c.ID = field.NewInt32(table, "id")
+	c.CategoryID = field.NewInt32(table, "category_id")
+	c.Locale = field.NewString(table, "locale")
+	c.Name = field.NewString(table, "name")
+	c.Description = field.NewString(table, "description")
+	c.CreatedAt = field.NewTime(table, "created_at")
+	c.UpdatedAt = field.NewTime(table, "updated_at")
+
+	c.fillFieldMap()
+
+	return c
+}
+
+func (c *categoryTranslation) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := c.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (c *categoryTranslation) fillFieldMap() {
+	c.fieldMap = make(map[string]field.Expr, 7)
+	c.fieldMap["id"] = c.ID
+	c.fieldMap["category_id"] = c.CategoryID
+	c.fieldMap["locale"] = c.Locale
+	c.fieldMap["name"] = c.Name
+	c.fieldMap["description"] = c.Description
+	c.fieldMap["created_at"] = c.CreatedAt
+	c.fieldMap["updated_at"] = c.UpdatedAt
+}
+
+func (c categoryTranslation) clone(db *gorm.DB) categoryTranslation {
+	c.categoryTranslationDo.ReplaceConnPool(db.Statement.ConnPool)
+	return c
+}
+
+func (c categoryTranslation) replaceDB(db *gorm.DB) categoryTranslation {
+	c.categoryTranslationDo.ReplaceDB(db)
+	return c
+}
+
+type categoryTranslationDo struct{ gen.DO }
+
+type ICategoryTranslationDo interface {
+	gen.SubQuery
+	Debug() ICategoryTranslationDo
+	WithContext(ctx context.Context) ICategoryTranslationDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ICategoryTranslationDo
+	WriteDB() ICategoryTranslationDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ICategoryTranslationDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ICategoryTranslationDo
+	Not(conds ...gen.Condition) ICategoryTranslationDo
+	Or(conds ...gen.Condition) ICategoryTranslationDo
+	Select(conds ...field.Expr) ICategoryTranslationDo
+	Where(conds ...gen.Condition) ICategoryTranslationDo
+	Order(conds ...field.Expr) ICategoryTranslationDo
+	Distinct(cols ...field.Expr) ICategoryTranslationDo
+	Omit(cols ...field.Expr) ICategoryTranslationDo
+	Join(table schema.Tabler, on ...field.Expr) ICategoryTranslationDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ICategoryTranslationDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ICategoryTranslationDo
+	Group(cols ...field.Expr) ICategoryTranslationDo
+	Having(conds ...gen.Condition) ICategoryTranslationDo
+	Limit(limit int) ICategoryTranslationDo
+	Offset(offset int) ICategoryTranslationDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ICategoryTranslationDo
+	Unscoped() ICategoryTranslationDo
+	Create(values ...*model.CategoryTranslation) error
+	CreateInBatches(values []*model.CategoryTranslation, batchSize int) error
+	Save(values ...*model.CategoryTranslation) error
+	First() (*model.CategoryTranslation, error)
+	Take() (*model.CategoryTranslation, error)
+	Last() (*model.CategoryTranslation, error)
+	Find() ([]*model.CategoryTranslation, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.CategoryTranslation, err error)
+	FindInBatches(result *[]*model.CategoryTranslation, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.CategoryTranslation) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ICategoryTranslationDo
+	Assign(attrs ...field.AssignExpr) ICategoryTranslationDo
+	Joins(fields ...field.RelationField) ICategoryTranslationDo
+	Preload(fields ...field.RelationField) ICategoryTranslationDo
+	FirstOrInit() (*model.CategoryTranslation, error)
+	FirstOrCreate() (*model.CategoryTranslation, error)
+	FindByPage(offset int, limit int) (result []*model.CategoryTranslation, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ICategoryTranslationDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (c categoryTranslationDo) Debug() ICategoryTranslationDo {
+	return c.withDO(c.DO.Debug())
+}
+
+func (c categoryTranslationDo) WithContext(ctx context.Context) ICategoryTranslationDo {
+	return c.withDO(c.DO.WithContext(ctx))
+}
+
+func (c categoryTranslationDo) ReadDB() ICategoryTranslationDo {
+	return c.Clauses(dbresolver.Read)
+}
+
+func (c categoryTranslationDo) WriteDB() ICategoryTranslationDo {
+	return c.Clauses(dbresolver.Write)
+}
+
+func (c categoryTranslationDo) Session(config *gorm.Session) ICategoryTranslationDo {
+	return c.withDO(c.DO.Session(config))
+}
+
+func (c categoryTranslationDo) Clauses(conds ...clause.Expression) ICategoryTranslationDo {
+	return c.withDO(c.DO.Clauses(conds...))
+}
+
+func (c categoryTranslationDo) Returning(value interface{}, columns ...string) ICategoryTranslationDo {
+	return c.withDO(c.DO.Returning(value, columns...))
+}
+
+func (c categoryTranslationDo) Not(conds ...gen.Condition) ICategoryTranslationDo {
+	return c.withDO(c.DO.Not(conds...))
+}
+
+func (c categoryTranslationDo) Or(conds ...gen.Condition) ICategoryTranslationDo {
+	return c.withDO(c.DO.Or(conds...))
+}
+
+func (c categoryTranslationDo) Select(conds ...field.Expr) ICategoryTranslationDo {
+	return c.withDO(c.DO.Select(conds...))
+}
+
+func (c categoryTranslationDo) Where(conds ...gen.Condition) ICategoryTranslationDo {
+	return c.withDO(c.DO.Where(conds...))
+}
+
+func (c categoryTranslationDo) Order(conds ...field.Expr) ICategoryTranslationDo {
+	return c.withDO(c.DO.Order(conds...))
+}
+
+func (c categoryTranslationDo) Distinct(cols ...field.Expr) ICategoryTranslationDo {
+	return c.withDO(c.DO.Distinct(cols...))
+}
+
+func (c categoryTranslationDo) Omit(cols ...field.Expr) ICategoryTranslationDo {
+	return c.withDO(c.DO.Omit(cols...))
+}
+
+func (c categoryTranslationDo) Join(table schema.Tabler, on ...field.Expr) ICategoryTranslationDo {
+	return c.withDO(c.DO.Join(table, on...))
+}
+
+func (c categoryTranslationDo) LeftJoin(table schema.Tabler, on ...field.Expr) ICategoryTranslationDo {
+	return c.withDO(c.DO.LeftJoin(table, on...))
+}
+
+func (c categoryTranslationDo) RightJoin(table schema.Tabler, on ...field.Expr) ICategoryTranslationDo {
+	return c.withDO(c.DO.RightJoin(table, on...))
+}
+
+func (c categoryTranslationDo) Group(cols ...field.Expr) ICategoryTranslationDo {
+	return c.withDO(c.DO.Group(cols...))
+}
+
+func (c categoryTranslationDo) Having(conds ...gen.Condition) ICategoryTranslationDo {
+	return c.withDO(c.DO.Having(conds...))
+}
+
+func (c categoryTranslationDo) Limit(limit int) ICategoryTranslationDo {
+	return c.withDO(c.DO.Limit(limit))
+}
+
+func (c categoryTranslationDo) Offset(offset int) ICategoryTranslationDo {
+	return c.withDO(c.DO.Offset(offset))
+}
+
+func (c categoryTranslationDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ICategoryTranslationDo {
+	return c.withDO(c.DO.Scopes(funcs...))
+}
+
+func (c categoryTranslationDo) Unscoped() ICategoryTranslationDo {
+	return c.withDO(c.DO.Unscoped())
+}
+
+func (c categoryTranslationDo) Create(values ...*model.CategoryTranslation) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return c.DO.Create(values)
+}
+
+func (c categoryTranslationDo) CreateInBatches(values []*model.CategoryTranslation, batchSize int) error {
+	return c.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (c categoryTranslationDo) Save(values ...*model.CategoryTranslation) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return c.DO.Save(values)
+}
+
+func (c categoryTranslationDo) First() (*model.CategoryTranslation, error) {
+	if result, err := c.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.CategoryTranslation), nil
+	}
+}
+
+func (c categoryTranslationDo) Take() (*model.CategoryTranslation, error) {
+	if result, err := c.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.CategoryTranslation), nil
+	}
+}
+
+func (c categoryTranslationDo) Last() (*model.CategoryTranslation, error) {
+	if result, err := c.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.CategoryTranslation), nil
+	}
+}
+
+func (c categoryTranslationDo) Find() ([]*model.CategoryTranslation, error) {
+	result, err := c.DO.Find()
+	return result.([]*model.CategoryTranslation), err
+}
+
+func (c categoryTranslationDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.CategoryTranslation, err error) {
+	buf := make([]*model.CategoryTranslation, 0, batchSize)
+	err = c.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (c categoryTranslationDo) FindInBatches(result *[]*model.CategoryTranslation, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return c.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (c categoryTranslationDo) Attrs(attrs ...field.AssignExpr) ICategoryTranslationDo {
+	return c.withDO(c.DO.Attrs(attrs...))
+}
+
+func (c categoryTranslationDo) Assign(attrs ...field.AssignExpr) ICategoryTranslationDo {
+	return c.withDO(c.DO.Assign(attrs...))
+}
+
+func (c categoryTranslationDo) Joins(fields ...field.RelationField) ICategoryTranslationDo {
+	for _, _f := range fields {
+		c = *c.withDO(c.DO.Joins(_f))
+	}
+	return &c
+}
+
+func (c categoryTranslationDo) Preload(fields ...field.RelationField) ICategoryTranslationDo {
+	for _, _f := range fields {
+		c = *c.withDO(c.DO.Preload(_f))
+	}
+	return &c
+}
+
+func (c categoryTranslationDo) FirstOrInit() (*model.CategoryTranslation, error) {
+	if result, err := c.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.CategoryTranslation), nil
+	}
+}
+
+func (c categoryTranslationDo) FirstOrCreate() (*model.CategoryTranslation, error) {
+	if result, err := c.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.CategoryTranslation), nil
+	}
+}
+
+func (c categoryTranslationDo) FindByPage(offset int, limit int) (result []*model.CategoryTranslation, count int64, err error) {
+	result, err = c.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = c.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (c categoryTranslationDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = c.Count()
+	if err != nil {
+		return
+	}
+
+	err = c.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (c categoryTranslationDo) Scan(result interface{}) (err error) {
+	return c.DO.Scan(result)
+}
+
+func (c categoryTranslationDo) Delete(models ...*model.CategoryTranslation) (result gen.ResultInfo, err error) {
+	return c.DO.Delete(models)
+}
+
+func (c *categoryTranslationDo) withDO(do gen.Dao) *categoryTranslationDo {
+	c.DO = *do.(*gen.DO)
+	return c
+}
@@ -0,0 +1,391 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newCollectionItem(db *gorm.DB, opts ...gen.DOOption) collectionItem {
+	_collectionItem := collectionItem{}
+
+	_collectionItem.collectionItemDo.UseDB(db, opts...)
+	_collectionItem.collectionItemDo.UseModel(&model.CollectionItem{})
+
+	tableName := _collectionItem.collectionItemDo.TableName()
+	_collectionItem.ALL = field.NewAsterisk(tableName)
+	_collectionItem.CollectionID = field.NewInt32(tableName, "collection_id")
+	_collectionItem.TemplateID = field.NewInt32(tableName, "template_id")
+	_collectionItem.SortOrder = field.NewInt32(tableName, "sort_order")
+
+	_collectionItem.fillFieldMap()
+
+	return _collectionItem
+}
+
+type collectionItem struct {
+	collectionItemDo
+
+	ALL          field.Asterisk
+	CollectionID field.Int32
+	TemplateID   field.Int32
+	SortOrder    field.Int32
+
+	fieldMap map[string]field.Expr
+}
+
+func (c collectionItem) Table(newTableName string) *collectionItem {
+	c.collectionItemDo.UseTable(newTableName)
+	return c.updateTableName(newTableName)
+}
+
+func (c collectionItem) As(alias string) *collectionItem {
+	c.collectionItemDo.DO = *(c.collectionItemDo.As(alias).(*gen.DO))
+	return c.updateTableName(alias)
+}
+
+func (c *collectionItem) updateTableName(table string) *collectionItem {
+	c.ALL = field.NewAsterisk(table)
+	c.CollectionID = field.NewInt32(table, "collection_id")
+	c.TemplateID = field.NewInt32(table, "template_id")
+	c.SortOrder = field.NewInt32(table, "sort_order")
+
+	c.fillFieldMap()
+
+	return c
+}
+
+func (c *collectionItem) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := c.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (c *collectionItem) fillFieldMap() {
+	c.fieldMap = make(map[string]field.Expr, 3)
+	c.fieldMap["collection_id"] = c.CollectionID
+	c.fieldMap["template_id"] = c.TemplateID
+	c.fieldMap["sort_order"] = c.SortOrder
+}
+
+func (c collectionItem) clone(db *gorm.DB) collectionItem {
+	c.collectionItemDo.ReplaceConnPool(db.Statement.ConnPool)
+	return c
+}
+
+func (c collectionItem) replaceDB(db *gorm.DB) collectionItem {
+	c.collectionItemDo.ReplaceDB(db)
+	return c
+}
+
+type collectionItemDo struct{ gen.DO }
+
+type ICollectionItemDo interface {
+	gen.SubQuery
+	Debug() ICollectionItemDo
+	WithContext(ctx context.Context) ICollectionItemDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ICollectionItemDo
+	WriteDB() ICollectionItemDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ICollectionItemDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ICollectionItemDo
+	Not(conds ...gen.Condition) ICollectionItemDo
+	Or(conds ...gen.Condition) ICollectionItemDo
+	Select(conds ...field.Expr) ICollectionItemDo
+	Where(conds ...gen.Condition) ICollectionItemDo
+	Order(conds ...field.Expr) ICollectionItemDo
+	Distinct(cols ...field.Expr) ICollectionItemDo
+	Omit(cols ...field.Expr) ICollectionItemDo
+	Join(table schema.Tabler, on ...field.Expr) ICollectionItemDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ICollectionItemDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ICollectionItemDo
+	Group(cols ...field.Expr) ICollectionItemDo
+	Having(conds ...gen.Condition) ICollectionItemDo
+	Limit(limit int) ICollectionItemDo
+	Offset(offset int) ICollectionItemDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ICollectionItemDo
+	Unscoped() ICollectionItemDo
+	Create(values ...*model.CollectionItem) error
+	CreateInBatches(values []*model.CollectionItem, batchSize int) error
+	Save(values ...*model.CollectionItem) error
+	First() (*model.CollectionItem, error)
+	Take() (*model.CollectionItem, error)
+	Last() (*model.CollectionItem, error)
+	Find() ([]*model.CollectionItem, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.CollectionItem, err error)
+	FindInBatches(result *[]*model.CollectionItem, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.CollectionItem) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ICollectionItemDo
+	Assign(attrs ...field.AssignExpr) ICollectionItemDo
+	Joins(fields ...field.RelationField) ICollectionItemDo
+	Preload(fields ...field.RelationField) ICollectionItemDo
+	FirstOrInit() (*model.CollectionItem, error)
+	FirstOrCreate() (*model.CollectionItem, error)
+	FindByPage(offset int, limit int) (result []*model.CollectionItem, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ICollectionItemDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (c collectionItemDo) Debug() ICollectionItemDo {
+	return c.withDO(c.DO.Debug())
+}
+
+func (c collectionItemDo) WithContext(ctx context.Context) ICollectionItemDo {
+	return c.withDO(c.DO.WithContext(ctx))
+}
+
+func (c collectionItemDo) ReadDB() ICollectionItemDo {
+	return c.Clauses(dbresolver.Read)
+}
+
+func (c collectionItemDo) WriteDB() ICollectionItemDo {
+	return c.Clauses(dbresolver.Write)
+}
+
+func (c collectionItemDo) Session(config *gorm.Session) ICollectionItemDo {
+	return c.withDO(c.DO.Session(config))
+}
+
+func (c collectionItemDo) Clauses(conds ...clause.Expression) ICollectionItemDo {
+	return c.withDO(c.DO.Clauses(conds...))
+}
+
+func (c collectionItemDo) Returning(value interface{}, columns ...string) ICollectionItemDo {
+	return c.withDO(c.DO.Returning(value, columns...))
+}
+
+func (c collectionItemDo) Not(conds ...gen.Condition) ICollectionItemDo {
+	return c.withDO(c.DO.Not(conds...))
+}
+
+func (c collectionItemDo) Or(conds ...gen.Condition) ICollectionItemDo {
+	return c.withDO(c.DO.Or(conds...))
+}
+
+func (c collectionItemDo) Select(conds ...field.Expr) ICollectionItemDo {
+	return c.withDO(c.DO.Select(conds...))
+}
+
+func (c collectionItemDo) Where(conds ...gen.Condition) ICollectionItemDo {
+	return c.withDO(c.DO.Where(conds...))
+}
+
+func (c collectionItemDo) Order(conds ...field.Expr) ICollectionItemDo {
+	return c.withDO(c.DO.Order(conds...))
+}
+
+func (c collectionItemDo) Distinct(cols ...field.Expr) ICollectionItemDo {
+	return c.withDO(c.DO.Distinct(cols...))
+}
+
+func (c collectionItemDo) Omit(cols ...field.Expr) ICollectionItemDo {
+	return c.withDO(c.DO.Omit(cols...))
+}
+
+func (c collectionItemDo) Join(table schema.Tabler, on ...field.Expr) ICollectionItemDo {
+	return c.withDO(c.DO.Join(table, on...))
+}
+
+func (c collectionItemDo) LeftJoin(table schema.Tabler, on ...field.Expr) ICollectionItemDo {
+	return c.withDO(c.DO.LeftJoin(table, on...))
+}
+
+func (c collectionItemDo) RightJoin(table schema.Tabler, on ...field.Expr) ICollectionItemDo {
+	return c.withDO(c.DO.RightJoin(table, on...))
+}
+
+func (c collectionItemDo) Group(cols ...field.Expr) ICollectionItemDo {
+	return c.withDO(c.DO.Group(cols...))
+}
+
+func (c collectionItemDo) Having(conds ...gen.Condition) ICollectionItemDo {
+	return c.withDO(c.DO.Having(conds...))
+}
+
+func (c collectionItemDo) Limit(limit int) ICollectionItemDo {
+	return c.withDO(c.DO.Limit(limit))
+}
+
+func (c collectionItemDo) Offset(offset int) ICollectionItemDo {
+	return c.withDO(c.DO.Offset(offset))
+}
+
+func (c collectionItemDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ICollectionItemDo {
+	return c.withDO(c.DO.Scopes(funcs...))
+}
+
+func (c collectionItemDo) Unscoped() ICollectionItemDo {
+	return c.withDO(c.DO.Unscoped())
+}
+
+func (c collectionItemDo) Create(values ...*model.CollectionItem) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return c.DO.Create(values)
+}
+
+func (c collectionItemDo) CreateInBatches(values []*model.CollectionItem, batchSize int) error {
+	return c.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (c collectionItemDo) Save(values ...*model.CollectionItem) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return c.DO.Save(values)
+}
+
+func (c collectionItemDo) First() (*model.CollectionItem, error) {
+	if result, err := c.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.CollectionItem), nil
+	}
+}
+
+func (c collectionItemDo) Take() (*model.CollectionItem, error) {
+	if result, err := c.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.CollectionItem), nil
+	}
+}
+
+func (c collectionItemDo) Last() (*model.CollectionItem, error) {
+	if result, err := c.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.CollectionItem), nil
+	}
+}
+
+func (c collectionItemDo) Find() ([]*model.CollectionItem, error) {
+	result, err := c.DO.Find()
+	return result.([]*model.CollectionItem), err
+}
+
+func (c collectionItemDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.CollectionItem, err error) {
+	buf := make([]*model.CollectionItem, 0, batchSize)
+	err = c.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (c collectionItemDo) FindInBatches(result *[]*model.CollectionItem, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return c.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (c collectionItemDo) Attrs(attrs ...field.AssignExpr) ICollectionItemDo {
+	return c.withDO(c.DO.Attrs(attrs...))
+}
+
+func (c collectionItemDo) Assign(attrs ...field.AssignExpr) ICollectionItemDo {
+	return c.withDO(c.DO.Assign(attrs...))
+}
+
+func (c collectionItemDo) Joins(fields ...field.RelationField) ICollectionItemDo {
+	for _, _f := range fields {
+		c = *c.withDO(c.DO.Joins(_f))
+	}
+	return &c
+}
+
+func (c collectionItemDo) Preload(fields ...field.RelationField) ICollectionItemDo {
+	for _, _f := range fields {
+		c = *c.withDO(c.DO.Preload(_f))
+	}
+	return &c
+}
+
+func (c collectionItemDo) FirstOrInit() (*model.CollectionItem, error) {
+	if result, err := c.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.CollectionItem), nil
+	}
+}
+
+func (c collectionItemDo) FirstOrCreate() (*model.CollectionItem, error) {
+	if result, err := c.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.CollectionItem), nil
+	}
+}
+
+func (c collectionItemDo) FindByPage(offset int, limit int) (result []*model.CollectionItem, count int64, err error) {
+	result, err = c.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = c.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (c collectionItemDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = c.Count()
+	if err != nil {
+		return
+	}
+
+	err = c.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (c collectionItemDo) Scan(result interface{}) (err error) {
+	return c.DO.Scan(result)
+}
+
+func (c collectionItemDo) Delete(models ...*model.CollectionItem) (result gen.ResultInfo, err error) {
+	return c.DO.Delete(models)
+}
+
+func (c *collectionItemDo) withDO(do gen.Dao) *collectionItemDo {
+	c.DO = *do.(*gen.DO)
+	return c
+}
@@ -16,59 +16,169 @@ import (
 )
 
 var (
-	Q                 = new(Query)
-	Category          *category
-	EmailVerification *emailVerification
-	Template          *template
-	TemplateDetail    *templateDetail
-	User              *user
-	UserIdentity      *userIdentity
+	Q                      = new(Query)
+	AuditLog               *auditLog
+	BillingEvent           *billingEvent
+	Category               *category
+	CategoryTranslation    *categoryTranslation
+	Collection             *collection
+	CollectionItem         *collectionItem
+	EmailVerification      *emailVerification
+	GenerationUsage        *generationUsage
+	Organization           *organization
+	OrganizationInvitation *organizationInvitation
+	OrganizationMember     *organizationMember
+	Referral               *referral
+	Tag                    *tag
+	Template               *template
+	TemplateActivity       *templateActivity
+	TemplateCopy           *templateCopy
+	TemplateDetail         *templateDetail
+	TemplateEmbedding      *templateEmbedding
+	TemplateFavorite       *templateFavorite
+	TemplateRating         *templateRating
+	TemplateRevision       *templateRevision
+	TemplateShare          *templateShare
+	TemplateSlugRedirect   *templateSlugRedirect
+	TemplateTag            *templateTag
+	TemplateTranslation    *templateTranslation
+	User                   *user
+	UserIdentity           *userIdentity
+	UserTemplate           *userTemplate
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	AuditLog = &Q.AuditLog
+	BillingEvent = &Q.BillingEvent
 	Category = &Q.Category
+	CategoryTranslation = &Q.CategoryTranslation
+	Collection = &Q.Collection
+	CollectionItem = &Q.CollectionItem
 	EmailVerification = &Q.EmailVerification
+	GenerationUsage = &Q.GenerationUsage
+	Organization = &Q.Organization
+	OrganizationInvitation = &Q.OrganizationInvitation
+	OrganizationMember = &Q.OrganizationMember
+	Referral = &Q.Referral
+	Tag = &Q.Tag
 	Template = &Q.Template
+	TemplateActivity = &Q.TemplateActivity
+	TemplateCopy = &Q.TemplateCopy
 	TemplateDetail = &Q.TemplateDetail
+	TemplateEmbedding = &Q.TemplateEmbedding
+	TemplateFavorite = &Q.TemplateFavorite
+	TemplateRating = &Q.TemplateRating
+	TemplateRevision = &Q.TemplateRevision
+	TemplateShare = &Q.TemplateShare
+	TemplateSlugRedirect = &Q.TemplateSlugRedirect
+	TemplateTag = &Q.TemplateTag
+	TemplateTranslation = &Q.TemplateTranslation
 	User = &Q.User
 	UserIdentity = &Q.UserIdentity
+	UserTemplate = &Q.UserTemplate
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:                db,
-		Category:          newCategory(db, opts...),
-		EmailVerification: newEmailVerification(db, opts...),
-		Template:          newTemplate(db, opts...),
-		TemplateDetail:    newTemplateDetail(db, opts...),
-		User:              newUser(db, opts...),
-		UserIdentity:      newUserIdentity(db, opts...),
+		db:                     db,
+		AuditLog:               newAuditLog(db, opts...),
+		BillingEvent:           newBillingEvent(db, opts...),
+		Category:               newCategory(db, opts...),
+		CategoryTranslation:    newCategoryTranslation(db, opts...),
+		Collection:             newCollection(db, opts...),
+		CollectionItem:         newCollectionItem(db, opts...),
+		EmailVerification:      newEmailVerification(db, opts...),
+		GenerationUsage:        newGenerationUsage(db, opts...),
+		Organization:           newOrganization(db, opts...),
+		OrganizationInvitation: newOrganizationInvitation(db, opts...),
+		OrganizationMember:     newOrganizationMember(db, opts...),
+		Referral:               newReferral(db, opts...),
+		Tag:                    newTag(db, opts...),
+		Template:               newTemplate(db, opts...),
+		TemplateActivity:       newTemplateActivity(db, opts...),
+		TemplateCopy:           newTemplateCopy(db, opts...),
+		TemplateDetail:         newTemplateDetail(db, opts...),
+		TemplateEmbedding:      newTemplateEmbedding(db, opts...),
+		TemplateFavorite:       newTemplateFavorite(db, opts...),
+		TemplateRating:         newTemplateRating(db, opts...),
+		TemplateRevision:       newTemplateRevision(db, opts...),
+		TemplateShare:          newTemplateShare(db, opts...),
+		TemplateSlugRedirect:   newTemplateSlugRedirect(db, opts...),
+		TemplateTag:            newTemplateTag(db, opts...),
+		TemplateTranslation:    newTemplateTranslation(db, opts...),
+		User:                   newUser(db, opts...),
+		UserIdentity:           newUserIdentity(db, opts...),
+		UserTemplate:           newUserTemplate(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	Category          category
-	EmailVerification emailVerification
-	Template          template
-	TemplateDetail    templateDetail
-	User              user
-	UserIdentity      userIdentity
+	AuditLog               auditLog
+	BillingEvent           billingEvent
+	Category               category
+	CategoryTranslation    categoryTranslation
+	Collection             collection
+	CollectionItem         collectionItem
+	EmailVerification      emailVerification
+	GenerationUsage        generationUsage
+	Organization           organization
+	OrganizationInvitation organizationInvitation
+	OrganizationMember     organizationMember
+	Referral               referral
+	Tag                    tag
+	Template               template
+	TemplateActivity       templateActivity
+	TemplateCopy           templateCopy
+	TemplateDetail         templateDetail
+	TemplateEmbedding      templateEmbedding
+	TemplateFavorite       templateFavorite
+	TemplateRating         templateRating
+	TemplateRevision       templateRevision
+	TemplateShare          templateShare
+	TemplateSlugRedirect   templateSlugRedirect
+	TemplateTag            templateTag
+	TemplateTranslation    templateTranslation
+	User                   user
+	UserIdentity           userIdentity
+	UserTemplate           userTemplate
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:                db,
-		Category:          q.Category.clone(db),
-		EmailVerification: q.EmailVerification.clone(db),
-		Template:          q.Template.clone(db),
-		TemplateDetail:    q.TemplateDetail.clone(db),
-		User:              q.User.clone(db),
-		UserIdentity:      q.UserIdentity.clone(db),
+		db:                     db,
+		AuditLog:               q.AuditLog.clone(db),
+		BillingEvent:           q.BillingEvent.clone(db),
+		Category:               q.Category.clone(db),
+		CategoryTranslation:    q.CategoryTranslation.clone(db),
+		Collection:             q.Collection.clone(db),
+		CollectionItem:         q.CollectionItem.clone(db),
+		EmailVerification:      q.EmailVerification.clone(db),
+		GenerationUsage:        q.GenerationUsage.clone(db),
+		Organization:           q.Organization.clone(db),
+		OrganizationInvitation: q.OrganizationInvitation.clone(db),
+		OrganizationMember:     q.OrganizationMember.clone(db),
+		Referral:               q.Referral.clone(db),
+		Tag:                    q.Tag.clone(db),
+		Template:               q.Template.clone(db),
+		TemplateActivity:       q.TemplateActivity.clone(db),
+		TemplateCopy:           q.TemplateCopy.clone(db),
+		TemplateDetail:         q.TemplateDetail.clone(db),
+		TemplateEmbedding:      q.TemplateEmbedding.clone(db),
+		TemplateFavorite:       q.TemplateFavorite.clone(db),
+		TemplateRating:         q.TemplateRating.clone(db),
+		TemplateRevision:       q.TemplateRevision.clone(db),
+		TemplateShare:          q.TemplateShare.clone(db),
+		TemplateSlugRedirect:   q.TemplateSlugRedirect.clone(db),
+		TemplateTag:            q.TemplateTag.clone(db),
+		TemplateTranslation:    q.TemplateTranslation.clone(db),
+		User:                   q.User.clone(db),
+		UserIdentity:           q.UserIdentity.clone(db),
+		UserTemplate:           q.UserTemplate.clone(db),
 	}
 }
 
@@ -82,33 +192,99 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:                db,
-		Category:          q.Category.replaceDB(db),
-		EmailVerification: q.EmailVerification.replaceDB(db),
-		Template:          q.Template.replaceDB(db),
-		TemplateDetail:    q.TemplateDetail.replaceDB(db),
-		User:              q.User.replaceDB(db),
-		UserIdentity:      q.UserIdentity.replaceDB(db),
+		db:                     db,
+		AuditLog:               q.AuditLog.replaceDB(db),
+		BillingEvent:           q.BillingEvent.replaceDB(db),
+		Category:               q.Category.replaceDB(db),
+		CategoryTranslation:    q.CategoryTranslation.replaceDB(db),
+		Collection:             q.Collection.replaceDB(db),
+		CollectionItem:         q.CollectionItem.replaceDB(db),
+		EmailVerification:      q.EmailVerification.replaceDB(db),
+		GenerationUsage:        q.GenerationUsage.replaceDB(db),
+		Organization:           q.Organization.replaceDB(db),
+		OrganizationInvitation: q.OrganizationInvitation.replaceDB(db),
+		OrganizationMember:     q.OrganizationMember.replaceDB(db),
+		Referral:               q.Referral.replaceDB(db),
+		Tag:                    q.Tag.replaceDB(db),
+		Template:               q.Template.replaceDB(db),
+		TemplateActivity:       q.TemplateActivity.replaceDB(db),
+		TemplateCopy:           q.TemplateCopy.replaceDB(db),
+		TemplateDetail:         q.TemplateDetail.replaceDB(db),
+		TemplateEmbedding:      q.TemplateEmbedding.replaceDB(db),
+		TemplateFavorite:       q.TemplateFavorite.replaceDB(db),
+		TemplateRating:         q.TemplateRating.replaceDB(db),
+		TemplateRevision:       q.TemplateRevision.replaceDB(db),
+		TemplateShare:          q.TemplateShare.replaceDB(db),
+		TemplateSlugRedirect:   q.TemplateSlugRedirect.replaceDB(db),
+		TemplateTag:            q.TemplateTag.replaceDB(db),
+		TemplateTranslation:    q.TemplateTranslation.replaceDB(db),
+		User:                   q.User.replaceDB(db),
+		UserIdentity:           q.UserIdentity.replaceDB(db),
+		UserTemplate:           q.UserTemplate.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	Category          ICategoryDo
-	EmailVerification IEmailVerificationDo
-	Template          ITemplateDo
-	TemplateDetail    ITemplateDetailDo
-	User              IUserDo
-	UserIdentity      IUserIdentityDo
+	AuditLog               IAuditLogDo
+	BillingEvent           IBillingEventDo
+	Category               ICategoryDo
+	CategoryTranslation    ICategoryTranslationDo
+	Collection             ICollectionDo
+	CollectionItem         ICollectionItemDo
+	EmailVerification      IEmailVerificationDo
+	GenerationUsage        IGenerationUsageDo
+	Organization           IOrganizationDo
+	OrganizationInvitation IOrganizationInvitationDo
+	OrganizationMember     IOrganizationMemberDo
+	Referral               IReferralDo
+	Tag                    ITagDo
+	Template               ITemplateDo
+	TemplateActivity       ITemplateActivityDo
+	TemplateCopy           ITemplateCopyDo
+	TemplateDetail         ITemplateDetailDo
+	TemplateEmbedding      ITemplateEmbeddingDo
+	TemplateFavorite       ITemplateFavoriteDo
+	TemplateRating         ITemplateRatingDo
+	TemplateRevision       ITemplateRevisionDo
+	TemplateShare          ITemplateShareDo
+	TemplateSlugRedirect   ITemplateSlugRedirectDo
+	TemplateTag            ITemplateTagDo
+	TemplateTranslation    ITemplateTranslationDo
+	User                   IUserDo
+	UserIdentity           IUserIdentityDo
+	UserTemplate           IUserTemplateDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		Category:          q.Category.WithContext(ctx),
-		EmailVerification: q.EmailVerification.WithContext(ctx),
-		Template:          q.Template.WithContext(ctx),
-		TemplateDetail:    q.TemplateDetail.WithContext(ctx),
-		User:              q.User.WithContext(ctx),
-		UserIdentity:      q.UserIdentity.WithContext(ctx),
+		AuditLog:               q.AuditLog.WithContext(ctx),
+		BillingEvent:           q.BillingEvent.WithContext(ctx),
+		Category:               q.Category.WithContext(ctx),
+		CategoryTranslation:    q.CategoryTranslation.WithContext(ctx),
+		Collection:             q.Collection.WithContext(ctx),
+		CollectionItem:         q.CollectionItem.WithContext(ctx),
+		EmailVerification:      q.EmailVerification.WithContext(ctx),
+		GenerationUsage:        q.GenerationUsage.WithContext(ctx),
+		Organization:           q.Organization.WithContext(ctx),
+		OrganizationInvitation: q.OrganizationInvitation.WithContext(ctx),
+		OrganizationMember:     q.OrganizationMember.WithContext(ctx),
+		Referral:               q.Referral.WithContext(ctx),
+		Tag:                    q.Tag.WithContext(ctx),
+		Template:               q.Template.WithContext(ctx),
+		TemplateActivity:       q.TemplateActivity.WithContext(ctx),
+		TemplateCopy:           q.TemplateCopy.WithContext(ctx),
+		TemplateDetail:         q.TemplateDetail.WithContext(ctx),
+		TemplateEmbedding:      q.TemplateEmbedding.WithContext(ctx),
+		TemplateFavorite:       q.TemplateFavorite.WithContext(ctx),
+		TemplateRating:         q.TemplateRating.WithContext(ctx),
+		TemplateRevision:       q.TemplateRevision.WithContext(ctx),
+		TemplateShare:          q.TemplateShare.WithContext(ctx),
+		TemplateSlugRedirect:   q.TemplateSlugRedirect.WithContext(ctx),
+		TemplateTag:            q.TemplateTag.WithContext(ctx),
+		TemplateTranslation:    q.TemplateTranslation.WithContext(ctx),
+		User:                   q.User.WithContext(ctx),
+		UserIdentity:           q.UserIdentity.WithContext(ctx),
+		UserTemplate:           q.UserTemplate.WithContext(ctx),
 	}
 }
 
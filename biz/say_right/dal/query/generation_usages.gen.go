@@ -0,0 +1,435 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newGenerationUsage(db *gorm.DB, opts ...gen.DOOption) generationUsage {
+	_generationUsage := generationUsage{}
+
+	_generationUsage.generationUsageDo.UseDB(db, opts...)
+	_generationUsage.generationUsageDo.UseModel(&model.GenerationUsage{})
+
+	tableName := _generationUsage.generationUsageDo.TableName()
+	_generationUsage.ALL = field.NewAsterisk(tableName)
+	_generationUsage.ID = field.NewInt32(tableName, "id")
+	_generationUsage.UserID = field.NewInt32(tableName, "user_id")
+	_generationUsage.TemplateID = field.NewInt32(tableName, "template_id")
+	_generationUsage.Kind = field.NewString(tableName, "kind")
+	_generationUsage.Provider = field.NewString(tableName, "provider")
+	_generationUsage.Model = field.NewString(tableName, "model")
+	_generationUsage.Tone = field.NewString(tableName, "tone")
+	_generationUsage.Status = field.NewString(tableName, "status")
+	_generationUsage.InputTokens = field.NewInt32(tableName, "input_tokens")
+	_generationUsage.OutputTokens = field.NewInt32(tableName, "output_tokens")
+	_generationUsage.CostMicros = field.NewInt64(tableName, "cost_micros")
+	_generationUsage.LatencyMs = field.NewInt32(tableName, "latency_ms")
+	_generationUsage.Error = field.NewString(tableName, "error")
+	_generationUsage.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_generationUsage.fillFieldMap()
+
+	return _generationUsage
+}
+
+type generationUsage struct {
+	generationUsageDo
+
+	ALL          field.Asterisk
+	ID           field.Int32
+	UserID       field.Int32
+	TemplateID   field.Int32
+	Kind         field.String
+	Provider     field.String
+	Model        field.String
+	Tone         field.String
+	Status       field.String
+	InputTokens  field.Int32
+	OutputTokens field.Int32
+	CostMicros   field.Int64
+	LatencyMs    field.Int32
+	Error        field.String
+	CreatedAt    field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (g generationUsage) Table(newTableName string) *generationUsage {
+	g.generationUsageDo.UseTable(newTableName)
+	return g.updateTableName(newTableName)
+}
+
+func (g generationUsage) As(alias string) *generationUsage {
+	g.generationUsageDo.DO = *(g.generationUsageDo.As(alias).(*gen.DO))
+	return g.updateTableName(alias)
+}
+
+func (g *generationUsage) updateTableName(table string) *generationUsage {
+	g.ALL = field.NewAsterisk(table)
+	g.ID = field.NewInt32(table, "id")
+	g.UserID = field.NewInt32(table, "user_id")
+	g.TemplateID = field.NewInt32(table, "template_id")
+	g.Kind = field.NewString(table, "kind")
+	g.Provider = field.NewString(table, "provider")
+	g.Model = field.NewString(table, "model")
+	g.Tone = field.NewString(table, "tone")
+	g.Status = field.NewString(table, "status")
+	g.InputTokens = field.NewInt32(table, "input_tokens")
+	g.OutputTokens = field.NewInt32(table, "output_tokens")
+	g.CostMicros = field.NewInt64(table, "cost_micros")
+	g.LatencyMs = field.NewInt32(table, "latency_ms")
+	g.Error = field.NewString(table, "error")
+	g.CreatedAt = field.NewTime(table, "created_at")
+
+	g.fillFieldMap()
+
+	return g
+}
+
+func (g *generationUsage) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := g.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (g *generationUsage) fillFieldMap() {
+	g.fieldMap = make(map[string]field.Expr, 14)
+	g.fieldMap["id"] = g.ID
+	g.fieldMap["user_id"] = g.UserID
+	g.fieldMap["template_id"] = g.TemplateID
+	g.fieldMap["kind"] = g.Kind
+	g.fieldMap["provider"] = g.Provider
+	g.fieldMap["model"] = g.Model
+	g.fieldMap["tone"] = g.Tone
+	g.fieldMap["status"] = g.Status
+	g.fieldMap["input_tokens"] = g.InputTokens
+	g.fieldMap["output_tokens"] = g.OutputTokens
+	g.fieldMap["cost_micros"] = g.CostMicros
+	g.fieldMap["latency_ms"] = g.LatencyMs
+	g.fieldMap["error"] = g.Error
+	g.fieldMap["created_at"] = g.CreatedAt
+}
+
+func (g generationUsage) clone(db *gorm.DB) generationUsage {
+	g.generationUsageDo.ReplaceConnPool(db.Statement.ConnPool)
+	return g
+}
+
+func (g generationUsage) replaceDB(db *gorm.DB) generationUsage {
+	g.generationUsageDo.ReplaceDB(db)
+	return g
+}
+
+type generationUsageDo struct{ gen.DO }
+
+type IGenerationUsageDo interface {
+	gen.SubQuery
+	Debug() IGenerationUsageDo
+	WithContext(ctx context.Context) IGenerationUsageDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() IGenerationUsageDo
+	WriteDB() IGenerationUsageDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) IGenerationUsageDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) IGenerationUsageDo
+	Not(conds ...gen.Condition) IGenerationUsageDo
+	Or(conds ...gen.Condition) IGenerationUsageDo
+	Select(conds ...field.Expr) IGenerationUsageDo
+	Where(conds ...gen.Condition) IGenerationUsageDo
+	Order(conds ...field.Expr) IGenerationUsageDo
+	Distinct(cols ...field.Expr) IGenerationUsageDo
+	Omit(cols ...field.Expr) IGenerationUsageDo
+	Join(table schema.Tabler, on ...field.Expr) IGenerationUsageDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) IGenerationUsageDo
+	RightJoin(table schema.Tabler, on ...field.Expr) IGenerationUsageDo
+	Group(cols ...field.Expr) IGenerationUsageDo
+	Having(conds ...gen.Condition) IGenerationUsageDo
+	Limit(limit int) IGenerationUsageDo
+	Offset(offset int) IGenerationUsageDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) IGenerationUsageDo
+	Unscoped() IGenerationUsageDo
+	Create(values ...*model.GenerationUsage) error
+	CreateInBatches(values []*model.GenerationUsage, batchSize int) error
+	Save(values ...*model.GenerationUsage) error
+	First() (*model.GenerationUsage, error)
+	Take() (*model.GenerationUsage, error)
+	Last() (*model.GenerationUsage, error)
+	Find() ([]*model.GenerationUsage, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.GenerationUsage, err error)
+	FindInBatches(result *[]*model.GenerationUsage, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.GenerationUsage) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) IGenerationUsageDo
+	Assign(attrs ...field.AssignExpr) IGenerationUsageDo
+	Joins(fields ...field.RelationField) IGenerationUsageDo
+	Preload(fields ...field.RelationField) IGenerationUsageDo
+	FirstOrInit() (*model.GenerationUsage, error)
+	FirstOrCreate() (*model.GenerationUsage, error)
+	FindByPage(offset int, limit int) (result []*model.GenerationUsage, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) IGenerationUsageDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (g generationUsageDo) Debug() IGenerationUsageDo {
+	return g.withDO(g.DO.Debug())
+}
+
+func (g generationUsageDo) WithContext(ctx context.Context) IGenerationUsageDo {
+	return g.withDO(g.DO.WithContext(ctx))
+}
+
+func (g generationUsageDo) ReadDB() IGenerationUsageDo {
+	return g.Clauses(dbresolver.Read)
+}
+
+func (g generationUsageDo) WriteDB() IGenerationUsageDo {
+	return g.Clauses(dbresolver.Write)
+}
+
+func (g generationUsageDo) Session(config *gorm.Session) IGenerationUsageDo {
+	return g.withDO(g.DO.Session(config))
+}
+
+func (g generationUsageDo) Clauses(conds ...clause.Expression) IGenerationUsageDo {
+	return g.withDO(g.DO.Clauses(conds...))
+}
+
+func (g generationUsageDo) Returning(value interface{}, columns ...string) IGenerationUsageDo {
+	return g.withDO(g.DO.Returning(value, columns...))
+}
+
+func (g generationUsageDo) Not(conds ...gen.Condition) IGenerationUsageDo {
+	return g.withDO(g.DO.Not(conds...))
+}
+
+func (g generationUsageDo) Or(conds ...gen.Condition) IGenerationUsageDo {
+	return g.withDO(g.DO.Or(conds...))
+}
+
+func (g generationUsageDo) Select(conds ...field.Expr) IGenerationUsageDo {
+	return g.withDO(g.DO.Select(conds...))
+}
+
+func (g generationUsageDo) Where(conds ...gen.Condition) IGenerationUsageDo {
+	return g.withDO(g.DO.Where(conds...))
+}
+
+func (g generationUsageDo) Order(conds ...field.Expr) IGenerationUsageDo {
+	return g.withDO(g.DO.Order(conds...))
+}
+
+func (g generationUsageDo) Distinct(cols ...field.Expr) IGenerationUsageDo {
+	return g.withDO(g.DO.Distinct(cols...))
+}
+
+func (g generationUsageDo) Omit(cols ...field.Expr) IGenerationUsageDo {
+	return g.withDO(g.DO.Omit(cols...))
+}
+
+func (g generationUsageDo) Join(table schema.Tabler, on ...field.Expr) IGenerationUsageDo {
+	return g.withDO(g.DO.Join(table, on...))
+}
+
+func (g generationUsageDo) LeftJoin(table schema.Tabler, on ...field.Expr) IGenerationUsageDo {
+	return g.withDO(g.DO.LeftJoin(table, on...))
+}
+
+func (g generationUsageDo) RightJoin(table schema.Tabler, on ...field.Expr) IGenerationUsageDo {
+	return g.withDO(g.DO.RightJoin(table, on...))
+}
+
+func (g generationUsageDo) Group(cols ...field.Expr) IGenerationUsageDo {
+	return g.withDO(g.DO.Group(cols...))
+}
+
+func (g generationUsageDo) Having(conds ...gen.Condition) IGenerationUsageDo {
+	return g.withDO(g.DO.Having(conds...))
+}
+
+func (g generationUsageDo) Limit(limit int) IGenerationUsageDo {
+	return g.withDO(g.DO.Limit(limit))
+}
+
+func (g generationUsageDo) Offset(offset int) IGenerationUsageDo {
+	return g.withDO(g.DO.Offset(offset))
+}
+
+func (g generationUsageDo) Scopes(funcs ...func(gen.Dao) gen.Dao) IGenerationUsageDo {
+	return g.withDO(g.DO.Scopes(funcs...))
+}
+
+func (g generationUsageDo) Unscoped() IGenerationUsageDo {
+	return g.withDO(g.DO.Unscoped())
+}
+
+func (g generationUsageDo) Create(values ...*model.GenerationUsage) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return g.DO.Create(values)
+}
+
+func (g generationUsageDo) CreateInBatches(values []*model.GenerationUsage, batchSize int) error {
+	return g.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (g generationUsageDo) Save(values ...*model.GenerationUsage) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return g.DO.Save(values)
+}
+
+func (g generationUsageDo) First() (*model.GenerationUsage, error) {
+	if result, err := g.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.GenerationUsage), nil
+	}
+}
+
+func (g generationUsageDo) Take() (*model.GenerationUsage, error) {
+	if result, err := g.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.GenerationUsage), nil
+	}
+}
+
+func (g generationUsageDo) Last() (*model.GenerationUsage, error) {
+	if result, err := g.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.GenerationUsage), nil
+	}
+}
+
+func (g generationUsageDo) Find() ([]*model.GenerationUsage, error) {
+	result, err := g.DO.Find()
+	return result.([]*model.GenerationUsage), err
+}
+
+func (g generationUsageDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.GenerationUsage, err error) {
+	buf := make([]*model.GenerationUsage, 0, batchSize)
+	err = g.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (g generationUsageDo) FindInBatches(result *[]*model.GenerationUsage, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return g.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (g generationUsageDo) Attrs(attrs ...field.AssignExpr) IGenerationUsageDo {
+	return g.withDO(g.DO.Attrs(attrs...))
+}
+
+func (g generationUsageDo) Assign(attrs ...field.AssignExpr) IGenerationUsageDo {
+	return g.withDO(g.DO.Assign(attrs...))
+}
+
+func (g generationUsageDo) Joins(fields ...field.RelationField) IGenerationUsageDo {
+	for _, _f := range fields {
+		g = *g.withDO(g.DO.Joins(_f))
+	}
+	return &g
+}
+
+func (g generationUsageDo) Preload(fields ...field.RelationField) IGenerationUsageDo {
+	for _, _f := range fields {
+		g = *g.withDO(g.DO.Preload(_f))
+	}
+	return &g
+}
+
+func (g generationUsageDo) FirstOrInit() (*model.GenerationUsage, error) {
+	if result, err := g.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.GenerationUsage), nil
+	}
+}
+
+func (g generationUsageDo) FirstOrCreate() (*model.GenerationUsage, error) {
+	if result, err := g.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.GenerationUsage), nil
+	}
+}
+
+func (g generationUsageDo) FindByPage(offset int, limit int) (result []*model.GenerationUsage, count int64, err error) {
+	result, err = g.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = g.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (g generationUsageDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = g.Count()
+	if err != nil {
+		return
+	}
+
+	err = g.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (g generationUsageDo) Scan(result interface{}) (err error) {
+	return g.DO.Scan(result)
+}
+
+func (g generationUsageDo) Delete(models ...*model.GenerationUsage) (result gen.ResultInfo, err error) {
+	return g.DO.Delete(models)
+}
+
+func (g *generationUsageDo) withDO(do gen.Dao) *generationUsageDo {
+	g.DO = *do.(*gen.DO)
+	return g
+}
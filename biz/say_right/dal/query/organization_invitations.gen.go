@@ -0,0 +1,411 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newOrganizationInvitation(db *gorm.DB, opts ...gen.DOOption) organizationInvitation {
+	_organizationInvitation := organizationInvitation{}
+
+	_organizationInvitation.organizationInvitationDo.UseDB(db, opts...)
+	_organizationInvitation.organizationInvitationDo.UseModel(&model.OrganizationInvitation{})
+
+	tableName := _organizationInvitation.organizationInvitationDo.TableName()
+	_organizationInvitation.ALL = field.NewAsterisk(tableName)
+	_organizationInvitation.ID = field.NewInt32(tableName, "id")
+	_organizationInvitation.OrganizationID = field.NewInt32(tableName, "organization_id")
+	_organizationInvitation.Email = field.NewString(tableName, "email")
+	_organizationInvitation.Role = field.NewString(tableName, "role")
+	_organizationInvitation.Token = field.NewString(tableName, "token")
+	_organizationInvitation.InvitedBy = field.NewInt32(tableName, "invited_by")
+	_organizationInvitation.ExpiresAt = field.NewTime(tableName, "expires_at")
+	_organizationInvitation.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_organizationInvitation.fillFieldMap()
+
+	return _organizationInvitation
+}
+
+type organizationInvitation struct {
+	organizationInvitationDo
+
+	ALL            field.Asterisk
+	ID             field.Int32
+	OrganizationID field.Int32
+	Email          field.String
+	Role           field.String
+	Token          field.String
+	InvitedBy      field.Int32
+	ExpiresAt      field.Time
+	CreatedAt      field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (o organizationInvitation) Table(newTableName string) *organizationInvitation {
+	o.organizationInvitationDo.UseTable(newTableName)
+	return o.updateTableName(newTableName)
+}
+
+func (o organizationInvitation) As(alias string) *organizationInvitation {
+	o.organizationInvitationDo.DO = *(o.organizationInvitationDo.As(alias).(*gen.DO))
+	return o.updateTableName(alias)
+}
+
+func (o *organizationInvitation) updateTableName(table string) *organizationInvitation {
+	o.ALL = field.NewAsterisk(table)
+	o.ID = field.NewInt32(table, "id")
+	o.OrganizationID = field.NewInt32(table, "organization_id")
+	o.Email = field.NewString(table, "email")
+	o.Role = field.NewString(table, "role")
+	o.Token = field.NewString(table, "token")
+	o.InvitedBy = field.NewInt32(table, "invited_by")
+	o.ExpiresAt = field.NewTime(table, "expires_at")
+	o.CreatedAt = field.NewTime(table, "created_at")
+
+	o.fillFieldMap()
+
+	return o
+}
+
+func (o *organizationInvitation) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := o.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (o *organizationInvitation) fillFieldMap() {
+	o.fieldMap = make(map[string]field.Expr, 8)
+	o.fieldMap["id"] = o.ID
+	o.fieldMap["organization_id"] = o.OrganizationID
+	o.fieldMap["email"] = o.Email
+	o.fieldMap["role"] = o.Role
+	o.fieldMap["token"] = o.Token
+	o.fieldMap["invited_by"] = o.InvitedBy
+	o.fieldMap["expires_at"] = o.ExpiresAt
+	o.fieldMap["created_at"] = o.CreatedAt
+}
+
+func (o organizationInvitation) clone(db *gorm.DB) organizationInvitation {
+	o.organizationInvitationDo.ReplaceConnPool(db.Statement.ConnPool)
+	return o
+}
+
+func (o organizationInvitation) replaceDB(db *gorm.DB) organizationInvitation {
+	o.organizationInvitationDo.ReplaceDB(db)
+	return o
+}
+
+type organizationInvitationDo struct{ gen.DO }
+
+type IOrganizationInvitationDo interface {
+	gen.SubQuery
+	Debug() IOrganizationInvitationDo
+	WithContext(ctx context.Context) IOrganizationInvitationDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() IOrganizationInvitationDo
+	WriteDB() IOrganizationInvitationDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) IOrganizationInvitationDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) IOrganizationInvitationDo
+	Not(conds ...gen.Condition) IOrganizationInvitationDo
+	Or(conds ...gen.Condition) IOrganizationInvitationDo
+	Select(conds ...field.Expr) IOrganizationInvitationDo
+	Where(conds ...gen.Condition) IOrganizationInvitationDo
+	Order(conds ...field.Expr) IOrganizationInvitationDo
+	Distinct(cols ...field.Expr) IOrganizationInvitationDo
+	Omit(cols ...field.Expr) IOrganizationInvitationDo
+	Join(table schema.Tabler, on ...field.Expr) IOrganizationInvitationDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) IOrganizationInvitationDo
+	RightJoin(table schema.Tabler, on ...field.Expr) IOrganizationInvitationDo
+	Group(cols ...field.Expr) IOrganizationInvitationDo
+	Having(conds ...gen.Condition) IOrganizationInvitationDo
+	Limit(limit int) IOrganizationInvitationDo
+	Offset(offset int) IOrganizationInvitationDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) IOrganizationInvitationDo
+	Unscoped() IOrganizationInvitationDo
+	Create(values ...*model.OrganizationInvitation) error
+	CreateInBatches(values []*model.OrganizationInvitation, batchSize int) error
+	Save(values ...*model.OrganizationInvitation) error
+	First() (*model.OrganizationInvitation, error)
+	Take() (*model.OrganizationInvitation, error)
+	Last() (*model.OrganizationInvitation, error)
+	Find() ([]*model.OrganizationInvitation, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.OrganizationInvitation, err error)
+	FindInBatches(result *[]*model.OrganizationInvitation, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.OrganizationInvitation) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) IOrganizationInvitationDo
+	Assign(attrs ...field.AssignExpr) IOrganizationInvitationDo
+	Joins(fields ...field.RelationField) IOrganizationInvitationDo
+	Preload(fields ...field.RelationField) IOrganizationInvitationDo
+	FirstOrInit() (*model.OrganizationInvitation, error)
+	FirstOrCreate() (*model.OrganizationInvitation, error)
+	FindByPage(offset int, limit int) (result []*model.OrganizationInvitation, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) IOrganizationInvitationDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (o organizationInvitationDo) Debug() IOrganizationInvitationDo {
+	return o.withDO(o.DO.Debug())
+}
+
+func (o organizationInvitationDo) WithContext(ctx context.Context) IOrganizationInvitationDo {
+	return o.withDO(o.DO.WithContext(ctx))
+}
+
+func (o organizationInvitationDo) ReadDB() IOrganizationInvitationDo {
+	return o.Clauses(dbresolver.Read)
+}
+
+func (o organizationInvitationDo) WriteDB() IOrganizationInvitationDo {
+	return o.Clauses(dbresolver.Write)
+}
+
+func (o organizationInvitationDo) Session(config *gorm.Session) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Session(config))
+}
+
+func (o organizationInvitationDo) Clauses(conds ...clause.Expression) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Clauses(conds...))
+}
+
+func (o organizationInvitationDo) Returning(value interface{}, columns ...string) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Returning(value, columns...))
+}
+
+func (o organizationInvitationDo) Not(conds ...gen.Condition) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Not(conds...))
+}
+
+func (o organizationInvitationDo) Or(conds ...gen.Condition) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Or(conds...))
+}
+
+func (o organizationInvitationDo) Select(conds ...field.Expr) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Select(conds...))
+}
+
+func (o organizationInvitationDo) Where(conds ...gen.Condition) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Where(conds...))
+}
+
+func (o organizationInvitationDo) Order(conds ...field.Expr) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Order(conds...))
+}
+
+func (o organizationInvitationDo) Distinct(cols ...field.Expr) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Distinct(cols...))
+}
+
+func (o organizationInvitationDo) Omit(cols ...field.Expr) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Omit(cols...))
+}
+
+func (o organizationInvitationDo) Join(table schema.Tabler, on ...field.Expr) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Join(table, on...))
+}
+
+func (o organizationInvitationDo) LeftJoin(table schema.Tabler, on ...field.Expr) IOrganizationInvitationDo {
+	return o.withDO(o.DO.LeftJoin(table, on...))
+}
+
+func (o organizationInvitationDo) RightJoin(table schema.Tabler, on ...field.Expr) IOrganizationInvitationDo {
+	return o.withDO(o.DO.RightJoin(table, on...))
+}
+
+func (o organizationInvitationDo) Group(cols ...field.Expr) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Group(cols...))
+}
+
+func (o organizationInvitationDo) Having(conds ...gen.Condition) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Having(conds...))
+}
+
+func (o organizationInvitationDo) Limit(limit int) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Limit(limit))
+}
+
+func (o organizationInvitationDo) Offset(offset int) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Offset(offset))
+}
+
+func (o organizationInvitationDo) Scopes(funcs ...func(gen.Dao) gen.Dao) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Scopes(funcs...))
+}
+
+func (o organizationInvitationDo) Unscoped() IOrganizationInvitationDo {
+	return o.withDO(o.DO.Unscoped())
+}
+
+func (o organizationInvitationDo) Create(values ...*model.OrganizationInvitation) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return o.DO.Create(values)
+}
+
+func (o organizationInvitationDo) CreateInBatches(values []*model.OrganizationInvitation, batchSize int) error {
+	return o.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (o organizationInvitationDo) Save(values ...*model.OrganizationInvitation) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return o.DO.Save(values)
+}
+
+func (o organizationInvitationDo) First() (*model.OrganizationInvitation, error) {
+	if result, err := o.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.OrganizationInvitation), nil
+	}
+}
+
+func (o organizationInvitationDo) Take() (*model.OrganizationInvitation, error) {
+	if result, err := o.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.OrganizationInvitation), nil
+	}
+}
+
+func (o organizationInvitationDo) Last() (*model.OrganizationInvitation, error) {
+	if result, err := o.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.OrganizationInvitation), nil
+	}
+}
+
+func (o organizationInvitationDo) Find() ([]*model.OrganizationInvitation, error) {
+	result, err := o.DO.Find()
+	return result.([]*model.OrganizationInvitation), err
+}
+
+func (o organizationInvitationDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.OrganizationInvitation, err error) {
+	buf := make([]*model.OrganizationInvitation, 0, batchSize)
+	err = o.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (o organizationInvitationDo) FindInBatches(result *[]*model.OrganizationInvitation, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return o.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (o organizationInvitationDo) Attrs(attrs ...field.AssignExpr) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Attrs(attrs...))
+}
+
+func (o organizationInvitationDo) Assign(attrs ...field.AssignExpr) IOrganizationInvitationDo {
+	return o.withDO(o.DO.Assign(attrs...))
+}
+
+func (o organizationInvitationDo) Joins(fields ...field.RelationField) IOrganizationInvitationDo {
+	for _, _f := range fields {
+		o = *o.withDO(o.DO.Joins(_f))
+	}
+	return &o
+}
+
+func (o organizationInvitationDo) Preload(fields ...field.RelationField) IOrganizationInvitationDo {
+	for _, _f := range fields {
+		o = *o.withDO(o.DO.Preload(_f))
+	}
+	return &o
+}
+
+func (o organizationInvitationDo) FirstOrInit() (*model.OrganizationInvitation, error) {
+	if result, err := o.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.OrganizationInvitation), nil
+	}
+}
+
+func (o organizationInvitationDo) FirstOrCreate() (*model.OrganizationInvitation, error) {
+	if result, err := o.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.OrganizationInvitation), nil
+	}
+}
+
+func (o organizationInvitationDo) FindByPage(offset int, limit int) (result []*model.OrganizationInvitation, count int64, err error) {
+	result, err = o.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = o.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (o organizationInvitationDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = o.Count()
+	if err != nil {
+		return
+	}
+
+	err = o.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (o organizationInvitationDo) Scan(result interface{}) (err error) {
+	return o.DO.Scan(result)
+}
+
+func (o organizationInvitationDo) Delete(models ...*model.OrganizationInvitation) (result gen.ResultInfo, err error) {
+	return o.DO.Delete(models)
+}
+
+func (o *organizationInvitationDo) withDO(do gen.Dao) *organizationInvitationDo {
+	o.DO = *do.(*gen.DO)
+	return o
+}
@@ -0,0 +1,423 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newReferral(db *gorm.DB, opts ...gen.DOOption) referral {
+	_referral := referral{}
+
+	_referral.referralDo.UseDB(db, opts...)
+	_referral.referralDo.UseModel(&model.Referral{})
+
+	tableName := _referral.referralDo.TableName()
+	_referral.ALL = field.NewAsterisk(tableName)
+	_referral.ID = field.NewInt32(tableName, "id")
+	_referral.ReferrerID = field.NewInt32(tableName, "referrer_id")
+	_referral.RefereeID = field.NewInt32(tableName, "referee_id")
+	_referral.Status = field.NewString(tableName, "status")
+	_referral.Reason = field.NewString(tableName, "reason")
+	_referral.SignupIP = field.NewString(tableName, "signup_ip")
+	_referral.RewardDays = field.NewInt32(tableName, "reward_days")
+	_referral.TransactionID = field.NewString(tableName, "transaction_id")
+	_referral.RewardedAt = field.NewTime(tableName, "rewarded_at")
+	_referral.CreatedAt = field.NewTime(tableName, "created_at")
+	_referral.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_referral.fillFieldMap()
+
+	return _referral
+}
+
+type referral struct {
+	referralDo
+
+	ALL           field.Asterisk
+	ID            field.Int32
+	ReferrerID    field.Int32
+	RefereeID     field.Int32
+	Status        field.String
+	Reason        field.String
+	SignupIP      field.String
+	RewardDays    field.Int32
+	TransactionID field.String
+	RewardedAt    field.Time
+	CreatedAt     field.Time
+	UpdatedAt     field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (r referral) Table(newTableName string) *referral {
+	r.referralDo.UseTable(newTableName)
+	return r.updateTableName(newTableName)
+}
+
+func (r referral) As(alias string) *referral {
+	r.referralDo.DO = *(r.referralDo.As(alias).(*gen.DO))
+	return r.updateTableName(alias)
+}
+
+func (r *referral) updateTableName(table string) *referral {
+	r.ALL = field.NewAsterisk(table)
+	r.ID = field.NewInt32(table, "id")
+	r.ReferrerID = field.NewInt32(table, "referrer_id")
+	r.RefereeID = field.NewInt32(table, "referee_id")
+	r.Status = field.NewString(table, "status")
+	r.Reason = field.NewString(table, "reason")
+	r.SignupIP = field.NewString(table, "signup_ip")
+	r.RewardDays = field.NewInt32(table, "reward_days")
+	r.TransactionID = field.NewString(table, "transaction_id")
+	r.RewardedAt = field.NewTime(table, "rewarded_at")
+	r.CreatedAt = field.NewTime(table, "created_at")
+	r.UpdatedAt = field.NewTime(table, "updated_at")
+
+	r.fillFieldMap()
+
+	return r
+}
+
+func (r *referral) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := r.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (r *referral) fillFieldMap() {
+	r.fieldMap = make(map[string]field.Expr, 11)
+	r.fieldMap["id"] = r.ID
+	r.fieldMap["referrer_id"] = r.ReferrerID
+	r.fieldMap["referee_id"] = r.RefereeID
+	r.fieldMap["status"] = r.Status
+	r.fieldMap["reason"] = r.Reason
+	r.fieldMap["signup_ip"] = r.SignupIP
+	r.fieldMap["reward_days"] = r.RewardDays
+	r.fieldMap["transaction_id"] = r.TransactionID
+	r.fieldMap["rewarded_at"] = r.RewardedAt
+	r.fieldMap["created_at"] = r.CreatedAt
+	r.fieldMap["updated_at"] = r.UpdatedAt
+}
+
+func (r referral) clone(db *gorm.DB) referral {
+	r.referralDo.ReplaceConnPool(db.Statement.ConnPool)
+	return r
+}
+
+func (r referral) replaceDB(db *gorm.DB) referral {
+	r.referralDo.ReplaceDB(db)
+	return r
+}
+
+type referralDo struct{ gen.DO }
+
+type IReferralDo interface {
+	gen.SubQuery
+	Debug() IReferralDo
+	WithContext(ctx context.Context) IReferralDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() IReferralDo
+	WriteDB() IReferralDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) IReferralDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) IReferralDo
+	Not(conds ...gen.Condition) IReferralDo
+	Or(conds ...gen.Condition) IReferralDo
+	Select(conds ...field.Expr) IReferralDo
+	Where(conds ...gen.Condition) IReferralDo
+	Order(conds ...field.Expr) IReferralDo
+	Distinct(cols ...field.Expr) IReferralDo
+	Omit(cols ...field.Expr) IReferralDo
+	Join(table schema.Tabler, on ...field.Expr) IReferralDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) IReferralDo
+	RightJoin(table schema.Tabler, on ...field.Expr) IReferralDo
+	Group(cols ...field.Expr) IReferralDo
+	Having(conds ...gen.Condition) IReferralDo
+	Limit(limit int) IReferralDo
+	Offset(offset int) IReferralDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) IReferralDo
+	Unscoped() IReferralDo
+	Create(values ...*model.Referral) error
+	CreateInBatches(values []*model.Referral, batchSize int) error
+	Save(values ...*model.Referral) error
+	First() (*model.Referral, error)
+	Take() (*model.Referral, error)
+	Last() (*model.Referral, error)
+	Find() ([]*model.Referral, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.Referral, err error)
+	FindInBatches(result *[]*model.Referral, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.Referral) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) IReferralDo
+	Assign(attrs ...field.AssignExpr) IReferralDo
+	Joins(fields ...field.RelationField) IReferralDo
+	Preload(fields ...field.RelationField) IReferralDo
+	FirstOrInit() (*model.Referral, error)
+	FirstOrCreate() (*model.Referral, error)
+	FindByPage(offset int, limit int) (result []*model.Referral, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) IReferralDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (r referralDo) Debug() IReferralDo {
+	return r.withDO(r.DO.Debug())
+}
+
+func (r referralDo) WithContext(ctx context.Context) IReferralDo {
+	return r.withDO(r.DO.WithContext(ctx))
+}
+
+func (r referralDo) ReadDB() IReferralDo {
+	return r.Clauses(dbresolver.Read)
+}
+
+func (r referralDo) WriteDB() IReferralDo {
+	return r.Clauses(dbresolver.Write)
+}
+
+func (r referralDo) Session(config *gorm.Session) IReferralDo {
+	return r.withDO(r.DO.Session(config))
+}
+
+func (r referralDo) Clauses(conds ...clause.Expression) IReferralDo {
+	return r.withDO(r.DO.Clauses(conds...))
+}
+
+func (r referralDo) Returning(value interface{}, columns ...string) IReferralDo {
+	return r.withDO(r.DO.Returning(value, columns...))
+}
+
+func (r referralDo) Not(conds ...gen.Condition) IReferralDo {
+	return r.withDO(r.DO.Not(conds...))
+}
+
+func (r referralDo) Or(conds ...gen.Condition) IReferralDo {
+	return r.withDO(r.DO.Or(conds...))
+}
+
+func (r referralDo) Select(conds ...field.Expr) IReferralDo {
+	return r.withDO(r.DO.Select(conds...))
+}
+
+func (r referralDo) Where(conds ...gen.Condition) IReferralDo {
+	return r.withDO(r.DO.Where(conds...))
+}
+
+func (r referralDo) Order(conds ...field.Expr) IReferralDo {
+	return r.withDO(r.DO.Order(conds...))
+}
+
+func (r referralDo) Distinct(cols ...field.Expr) IReferralDo {
+	return r.withDO(r.DO.Distinct(cols...))
+}
+
+func (r referralDo) Omit(cols ...field.Expr) IReferralDo {
+	return r.withDO(r.DO.Omit(cols...))
+}
+
+func (r referralDo) Join(table schema.Tabler, on ...field.Expr) IReferralDo {
+	return r.withDO(r.DO.Join(table, on...))
+}
+
+func (r referralDo) LeftJoin(table schema.Tabler, on ...field.Expr) IReferralDo {
+	return r.withDO(r.DO.LeftJoin(table, on...))
+}
+
+func (r referralDo) RightJoin(table schema.Tabler, on ...field.Expr) IReferralDo {
+	return r.withDO(r.DO.RightJoin(table, on...))
+}
+
+func (r referralDo) Group(cols ...field.Expr) IReferralDo {
+	return r.withDO(r.DO.Group(cols...))
+}
+
+func (r referralDo) Having(conds ...gen.Condition) IReferralDo {
+	return r.withDO(r.DO.Having(conds...))
+}
+
+func (r referralDo) Limit(limit int) IReferralDo {
+	return r.withDO(r.DO.Limit(limit))
+}
+
+func (r referralDo) Offset(offset int) IReferralDo {
+	return r.withDO(r.DO.Offset(offset))
+}
+
+func (r referralDo) Scopes(funcs ...func(gen.Dao) gen.Dao) IReferralDo {
+	return r.withDO(r.DO.Scopes(funcs...))
+}
+
+func (r referralDo) Unscoped() IReferralDo {
+	return r.withDO(r.DO.Unscoped())
+}
+
+func (r referralDo) Create(values ...*model.Referral) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return r.DO.Create(values)
+}
+
+func (r referralDo) CreateInBatches(values []*model.Referral, batchSize int) error {
+	return r.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (r referralDo) Save(values ...*model.Referral) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return r.DO.Save(values)
+}
+
+func (r referralDo) First() (*model.Referral, error) {
+	if result, err := r.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.Referral), nil
+	}
+}
+
+func (r referralDo) Take() (*model.Referral, error) {
+	if result, err := r.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.Referral), nil
+	}
+}
+
+func (r referralDo) Last() (*model.Referral, error) {
+	if result, err := r.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.Referral), nil
+	}
+}
+
+func (r referralDo) Find() ([]*model.Referral, error) {
+	result, err := r.DO.Find()
+	return result.([]*model.Referral), err
+}
+
+func (r referralDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.Referral, err error) {
+	buf := make([]*model.Referral, 0, batchSize)
+	err = r.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (r referralDo) FindInBatches(result *[]*model.Referral, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return r.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (r referralDo) Attrs(attrs ...field.AssignExpr) IReferralDo {
+	return r.withDO(r.DO.Attrs(attrs...))
+}
+
+func (r referralDo) Assign(attrs ...field.AssignExpr) IReferralDo {
+	return r.withDO(r.DO.Assign(attrs...))
+}
+
+func (r referralDo) Joins(fields ...field.RelationField) IReferralDo {
+	for _, _f := range fields {
+		r = *r.withDO(r.DO.Joins(_f))
+	}
+	return &r
+}
+
+func (r referralDo) Preload(fields ...field.RelationField) IReferralDo {
+	for _, _f := range fields {
+		r = *r.withDO(r.DO.Preload(_f))
+	}
+	return &r
+}
+
+func (r referralDo) FirstOrInit() (*model.Referral, error) {
+	if result, err := r.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.Referral), nil
+	}
+}
+
+func (r referralDo) FirstOrCreate() (*model.Referral, error) {
+	if result, err := r.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.Referral), nil
+	}
+}
+
+func (r referralDo) FindByPage(offset int, limit int) (result []*model.Referral, count int64, err error) {
+	result, err = r.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = r.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (r referralDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = r.Count()
+	if err != nil {
+		return
+	}
+
+	err = r.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (r referralDo) Scan(result interface{}) (err error) {
+	return r.DO.Scan(result)
+}
+
+func (r referralDo) Delete(models ...*model.Referral) (result gen.ResultInfo, err error) {
+	return r.DO.Delete(models)
+}
+
+func (r *referralDo) withDO(do gen.Dao) *referralDo {
+	r.DO = *do.(*gen.DO)
+	return r
+}
@@ -0,0 +1,407 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newTemplateActivity(db *gorm.DB, opts ...gen.DOOption) templateActivity {
+	_templateActivity := templateActivity{}
+
+	_templateActivity.templateActivityDo.UseDB(db, opts...)
+	_templateActivity.templateActivityDo.UseModel(&model.TemplateActivity{})
+
+	tableName := _templateActivity.templateActivityDo.TableName()
+	_templateActivity.ALL = field.NewAsterisk(tableName)
+	_templateActivity.UserID = field.NewInt32(tableName, "user_id")
+	_templateActivity.TemplateID = field.NewInt32(tableName, "template_id")
+	_templateActivity.ViewCount = field.NewInt32(tableName, "view_count")
+	_templateActivity.CopyCount = field.NewInt32(tableName, "copy_count")
+	_templateActivity.LastViewedAt = field.NewTime(tableName, "last_viewed_at")
+	_templateActivity.LastCopiedAt = field.NewTime(tableName, "last_copied_at")
+	_templateActivity.LastUsedAt = field.NewTime(tableName, "last_used_at")
+
+	_templateActivity.fillFieldMap()
+
+	return _templateActivity
+}
+
+type templateActivity struct {
+	templateActivityDo
+
+	ALL          field.Asterisk
+	UserID       field.Int32
+	TemplateID   field.Int32
+	ViewCount    field.Int32
+	CopyCount    field.Int32
+	LastViewedAt field.Time
+	LastCopiedAt field.Time
+	LastUsedAt   field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (t templateActivity) Table(newTableName string) *templateActivity {
+	t.templateActivityDo.UseTable(newTableName)
+	return t.updateTableName(newTableName)
+}
+
+func (t templateActivity) As(alias string) *templateActivity {
+	t.templateActivityDo.DO = *(t.templateActivityDo.As(alias).(*gen.DO))
+	return t.updateTableName(alias)
+}
+
+func (t *templateActivity) updateTableName(table string) *templateActivity {
+	t.ALL = field.NewAsterisk(table)
+	t.UserID = field.NewInt32(table, "user_id")
+	t.TemplateID = field.NewInt32(table, "template_id")
+	t.ViewCount = field.NewInt32(table, "view_count")
+	t.CopyCount = field.NewInt32(table, "copy_count")
+	t.LastViewedAt = field.NewTime(table, "last_viewed_at")
+	t.LastCopiedAt = field.NewTime(table, "last_copied_at")
+	t.LastUsedAt = field.NewTime(table, "last_used_at")
+
+	t.fillFieldMap()
+
+	return t
+}
+
+func (t *templateActivity) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := t.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (t *templateActivity) fillFieldMap() {
+	t.fieldMap = make(map[string]field.Expr, 7)
+	t.fieldMap["user_id"] = t.UserID
+	t.fieldMap["template_id"] = t.TemplateID
+	t.fieldMap["view_count"] = t.ViewCount
+	t.fieldMap["copy_count"] = t.CopyCount
+	t.fieldMap["last_viewed_at"] = t.LastViewedAt
+	t.fieldMap["last_copied_at"] = t.LastCopiedAt
+	t.fieldMap["last_used_at"] = t.LastUsedAt
+}
+
+func (t templateActivity) clone(db *gorm.DB) templateActivity {
+	t.templateActivityDo.ReplaceConnPool(db.Statement.ConnPool)
+	return t
+}
+
+func (t templateActivity) replaceDB(db *gorm.DB) templateActivity {
+	t.templateActivityDo.ReplaceDB(db)
+	return t
+}
+
+type templateActivityDo struct{ gen.DO }
+
+type ITemplateActivityDo interface {
+	gen.SubQuery
+	Debug() ITemplateActivityDo
+	WithContext(ctx context.Context) ITemplateActivityDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ITemplateActivityDo
+	WriteDB() ITemplateActivityDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ITemplateActivityDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ITemplateActivityDo
+	Not(conds ...gen.Condition) ITemplateActivityDo
+	Or(conds ...gen.Condition) ITemplateActivityDo
+	Select(conds ...field.Expr) ITemplateActivityDo
+	Where(conds ...gen.Condition) ITemplateActivityDo
+	Order(conds ...field.Expr) ITemplateActivityDo
+	Distinct(cols ...field.Expr) ITemplateActivityDo
+	Omit(cols ...field.Expr) ITemplateActivityDo
+	Join(table schema.Tabler, on ...field.Expr) ITemplateActivityDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateActivityDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ITemplateActivityDo
+	Group(cols ...field.Expr) ITemplateActivityDo
+	Having(conds ...gen.Condition) ITemplateActivityDo
+	Limit(limit int) ITemplateActivityDo
+	Offset(offset int) ITemplateActivityDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateActivityDo
+	Unscoped() ITemplateActivityDo
+	Create(values ...*model.TemplateActivity) error
+	CreateInBatches(values []*model.TemplateActivity, batchSize int) error
+	Save(values ...*model.TemplateActivity) error
+	First() (*model.TemplateActivity, error)
+	Take() (*model.TemplateActivity, error)
+	Last() (*model.TemplateActivity, error)
+	Find() ([]*model.TemplateActivity, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateActivity, err error)
+	FindInBatches(result *[]*model.TemplateActivity, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.TemplateActivity) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ITemplateActivityDo
+	Assign(attrs ...field.AssignExpr) ITemplateActivityDo
+	Joins(fields ...field.RelationField) ITemplateActivityDo
+	Preload(fields ...field.RelationField) ITemplateActivityDo
+	FirstOrInit() (*model.TemplateActivity, error)
+	FirstOrCreate() (*model.TemplateActivity, error)
+	FindByPage(offset int, limit int) (result []*model.TemplateActivity, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ITemplateActivityDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (t templateActivityDo) Debug() ITemplateActivityDo {
+	return t.withDO(t.DO.Debug())
+}
+
+func (t templateActivityDo) WithContext(ctx context.Context) ITemplateActivityDo {
+	return t.withDO(t.DO.WithContext(ctx))
+}
+
+func (t templateActivityDo) ReadDB() ITemplateActivityDo {
+	return t.Clauses(dbresolver.Read)
+}
+
+func (t templateActivityDo) WriteDB() ITemplateActivityDo {
+	return t.Clauses(dbresolver.Write)
+}
+
+func (t templateActivityDo) Session(config *gorm.Session) ITemplateActivityDo {
+	return t.withDO(t.DO.Session(config))
+}
+
+func (t templateActivityDo) Clauses(conds ...clause.Expression) ITemplateActivityDo {
+	return t.withDO(t.DO.Clauses(conds...))
+}
+
+func (t templateActivityDo) Returning(value interface{}, columns ...string) ITemplateActivityDo {
+	return t.withDO(t.DO.Returning(value, columns...))
+}
+
+func (t templateActivityDo) Not(conds ...gen.Condition) ITemplateActivityDo {
+	return t.withDO(t.DO.Not(conds...))
+}
+
+func (t templateActivityDo) Or(conds ...gen.Condition) ITemplateActivityDo {
+	return t.withDO(t.DO.Or(conds...))
+}
+
+func (t templateActivityDo) Select(conds ...field.Expr) ITemplateActivityDo {
+	return t.withDO(t.DO.Select(conds...))
+}
+
+func (t templateActivityDo) Where(conds ...gen.Condition) ITemplateActivityDo {
+	return t.withDO(t.DO.Where(conds...))
+}
+
+func (t templateActivityDo) Order(conds ...field.Expr) ITemplateActivityDo {
+	return t.withDO(t.DO.Order(conds...))
+}
+
+func (t templateActivityDo) Distinct(cols ...field.Expr) ITemplateActivityDo {
+	return t.withDO(t.DO.Distinct(cols...))
+}
+
+func (t templateActivityDo) Omit(cols ...field.Expr) ITemplateActivityDo {
+	return t.withDO(t.DO.Omit(cols...))
+}
+
+func (t templateActivityDo) Join(table schema.Tabler, on ...field.Expr) ITemplateActivityDo {
+	return t.withDO(t.DO.Join(table, on...))
+}
+
+func (t templateActivityDo) LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateActivityDo {
+	return t.withDO(t.DO.LeftJoin(table, on...))
+}
+
+func (t templateActivityDo) RightJoin(table schema.Tabler, on ...field.Expr) ITemplateActivityDo {
+	return t.withDO(t.DO.RightJoin(table, on...))
+}
+
+func (t templateActivityDo) Group(cols ...field.Expr) ITemplateActivityDo {
+	return t.withDO(t.DO.Group(cols...))
+}
+
+func (t templateActivityDo) Having(conds ...gen.Condition) ITemplateActivityDo {
+	return t.withDO(t.DO.Having(conds...))
+}
+
+func (t templateActivityDo) Limit(limit int) ITemplateActivityDo {
+	return t.withDO(t.DO.Limit(limit))
+}
+
+func (t templateActivityDo) Offset(offset int) ITemplateActivityDo {
+	return t.withDO(t.DO.Offset(offset))
+}
+
+func (t templateActivityDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateActivityDo {
+	return t.withDO(t.DO.Scopes(funcs...))
+}
+
+func (t templateActivityDo) Unscoped() ITemplateActivityDo {
+	return t.withDO(t.DO.Unscoped())
+}
+
+func (t templateActivityDo) Create(values ...*model.TemplateActivity) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Create(values)
+}
+
+func (t templateActivityDo) CreateInBatches(values []*model.TemplateActivity, batchSize int) error {
+	return t.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (t templateActivityDo) Save(values ...*model.TemplateActivity) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Save(values)
+}
+
+func (t templateActivityDo) First() (*model.TemplateActivity, error) {
+	if result, err := t.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateActivity), nil
+	}
+}
+
+func (t templateActivityDo) Take() (*model.TemplateActivity, error) {
+	if result, err := t.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateActivity), nil
+	}
+}
+
+func (t templateActivityDo) Last() (*model.TemplateActivity, error) {
+	if result, err := t.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateActivity), nil
+	}
+}
+
+func (t templateActivityDo) Find() ([]*model.TemplateActivity, error) {
+	result, err := t.DO.Find()
+	return result.([]*model.TemplateActivity), err
+}
+
+func (t templateActivityDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateActivity, err error) {
+	buf := make([]*model.TemplateActivity, 0, batchSize)
+	err = t.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (t templateActivityDo) FindInBatches(result *[]*model.TemplateActivity, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return t.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (t templateActivityDo) Attrs(attrs ...field.AssignExpr) ITemplateActivityDo {
+	return t.withDO(t.DO.Attrs(attrs...))
+}
+
+func (t templateActivityDo) Assign(attrs ...field.AssignExpr) ITemplateActivityDo {
+	return t.withDO(t.DO.Assign(attrs...))
+}
+
+func (t templateActivityDo) Joins(fields ...field.RelationField) ITemplateActivityDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Joins(_f))
+	}
+	return &t
+}
+
+func (t templateActivityDo) Preload(fields ...field.RelationField) ITemplateActivityDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Preload(_f))
+	}
+	return &t
+}
+
+func (t templateActivityDo) FirstOrInit() (*model.TemplateActivity, error) {
+	if result, err := t.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateActivity), nil
+	}
+}
+
+func (t templateActivityDo) FirstOrCreate() (*model.TemplateActivity, error) {
+	if result, err := t.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateActivity), nil
+	}
+}
+
+func (t templateActivityDo) FindByPage(offset int, limit int) (result []*model.TemplateActivity, count int64, err error) {
+	result, err = t.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = t.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (t templateActivityDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = t.Count()
+	if err != nil {
+		return
+	}
+
+	err = t.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (t templateActivityDo) Scan(result interface{}) (err error) {
+	return t.DO.Scan(result)
+}
+
+func (t templateActivityDo) Delete(models ...*model.TemplateActivity) (result gen.ResultInfo, err error) {
+	return t.DO.Delete(models)
+}
+
+func (t *templateActivityDo) withDO(do gen.Dao) *templateActivityDo {
+	t.DO = *do.(*gen.DO)
+	return t
+}
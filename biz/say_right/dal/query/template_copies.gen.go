@@ -0,0 +1,399 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newTemplateCopy(db *gorm.DB, opts ...gen.DOOption) templateCopy {
+	_templateCopy := templateCopy{}
+
+	_templateCopy.templateCopyDo.UseDB(db, opts...)
+	_templateCopy.templateCopyDo.UseModel(&model.TemplateCopy{})
+
+	tableName := _templateCopy.templateCopyDo.TableName()
+	_templateCopy.ALL = field.NewAsterisk(tableName)
+	_templateCopy.ID = field.NewInt32(tableName, "id")
+	_templateCopy.UserID = field.NewInt32(tableName, "user_id")
+	_templateCopy.TemplateID = field.NewInt32(tableName, "template_id")
+	_templateCopy.Tone = field.NewString(tableName, "tone")
+	_templateCopy.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_templateCopy.fillFieldMap()
+
+	return _templateCopy
+}
+
+type templateCopy struct {
+	templateCopyDo
+
+	ALL        field.Asterisk
+	ID         field.Int32
+	UserID     field.Int32
+	TemplateID field.Int32
+	Tone       field.String
+	CreatedAt  field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (t templateCopy) Table(newTableName string) *templateCopy {
+	t.templateCopyDo.UseTable(newTableName)
+	return t.updateTableName(newTableName)
+}
+
+func (t templateCopy) As(alias string) *templateCopy {
+	t.templateCopyDo.DO = *(t.templateCopyDo.As(alias).(*gen.DO))
+	return t.updateTableName(alias)
+}
+
+func (t *templateCopy) updateTableName(table string) *templateCopy {
+	t.ALL = field.NewAsterisk(table)
+	t.ID = field.NewInt32(table, "id")
+	t.UserID = field.NewInt32(table, "user_id")
+	t.TemplateID = field.NewInt32(table, "template_id")
+	t.Tone = field.NewString(table, "tone")
+	t.CreatedAt = field.NewTime(table, "created_at")
+
+	t.fillFieldMap()
+
+	return t
+}
+
+func (t *templateCopy) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := t.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (t *templateCopy) fillFieldMap() {
+	t.fieldMap = make(map[string]field.Expr, 5)
+	t.fieldMap["id"] = t.ID
+	t.fieldMap["user_id"] = t.UserID
+	t.fieldMap["template_id"] = t.TemplateID
+	t.fieldMap["tone"] = t.Tone
+	t.fieldMap["created_at"] = t.CreatedAt
+}
+
+func (t templateCopy) clone(db *gorm.DB) templateCopy {
+	t.templateCopyDo.ReplaceConnPool(db.Statement.ConnPool)
+	return t
+}
+
+func (t templateCopy) replaceDB(db *gorm.DB) templateCopy {
+	t.templateCopyDo.ReplaceDB(db)
+	return t
+}
+
+type templateCopyDo struct{ gen.DO }
+
+type ITemplateCopyDo interface {
+	gen.SubQuery
+	Debug() ITemplateCopyDo
+	WithContext(ctx context.Context) ITemplateCopyDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ITemplateCopyDo
+	WriteDB() ITemplateCopyDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ITemplateCopyDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ITemplateCopyDo
+	Not(conds ...gen.Condition) ITemplateCopyDo
+	Or(conds ...gen.Condition) ITemplateCopyDo
+	Select(conds ...field.Expr) ITemplateCopyDo
+	Where(conds ...gen.Condition) ITemplateCopyDo
+	Order(conds ...field.Expr) ITemplateCopyDo
+	Distinct(cols ...field.Expr) ITemplateCopyDo
+	Omit(cols ...field.Expr) ITemplateCopyDo
+	Join(table schema.Tabler, on ...field.Expr) ITemplateCopyDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateCopyDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ITemplateCopyDo
+	Group(cols ...field.Expr) ITemplateCopyDo
+	Having(conds ...gen.Condition) ITemplateCopyDo
+	Limit(limit int) ITemplateCopyDo
+	Offset(offset int) ITemplateCopyDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateCopyDo
+	Unscoped() ITemplateCopyDo
+	Create(values ...*model.TemplateCopy) error
+	CreateInBatches(values []*model.TemplateCopy, batchSize int) error
+	Save(values ...*model.TemplateCopy) error
+	First() (*model.TemplateCopy, error)
+	Take() (*model.TemplateCopy, error)
+	Last() (*model.TemplateCopy, error)
+	Find() ([]*model.TemplateCopy, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateCopy, err error)
+	FindInBatches(result *[]*model.TemplateCopy, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.TemplateCopy) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ITemplateCopyDo
+	Assign(attrs ...field.AssignExpr) ITemplateCopyDo
+	Joins(fields ...field.RelationField) ITemplateCopyDo
+	Preload(fields ...field.RelationField) ITemplateCopyDo
+	FirstOrInit() (*model.TemplateCopy, error)
+	FirstOrCreate() (*model.TemplateCopy, error)
+	FindByPage(offset int, limit int) (result []*model.TemplateCopy, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ITemplateCopyDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (t templateCopyDo) Debug() ITemplateCopyDo {
+	return t.withDO(t.DO.Debug())
+}
+
+func (t templateCopyDo) WithContext(ctx context.Context) ITemplateCopyDo {
+	return t.withDO(t.DO.WithContext(ctx))
+}
+
+func (t templateCopyDo) ReadDB() ITemplateCopyDo {
+	return t.Clauses(dbresolver.Read)
+}
+
+func (t templateCopyDo) WriteDB() ITemplateCopyDo {
+	return t.Clauses(dbresolver.Write)
+}
+
+func (t templateCopyDo) Session(config *gorm.Session) ITemplateCopyDo {
+	return t.withDO(t.DO.Session(config))
+}
+
+func (t templateCopyDo) Clauses(conds ...clause.Expression) ITemplateCopyDo {
+	return t.withDO(t.DO.Clauses(conds...))
+}
+
+func (t templateCopyDo) Returning(value interface{}, columns ...string) ITemplateCopyDo {
+	return t.withDO(t.DO.Returning(value, columns...))
+}
+
+func (t templateCopyDo) Not(conds ...gen.Condition) ITemplateCopyDo {
+	return t.withDO(t.DO.Not(conds...))
+}
+
+func (t templateCopyDo) Or(conds ...gen.Condition) ITemplateCopyDo {
+	return t.withDO(t.DO.Or(conds...))
+}
+
+func (t templateCopyDo) Select(conds ...field.Expr) ITemplateCopyDo {
+	return t.withDO(t.DO.Select(conds...))
+}
+
+func (t templateCopyDo) Where(conds ...gen.Condition) ITemplateCopyDo {
+	return t.withDO(t.DO.Where(conds...))
+}
+
+func (t templateCopyDo) Order(conds ...field.Expr) ITemplateCopyDo {
+	return t.withDO(t.DO.Order(conds...))
+}
+
+func (t templateCopyDo) Distinct(cols ...field.Expr) ITemplateCopyDo {
+	return t.withDO(t.DO.Distinct(cols...))
+}
+
+func (t templateCopyDo) Omit(cols ...field.Expr) ITemplateCopyDo {
+	return t.withDO(t.DO.Omit(cols...))
+}
+
+func (t templateCopyDo) Join(table schema.Tabler, on ...field.Expr) ITemplateCopyDo {
+	return t.withDO(t.DO.Join(table, on...))
+}
+
+func (t templateCopyDo) LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateCopyDo {
+	return t.withDO(t.DO.LeftJoin(table, on...))
+}
+
+func (t templateCopyDo) RightJoin(table schema.Tabler, on ...field.Expr) ITemplateCopyDo {
+	return t.withDO(t.DO.RightJoin(table, on...))
+}
+
+func (t templateCopyDo) Group(cols ...field.Expr) ITemplateCopyDo {
+	return t.withDO(t.DO.Group(cols...))
+}
+
+func (t templateCopyDo) Having(conds ...gen.Condition) ITemplateCopyDo {
+	return t.withDO(t.DO.Having(conds...))
+}
+
+func (t templateCopyDo) Limit(limit int) ITemplateCopyDo {
+	return t.withDO(t.DO.Limit(limit))
+}
+
+func (t templateCopyDo) Offset(offset int) ITemplateCopyDo {
+	return t.withDO(t.DO.Offset(offset))
+}
+
+func (t templateCopyDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateCopyDo {
+	return t.withDO(t.DO.Scopes(funcs...))
+}
+
+func (t templateCopyDo) Unscoped() ITemplateCopyDo {
+	return t.withDO(t.DO.Unscoped())
+}
+
+func (t templateCopyDo) Create(values ...*model.TemplateCopy) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Create(values)
+}
+
+func (t templateCopyDo) CreateInBatches(values []*model.TemplateCopy, batchSize int) error {
+	return t.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (t templateCopyDo) Save(values ...*model.TemplateCopy) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Save(values)
+}
+
+func (t templateCopyDo) First() (*model.TemplateCopy, error) {
+	if result, err := t.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateCopy), nil
+	}
+}
+
+func (t templateCopyDo) Take() (*model.TemplateCopy, error) {
+	if result, err := t.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateCopy), nil
+	}
+}
+
+func (t templateCopyDo) Last() (*model.TemplateCopy, error) {
+	if result, err := t.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateCopy), nil
+	}
+}
+
+func (t templateCopyDo) Find() ([]*model.TemplateCopy, error) {
+	result, err := t.DO.Find()
+	return result.([]*model.TemplateCopy), err
+}
+
+func (t templateCopyDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateCopy, err error) {
+	buf := make([]*model.TemplateCopy, 0, batchSize)
+	err = t.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (t templateCopyDo) FindInBatches(result *[]*model.TemplateCopy, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return t.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (t templateCopyDo) Attrs(attrs ...field.AssignExpr) ITemplateCopyDo {
+	return t.withDO(t.DO.Attrs(attrs...))
+}
+
+func (t templateCopyDo) Assign(attrs ...field.AssignExpr) ITemplateCopyDo {
+	return t.withDO(t.DO.Assign(attrs...))
+}
+
+func (t templateCopyDo) Joins(fields ...field.RelationField) ITemplateCopyDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Joins(_f))
+	}
+	return &t
+}
+
+func (t templateCopyDo) Preload(fields ...field.RelationField) ITemplateCopyDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Preload(_f))
+	}
+	return &t
+}
+
+func (t templateCopyDo) FirstOrInit() (*model.TemplateCopy, error) {
+	if result, err := t.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateCopy), nil
+	}
+}
+
+func (t templateCopyDo) FirstOrCreate() (*model.TemplateCopy, error) {
+	if result, err := t.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateCopy), nil
+	}
+}
+
+func (t templateCopyDo) FindByPage(offset int, limit int) (result []*model.TemplateCopy, count int64, err error) {
+	result, err = t.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = t.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (t templateCopyDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = t.Count()
+	if err != nil {
+		return
+	}
+
+	err = t.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (t templateCopyDo) Scan(result interface{}) (err error) {
+	return t.DO.Scan(result)
+}
+
+func (t templateCopyDo) Delete(models ...*model.TemplateCopy) (result gen.ResultInfo, err error) {
+	return t.DO.Delete(models)
+}
+
+func (t *templateCopyDo) withDO(do gen.Dao) *templateCopyDo {
+	t.DO = *do.(*gen.DO)
+	return t
+}
@@ -37,6 +37,8 @@ func newTemplateDetail(db *gorm.DB, opts ...gen.DOOption) templateDetail {
 	_templateDetail.ReplyFirm = field.NewString(tableName, "reply_firm")
 	_templateDetail.WhenNotToUse = field.NewString(tableName, "when_not_to_use")
 	_templateDetail.BestPractices = field.NewString(tableName, "best_practices")
+	_templateDetail.Variables = field.NewString(tableName, "variables")
+	_templateDetail.Prompt = field.NewString(tableName, "prompt")
 	_templateDetail.CreatedAt = field.NewTime(tableName, "created_at")
 	_templateDetail.UpdatedAt = field.NewTime(tableName, "updated_at")
 
@@ -58,6 +60,8 @@ type templateDetail struct {
 	ReplyFirm     field.String
 	WhenNotToUse  field.String
 	BestPractices field.String
+	Variables     field.String
+	Prompt        field.String
 	CreatedAt     field.Time
 	UpdatedAt     field.Time
 
@@ -85,6 +89,8 @@ func (t *templateDetail) updateTableName(table string) *templateDetail {
 	t.ReplyFirm = field.NewString(table, "reply_firm")
 	t.WhenNotToUse = field.NewString(table, "when_not_to_use")
 	t.BestPractices = field.NewString(table, "best_practices")
+	t.Variables = field.NewString(table, "variables")
+	t.Prompt = field.NewString(table, "prompt")
 	t.CreatedAt = field.NewTime(table, "created_at")
 	t.UpdatedAt = field.NewTime(table, "updated_at")
 
@@ -103,7 +109,7 @@ func (t *templateDetail) GetFieldByName(fieldName string) (field.OrderExpr, bool
 }
 
 func (t *templateDetail) fillFieldMap() {
-	t.fieldMap = make(map[string]field.Expr, 11)
+	t.fieldMap = make(map[string]field.Expr, 13)
 	t.fieldMap["id"] = t.ID
 	t.fieldMap["template_id"] = t.TemplateID
 	t.fieldMap["headline"] = t.Headline
@@ -113,6 +119,8 @@ func (t *templateDetail) fillFieldMap() {
 	t.fieldMap["reply_firm"] = t.ReplyFirm
 	t.fieldMap["when_not_to_use"] = t.WhenNotToUse
 	t.fieldMap["best_practices"] = t.BestPractices
+	t.fieldMap["variables"] = t.Variables
+	t.fieldMap["prompt"] = t.Prompt
 	t.fieldMap["created_at"] = t.CreatedAt
 	t.fieldMap["updated_at"] = t.UpdatedAt
 }
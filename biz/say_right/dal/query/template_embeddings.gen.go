@@ -0,0 +1,411 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newTemplateEmbedding(db *gorm.DB, opts ...gen.DOOption) templateEmbedding {
+	_templateEmbedding := templateEmbedding{}
+
+	_templateEmbedding.templateEmbeddingDo.UseDB(db, opts...)
+	_templateEmbedding.templateEmbeddingDo.UseModel(&model.TemplateEmbedding{})
+
+	tableName := _templateEmbedding.templateEmbeddingDo.TableName()
+	_templateEmbedding.ALL = field.NewAsterisk(tableName)
+	_templateEmbedding.ID = field.NewInt32(tableName, "id")
+	_templateEmbedding.TemplateID = field.NewInt32(tableName, "template_id")
+	_templateEmbedding.Model = field.NewString(tableName, "model")
+	_templateEmbedding.ContentHash = field.NewString(tableName, "content_hash")
+	_templateEmbedding.Dimensions = field.NewInt32(tableName, "dimensions")
+	_templateEmbedding.Vector = field.NewBytes(tableName, "vector")
+	_templateEmbedding.CreatedAt = field.NewTime(tableName, "created_at")
+	_templateEmbedding.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_templateEmbedding.fillFieldMap()
+
+	return _templateEmbedding
+}
+
+type templateEmbedding struct {
+	templateEmbeddingDo
+
+	ALL         field.Asterisk
+	ID          field.Int32
+	TemplateID  field.Int32
+	Model       field.String
+	ContentHash field.String
+	Dimensions  field.Int32
+	Vector      field.Bytes
+	CreatedAt   field.Time
+	UpdatedAt   field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (t templateEmbedding) Table(newTableName string) *templateEmbedding {
+	t.templateEmbeddingDo.UseTable(newTableName)
+	return t.updateTableName(newTableName)
+}
+
+func (t templateEmbedding) As(alias string) *templateEmbedding {
+	t.templateEmbeddingDo.DO = *(t.templateEmbeddingDo.As(alias).(*gen.DO))
+	return t.updateTableName(alias)
+}
+
+func (t *templateEmbedding) updateTableName(table string) *templateEmbedding {
+	t.ALL = field.NewAsterisk(table)
+	t.ID = field.NewInt32(table, "id")
+	t.TemplateID = field.NewInt32(table, "template_id")
+	t.Model = field.NewString(table, "model")
+	t.ContentHash = field.NewString(table, "content_hash")
+	t.Dimensions = field.NewInt32(table, "dimensions")
+	t.Vector = field.NewBytes(table, "vector")
+	t.CreatedAt = field.NewTime(table, "created_at")
+	t.UpdatedAt = field.NewTime(table, "updated_at")
+
+	t.fillFieldMap()
+
+	return t
+}
+
+func (t *templateEmbedding) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := t.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (t *templateEmbedding) fillFieldMap() {
+	t.fieldMap = make(map[string]field.Expr, 8)
+	t.fieldMap["id"] = t.ID
+	t.fieldMap["template_id"] = t.TemplateID
+	t.fieldMap["model"] = t.Model
+	t.fieldMap["content_hash"] = t.ContentHash
+	t.fieldMap["dimensions"] = t.Dimensions
+	t.fieldMap["vector"] = t.Vector
+	t.fieldMap["created_at"] = t.CreatedAt
+	t.fieldMap["updated_at"] = t.UpdatedAt
+}
+
+func (t templateEmbedding) clone(db *gorm.DB) templateEmbedding {
+	t.templateEmbeddingDo.ReplaceConnPool(db.Statement.ConnPool)
+	return t
+}
+
+func (t templateEmbedding) replaceDB(db *gorm.DB) templateEmbedding {
+	t.templateEmbeddingDo.ReplaceDB(db)
+	return t
+}
+
+type templateEmbeddingDo struct{ gen.DO }
+
+type ITemplateEmbeddingDo interface {
+	gen.SubQuery
+	Debug() ITemplateEmbeddingDo
+	WithContext(ctx context.Context) ITemplateEmbeddingDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ITemplateEmbeddingDo
+	WriteDB() ITemplateEmbeddingDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ITemplateEmbeddingDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ITemplateEmbeddingDo
+	Not(conds ...gen.Condition) ITemplateEmbeddingDo
+	Or(conds ...gen.Condition) ITemplateEmbeddingDo
+	Select(conds ...field.Expr) ITemplateEmbeddingDo
+	Where(conds ...gen.Condition) ITemplateEmbeddingDo
+	Order(conds ...field.Expr) ITemplateEmbeddingDo
+	Distinct(cols ...field.Expr) ITemplateEmbeddingDo
+	Omit(cols ...field.Expr) ITemplateEmbeddingDo
+	Join(table schema.Tabler, on ...field.Expr) ITemplateEmbeddingDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateEmbeddingDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ITemplateEmbeddingDo
+	Group(cols ...field.Expr) ITemplateEmbeddingDo
+	Having(conds ...gen.Condition) ITemplateEmbeddingDo
+	Limit(limit int) ITemplateEmbeddingDo
+	Offset(offset int) ITemplateEmbeddingDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateEmbeddingDo
+	Unscoped() ITemplateEmbeddingDo
+	Create(values ...*model.TemplateEmbedding) error
+	CreateInBatches(values []*model.TemplateEmbedding, batchSize int) error
+	Save(values ...*model.TemplateEmbedding) error
+	First() (*model.TemplateEmbedding, error)
+	Take() (*model.TemplateEmbedding, error)
+	Last() (*model.TemplateEmbedding, error)
+	Find() ([]*model.TemplateEmbedding, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateEmbedding, err error)
+	FindInBatches(result *[]*model.TemplateEmbedding, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.TemplateEmbedding) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ITemplateEmbeddingDo
+	Assign(attrs ...field.AssignExpr) ITemplateEmbeddingDo
+	Joins(fields ...field.RelationField) ITemplateEmbeddingDo
+	Preload(fields ...field.RelationField) ITemplateEmbeddingDo
+	FirstOrInit() (*model.TemplateEmbedding, error)
+	FirstOrCreate() (*model.TemplateEmbedding, error)
+	FindByPage(offset int, limit int) (result []*model.TemplateEmbedding, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ITemplateEmbeddingDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (t templateEmbeddingDo) Debug() ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Debug())
+}
+
+func (t templateEmbeddingDo) WithContext(ctx context.Context) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.WithContext(ctx))
+}
+
+func (t templateEmbeddingDo) ReadDB() ITemplateEmbeddingDo {
+	return t.Clauses(dbresolver.Read)
+}
+
+func (t templateEmbeddingDo) WriteDB() ITemplateEmbeddingDo {
+	return t.Clauses(dbresolver.Write)
+}
+
+func (t templateEmbeddingDo) Session(config *gorm.Session) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Session(config))
+}
+
+func (t templateEmbeddingDo) Clauses(conds ...clause.Expression) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Clauses(conds...))
+}
+
+func (t templateEmbeddingDo) Returning(value interface{}, columns ...string) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Returning(value, columns...))
+}
+
+func (t templateEmbeddingDo) Not(conds ...gen.Condition) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Not(conds...))
+}
+
+func (t templateEmbeddingDo) Or(conds ...gen.Condition) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Or(conds...))
+}
+
+func (t templateEmbeddingDo) Select(conds ...field.Expr) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Select(conds...))
+}
+
+func (t templateEmbeddingDo) Where(conds ...gen.Condition) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Where(conds...))
+}
+
+func (t templateEmbeddingDo) Order(conds ...field.Expr) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Order(conds...))
+}
+
+func (t templateEmbeddingDo) Distinct(cols ...field.Expr) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Distinct(cols...))
+}
+
+func (t templateEmbeddingDo) Omit(cols ...field.Expr) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Omit(cols...))
+}
+
+func (t templateEmbeddingDo) Join(table schema.Tabler, on ...field.Expr) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Join(table, on...))
+}
+
+func (t templateEmbeddingDo) LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.LeftJoin(table, on...))
+}
+
+func (t templateEmbeddingDo) RightJoin(table schema.Tabler, on ...field.Expr) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.RightJoin(table, on...))
+}
+
+func (t templateEmbeddingDo) Group(cols ...field.Expr) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Group(cols...))
+}
+
+func (t templateEmbeddingDo) Having(conds ...gen.Condition) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Having(conds...))
+}
+
+func (t templateEmbeddingDo) Limit(limit int) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Limit(limit))
+}
+
+func (t templateEmbeddingDo) Offset(offset int) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Offset(offset))
+}
+
+func (t templateEmbeddingDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Scopes(funcs...))
+}
+
+func (t templateEmbeddingDo) Unscoped() ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Unscoped())
+}
+
+func (t templateEmbeddingDo) Create(values ...*model.TemplateEmbedding) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Create(values)
+}
+
+func (t templateEmbeddingDo) CreateInBatches(values []*model.TemplateEmbedding, batchSize int) error {
+	return t.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (t templateEmbeddingDo) Save(values ...*model.TemplateEmbedding) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Save(values)
+}
+
+func (t templateEmbeddingDo) First() (*model.TemplateEmbedding, error) {
+	if result, err := t.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateEmbedding), nil
+	}
+}
+
+func (t templateEmbeddingDo) Take() (*model.TemplateEmbedding, error) {
+	if result, err := t.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateEmbedding), nil
+	}
+}
+
+func (t templateEmbeddingDo) Last() (*model.TemplateEmbedding, error) {
+	if result, err := t.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateEmbedding), nil
+	}
+}
+
+func (t templateEmbeddingDo) Find() ([]*model.TemplateEmbedding, error) {
+	result, err := t.DO.Find()
+	return result.([]*model.TemplateEmbedding), err
+}
+
+func (t templateEmbeddingDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateEmbedding, err error) {
+	buf := make([]*model.TemplateEmbedding, 0, batchSize)
+	err = t.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (t templateEmbeddingDo) FindInBatches(result *[]*model.TemplateEmbedding, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return t.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (t templateEmbeddingDo) Attrs(attrs ...field.AssignExpr) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Attrs(attrs...))
+}
+
+func (t templateEmbeddingDo) Assign(attrs ...field.AssignExpr) ITemplateEmbeddingDo {
+	return t.withDO(t.DO.Assign(attrs...))
+}
+
+func (t templateEmbeddingDo) Joins(fields ...field.RelationField) ITemplateEmbeddingDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Joins(_f))
+	}
+	return &t
+}
+
+func (t templateEmbeddingDo) Preload(fields ...field.RelationField) ITemplateEmbeddingDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Preload(_f))
+	}
+	return &t
+}
+
+func (t templateEmbeddingDo) FirstOrInit() (*model.TemplateEmbedding, error) {
+	if result, err := t.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateEmbedding), nil
+	}
+}
+
+func (t templateEmbeddingDo) FirstOrCreate() (*model.TemplateEmbedding, error) {
+	if result, err := t.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateEmbedding), nil
+	}
+}
+
+func (t templateEmbeddingDo) FindByPage(offset int, limit int) (result []*model.TemplateEmbedding, count int64, err error) {
+	result, err = t.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = t.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (t templateEmbeddingDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = t.Count()
+	if err != nil {
+		return
+	}
+
+	err = t.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (t templateEmbeddingDo) Scan(result interface{}) (err error) {
+	return t.DO.Scan(result)
+}
+
+func (t templateEmbeddingDo) Delete(models ...*model.TemplateEmbedding) (result gen.ResultInfo, err error) {
+	return t.DO.Delete(models)
+}
+
+func (t *templateEmbeddingDo) withDO(do gen.Dao) *templateEmbeddingDo {
+	t.DO = *do.(*gen.DO)
+	return t
+}
@@ -0,0 +1,391 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newTemplateFavorite(db *gorm.DB, opts ...gen.DOOption) templateFavorite {
+	_templateFavorite := templateFavorite{}
+
+	_templateFavorite.templateFavoriteDo.UseDB(db, opts...)
+	_templateFavorite.templateFavoriteDo.UseModel(&model.TemplateFavorite{})
+
+	tableName := _templateFavorite.templateFavoriteDo.TableName()
+	_templateFavorite.ALL = field.NewAsterisk(tableName)
+	_templateFavorite.UserID = field.NewInt32(tableName, "user_id")
+	_templateFavorite.TemplateID = field.NewInt32(tableName, "template_id")
+	_templateFavorite.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_templateFavorite.fillFieldMap()
+
+	return _templateFavorite
+}
+
+type templateFavorite struct {
+	templateFavoriteDo
+
+	ALL        field.Asterisk
+	UserID     field.Int32
+	TemplateID field.Int32
+	CreatedAt  field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (t templateFavorite) Table(newTableName string) *templateFavorite {
+	t.templateFavoriteDo.UseTable(newTableName)
+	return t.updateTableName(newTableName)
+}
+
+func (t templateFavorite) As(alias string) *templateFavorite {
+	t.templateFavoriteDo.DO = *(t.templateFavoriteDo.As(alias).(*gen.DO))
+	return t.updateTableName(alias)
+}
+
+func (t *templateFavorite) updateTableName(table string) *templateFavorite {
+	t.ALL = field.NewAsterisk(table)
+	t.UserID = field.NewInt32(table, "user_id")
+	t.TemplateID = field.NewInt32(table, "template_id")
+	t.CreatedAt = field.NewTime(table, "created_at")
+
+	t.fillFieldMap()
+
+	return t
+}
+
+func (t *templateFavorite) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := t.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (t *templateFavorite) fillFieldMap() {
+	t.fieldMap = make(map[string]field.Expr, 3)
+	t.fieldMap["user_id"] = t.UserID
+	t.fieldMap["template_id"] = t.TemplateID
+	t.fieldMap["created_at"] = t.CreatedAt
+}
+
+func (t templateFavorite) clone(db *gorm.DB) templateFavorite {
+	t.templateFavoriteDo.ReplaceConnPool(db.Statement.ConnPool)
+	return t
+}
+
+func (t templateFavorite) replaceDB(db *gorm.DB) templateFavorite {
+	t.templateFavoriteDo.ReplaceDB(db)
+	return t
+}
+
+type templateFavoriteDo struct{ gen.DO }
+
+type ITemplateFavoriteDo interface {
+	gen.SubQuery
+	Debug() ITemplateFavoriteDo
+	WithContext(ctx context.Context) ITemplateFavoriteDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ITemplateFavoriteDo
+	WriteDB() ITemplateFavoriteDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ITemplateFavoriteDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ITemplateFavoriteDo
+	Not(conds ...gen.Condition) ITemplateFavoriteDo
+	Or(conds ...gen.Condition) ITemplateFavoriteDo
+	Select(conds ...field.Expr) ITemplateFavoriteDo
+	Where(conds ...gen.Condition) ITemplateFavoriteDo
+	Order(conds ...field.Expr) ITemplateFavoriteDo
+	Distinct(cols ...field.Expr) ITemplateFavoriteDo
+	Omit(cols ...field.Expr) ITemplateFavoriteDo
+	Join(table schema.Tabler, on ...field.Expr) ITemplateFavoriteDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateFavoriteDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ITemplateFavoriteDo
+	Group(cols ...field.Expr) ITemplateFavoriteDo
+	Having(conds ...gen.Condition) ITemplateFavoriteDo
+	Limit(limit int) ITemplateFavoriteDo
+	Offset(offset int) ITemplateFavoriteDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateFavoriteDo
+	Unscoped() ITemplateFavoriteDo
+	Create(values ...*model.TemplateFavorite) error
+	CreateInBatches(values []*model.TemplateFavorite, batchSize int) error
+	Save(values ...*model.TemplateFavorite) error
+	First() (*model.TemplateFavorite, error)
+	Take() (*model.TemplateFavorite, error)
+	Last() (*model.TemplateFavorite, error)
+	Find() ([]*model.TemplateFavorite, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateFavorite, err error)
+	FindInBatches(result *[]*model.TemplateFavorite, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.TemplateFavorite) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ITemplateFavoriteDo
+	Assign(attrs ...field.AssignExpr) ITemplateFavoriteDo
+	Joins(fields ...field.RelationField) ITemplateFavoriteDo
+	Preload(fields ...field.RelationField) ITemplateFavoriteDo
+	FirstOrInit() (*model.TemplateFavorite, error)
+	FirstOrCreate() (*model.TemplateFavorite, error)
+	FindByPage(offset int, limit int) (result []*model.TemplateFavorite, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ITemplateFavoriteDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (t templateFavoriteDo) Debug() ITemplateFavoriteDo {
+	return t.withDO(t.DO.Debug())
+}
+
+func (t templateFavoriteDo) WithContext(ctx context.Context) ITemplateFavoriteDo {
+	return t.withDO(t.DO.WithContext(ctx))
+}
+
+func (t templateFavoriteDo) ReadDB() ITemplateFavoriteDo {
+	return t.Clauses(dbresolver.Read)
+}
+
+func (t templateFavoriteDo) WriteDB() ITemplateFavoriteDo {
+	return t.Clauses(dbresolver.Write)
+}
+
+func (t templateFavoriteDo) Session(config *gorm.Session) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Session(config))
+}
+
+func (t templateFavoriteDo) Clauses(conds ...clause.Expression) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Clauses(conds...))
+}
+
+func (t templateFavoriteDo) Returning(value interface{}, columns ...string) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Returning(value, columns...))
+}
+
+func (t templateFavoriteDo) Not(conds ...gen.Condition) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Not(conds...))
+}
+
+func (t templateFavoriteDo) Or(conds ...gen.Condition) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Or(conds...))
+}
+
+func (t templateFavoriteDo) Select(conds ...field.Expr) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Select(conds...))
+}
+
+func (t templateFavoriteDo) Where(conds ...gen.Condition) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Where(conds...))
+}
+
+func (t templateFavoriteDo) Order(conds ...field.Expr) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Order(conds...))
+}
+
+func (t templateFavoriteDo) Distinct(cols ...field.Expr) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Distinct(cols...))
+}
+
+func (t templateFavoriteDo) Omit(cols ...field.Expr) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Omit(cols...))
+}
+
+func (t templateFavoriteDo) Join(table schema.Tabler, on ...field.Expr) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Join(table, on...))
+}
+
+func (t templateFavoriteDo) LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateFavoriteDo {
+	return t.withDO(t.DO.LeftJoin(table, on...))
+}
+
+func (t templateFavoriteDo) RightJoin(table schema.Tabler, on ...field.Expr) ITemplateFavoriteDo {
+	return t.withDO(t.DO.RightJoin(table, on...))
+}
+
+func (t templateFavoriteDo) Group(cols ...field.Expr) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Group(cols...))
+}
+
+func (t templateFavoriteDo) Having(conds ...gen.Condition) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Having(conds...))
+}
+
+func (t templateFavoriteDo) Limit(limit int) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Limit(limit))
+}
+
+func (t templateFavoriteDo) Offset(offset int) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Offset(offset))
+}
+
+func (t templateFavoriteDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Scopes(funcs...))
+}
+
+func (t templateFavoriteDo) Unscoped() ITemplateFavoriteDo {
+	return t.withDO(t.DO.Unscoped())
+}
+
+func (t templateFavoriteDo) Create(values ...*model.TemplateFavorite) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Create(values)
+}
+
+func (t templateFavoriteDo) CreateInBatches(values []*model.TemplateFavorite, batchSize int) error {
+	return t.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (t templateFavoriteDo) Save(values ...*model.TemplateFavorite) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Save(values)
+}
+
+func (t templateFavoriteDo) First() (*model.TemplateFavorite, error) {
+	if result, err := t.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateFavorite), nil
+	}
+}
+
+func (t templateFavoriteDo) Take() (*model.TemplateFavorite, error) {
+	if result, err := t.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateFavorite), nil
+	}
+}
+
+func (t templateFavoriteDo) Last() (*model.TemplateFavorite, error) {
+	if result, err := t.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateFavorite), nil
+	}
+}
+
+func (t templateFavoriteDo) Find() ([]*model.TemplateFavorite, error) {
+	result, err := t.DO.Find()
+	return result.([]*model.TemplateFavorite), err
+}
+
+func (t templateFavoriteDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateFavorite, err error) {
+	buf := make([]*model.TemplateFavorite, 0, batchSize)
+	err = t.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (t templateFavoriteDo) FindInBatches(result *[]*model.TemplateFavorite, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return t.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (t templateFavoriteDo) Attrs(attrs ...field.AssignExpr) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Attrs(attrs...))
+}
+
+func (t templateFavoriteDo) Assign(attrs ...field.AssignExpr) ITemplateFavoriteDo {
+	return t.withDO(t.DO.Assign(attrs...))
+}
+
+func (t templateFavoriteDo) Joins(fields ...field.RelationField) ITemplateFavoriteDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Joins(_f))
+	}
+	return &t
+}
+
+func (t templateFavoriteDo) Preload(fields ...field.RelationField) ITemplateFavoriteDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Preload(_f))
+	}
+	return &t
+}
+
+func (t templateFavoriteDo) FirstOrInit() (*model.TemplateFavorite, error) {
+	if result, err := t.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateFavorite), nil
+	}
+}
+
+func (t templateFavoriteDo) FirstOrCreate() (*model.TemplateFavorite, error) {
+	if result, err := t.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateFavorite), nil
+	}
+}
+
+func (t templateFavoriteDo) FindByPage(offset int, limit int) (result []*model.TemplateFavorite, count int64, err error) {
+	result, err = t.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = t.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (t templateFavoriteDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = t.Count()
+	if err != nil {
+		return
+	}
+
+	err = t.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (t templateFavoriteDo) Scan(result interface{}) (err error) {
+	return t.DO.Scan(result)
+}
+
+func (t templateFavoriteDo) Delete(models ...*model.TemplateFavorite) (result gen.ResultInfo, err error) {
+	return t.DO.Delete(models)
+}
+
+func (t *templateFavoriteDo) withDO(do gen.Dao) *templateFavoriteDo {
+	t.DO = *do.(*gen.DO)
+	return t
+}
@@ -0,0 +1,423 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newTemplateRating(db *gorm.DB, opts ...gen.DOOption) templateRating {
+	_templateRating := templateRating{}
+
+	_templateRating.templateRatingDo.UseDB(db, opts...)
+	_templateRating.templateRatingDo.UseModel(&model.TemplateRating{})
+
+	tableName := _templateRating.templateRatingDo.TableName()
+	_templateRating.ALL = field.NewAsterisk(tableName)
+	_templateRating.ID = field.NewInt32(tableName, "id")
+	_templateRating.UserID = field.NewInt32(tableName, "user_id")
+	_templateRating.TemplateID = field.NewInt32(tableName, "template_id")
+	_templateRating.Tone = field.NewString(tableName, "tone")
+	_templateRating.Vote = field.NewInt32(tableName, "vote")
+	_templateRating.Comment = field.NewString(tableName, "comment")
+	_templateRating.CommentStatus = field.NewString(tableName, "comment_status")
+	_templateRating.ModeratedBy = field.NewInt32(tableName, "moderated_by")
+	_templateRating.ModeratedAt = field.NewTime(tableName, "moderated_at")
+	_templateRating.CreatedAt = field.NewTime(tableName, "created_at")
+	_templateRating.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_templateRating.fillFieldMap()
+
+	return _templateRating
+}
+
+type templateRating struct {
+	templateRatingDo
+
+	ALL           field.Asterisk
+	ID            field.Int32
+	UserID        field.Int32
+	TemplateID    field.Int32
+	Tone          field.String
+	Vote          field.Int32
+	Comment       field.String
+	CommentStatus field.String
+	ModeratedBy   field.Int32
+	ModeratedAt   field.Time
+	CreatedAt     field.Time
+	UpdatedAt     field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (t templateRating) Table(newTableName string) *templateRating {
+	t.templateRatingDo.UseTable(newTableName)
+	return t.updateTableName(newTableName)
+}
+
+func (t templateRating) As(alias string) *templateRating {
+	t.templateRatingDo.DO = *(t.templateRatingDo.As(alias).(*gen.DO))
+	return t.updateTableName(alias)
+}
+
+func (t *templateRating) updateTableName(table string) *templateRating {
+	t.ALL = field.NewAsterisk(table)
+	t.ID = field.NewInt32(table, "id")
+	t.UserID = field.NewInt32(table, "user_id")
+	t.TemplateID = field.NewInt32(table, "template_id")
+	t.Tone = field.NewString(table, "tone")
+	t.Vote = field.NewInt32(table, "vote")
+	t.Comment = field.NewString(table, "comment")
+	t.CommentStatus = field.NewString(table, "comment_status")
+	t.ModeratedBy = field.NewInt32(table, "moderated_by")
+	t.ModeratedAt = field.NewTime(table, "moderated_at")
+	t.CreatedAt = field.NewTime(table, "created_at")
+	t.UpdatedAt = field.NewTime(table, "updated_at")
+
+	t.fillFieldMap()
+
+	return t
+}
+
+func (t *templateRating) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := t.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (t *templateRating) fillFieldMap() {
+	t.fieldMap = make(map[string]field.Expr, 11)
+	t.fieldMap["id"] = t.ID
+	t.fieldMap["user_id"] = t.UserID
+	t.fieldMap["template_id"] = t.TemplateID
+	t.fieldMap["tone"] = t.Tone
+	t.fieldMap["vote"] = t.Vote
+	t.fieldMap["comment"] = t.Comment
+	t.fieldMap["comment_status"] = t.CommentStatus
+	t.fieldMap["moderated_by"] = t.ModeratedBy
+	t.fieldMap["moderated_at"] = t.ModeratedAt
+	t.fieldMap["created_at"] = t.CreatedAt
+	t.fieldMap["updated_at"] = t.UpdatedAt
+}
+
+func (t templateRating) clone(db *gorm.DB) templateRating {
+	t.templateRatingDo.ReplaceConnPool(db.Statement.ConnPool)
+	return t
+}
+
+func (t templateRating) replaceDB(db *gorm.DB) templateRating {
+	t.templateRatingDo.ReplaceDB(db)
+	return t
+}
+
+type templateRatingDo struct{ gen.DO }
+
+type ITemplateRatingDo interface {
+	gen.SubQuery
+	Debug() ITemplateRatingDo
+	WithContext(ctx context.Context) ITemplateRatingDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ITemplateRatingDo
+	WriteDB() ITemplateRatingDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ITemplateRatingDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ITemplateRatingDo
+	Not(conds ...gen.Condition) ITemplateRatingDo
+	Or(conds ...gen.Condition) ITemplateRatingDo
+	Select(conds ...field.Expr) ITemplateRatingDo
+	Where(conds ...gen.Condition) ITemplateRatingDo
+	Order(conds ...field.Expr) ITemplateRatingDo
+	Distinct(cols ...field.Expr) ITemplateRatingDo
+	Omit(cols ...field.Expr) ITemplateRatingDo
+	Join(table schema.Tabler, on ...field.Expr) ITemplateRatingDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateRatingDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ITemplateRatingDo
+	Group(cols ...field.Expr) ITemplateRatingDo
+	Having(conds ...gen.Condition) ITemplateRatingDo
+	Limit(limit int) ITemplateRatingDo
+	Offset(offset int) ITemplateRatingDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateRatingDo
+	Unscoped() ITemplateRatingDo
+	Create(values ...*model.TemplateRating) error
+	CreateInBatches(values []*model.TemplateRating, batchSize int) error
+	Save(values ...*model.TemplateRating) error
+	First() (*model.TemplateRating, error)
+	Take() (*model.TemplateRating, error)
+	Last() (*model.TemplateRating, error)
+	Find() ([]*model.TemplateRating, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateRating, err error)
+	FindInBatches(result *[]*model.TemplateRating, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.TemplateRating) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ITemplateRatingDo
+	Assign(attrs ...field.AssignExpr) ITemplateRatingDo
+	Joins(fields ...field.RelationField) ITemplateRatingDo
+	Preload(fields ...field.RelationField) ITemplateRatingDo
+	FirstOrInit() (*model.TemplateRating, error)
+	FirstOrCreate() (*model.TemplateRating, error)
+	FindByPage(offset int, limit int) (result []*model.TemplateRating, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ITemplateRatingDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (t templateRatingDo) Debug() ITemplateRatingDo {
+	return t.withDO(t.DO.Debug())
+}
+
+func (t templateRatingDo) WithContext(ctx context.Context) ITemplateRatingDo {
+	return t.withDO(t.DO.WithContext(ctx))
+}
+
+func (t templateRatingDo) ReadDB() ITemplateRatingDo {
+	return t.Clauses(dbresolver.Read)
+}
+
+func (t templateRatingDo) WriteDB() ITemplateRatingDo {
+	return t.Clauses(dbresolver.Write)
+}
+
+func (t templateRatingDo) Session(config *gorm.Session) ITemplateRatingDo {
+	return t.withDO(t.DO.Session(config))
+}
+
+func (t templateRatingDo) Clauses(conds ...clause.Expression) ITemplateRatingDo {
+	return t.withDO(t.DO.Clauses(conds...))
+}
+
+func (t templateRatingDo) Returning(value interface{}, columns ...string) ITemplateRatingDo {
+	return t.withDO(t.DO.Returning(value, columns...))
+}
+
+func (t templateRatingDo) Not(conds ...gen.Condition) ITemplateRatingDo {
+	return t.withDO(t.DO.Not(conds...))
+}
+
+func (t templateRatingDo) Or(conds ...gen.Condition) ITemplateRatingDo {
+	return t.withDO(t.DO.Or(conds...))
+}
+
+func (t templateRatingDo) Select(conds ...field.Expr) ITemplateRatingDo {
+	return t.withDO(t.DO.Select(conds...))
+}
+
+func (t templateRatingDo) Where(conds ...gen.Condition) ITemplateRatingDo {
+	return t.withDO(t.DO.Where(conds...))
+}
+
+func (t templateRatingDo) Order(conds ...field.Expr) ITemplateRatingDo {
+	return t.withDO(t.DO.Order(conds...))
+}
+
+func (t templateRatingDo) Distinct(cols ...field.Expr) ITemplateRatingDo {
+	return t.withDO(t.DO.Distinct(cols...))
+}
+
+func (t templateRatingDo) Omit(cols ...field.Expr) ITemplateRatingDo {
+	return t.withDO(t.DO.Omit(cols...))
+}
+
+func (t templateRatingDo) Join(table schema.Tabler, on ...field.Expr) ITemplateRatingDo {
+	return t.withDO(t.DO.Join(table, on...))
+}
+
+func (t templateRatingDo) LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateRatingDo {
+	return t.withDO(t.DO.LeftJoin(table, on...))
+}
+
+func (t templateRatingDo) RightJoin(table schema.Tabler, on ...field.Expr) ITemplateRatingDo {
+	return t.withDO(t.DO.RightJoin(table, on...))
+}
+
+func (t templateRatingDo) Group(cols ...field.Expr) ITemplateRatingDo {
+	return t.withDO(t.DO.Group(cols...))
+}
+
+func (t templateRatingDo) Having(conds ...gen.Condition) ITemplateRatingDo {
+	return t.withDO(t.DO.Having(conds...))
+}
+
+func (t templateRatingDo) Limit(limit int) ITemplateRatingDo {
+	return t.withDO(t.DO.Limit(limit))
+}
+
+func (t templateRatingDo) Offset(offset int) ITemplateRatingDo {
+	return t.withDO(t.DO.Offset(offset))
+}
+
+func (t templateRatingDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateRatingDo {
+	return t.withDO(t.DO.Scopes(funcs...))
+}
+
+func (t templateRatingDo) Unscoped() ITemplateRatingDo {
+	return t.withDO(t.DO.Unscoped())
+}
+
+func (t templateRatingDo) Create(values ...*model.TemplateRating) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Create(values)
+}
+
+func (t templateRatingDo) CreateInBatches(values []*model.TemplateRating, batchSize int) error {
+	return t.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (t templateRatingDo) Save(values ...*model.TemplateRating) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Save(values)
+}
+
+func (t templateRatingDo) First() (*model.TemplateRating, error) {
+	if result, err := t.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateRating), nil
+	}
+}
+
+func (t templateRatingDo) Take() (*model.TemplateRating, error) {
+	if result, err := t.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateRating), nil
+	}
+}
+
+func (t templateRatingDo) Last() (*model.TemplateRating, error) {
+	if result, err := t.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateRating), nil
+	}
+}
+
+func (t templateRatingDo) Find() ([]*model.TemplateRating, error) {
+	result, err := t.DO.Find()
+	return result.([]*model.TemplateRating), err
+}
+
+func (t templateRatingDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateRating, err error) {
+	buf := make([]*model.TemplateRating, 0, batchSize)
+	err = t.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (t templateRatingDo) FindInBatches(result *[]*model.TemplateRating, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return t.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (t templateRatingDo) Attrs(attrs ...field.AssignExpr) ITemplateRatingDo {
+	return t.withDO(t.DO.Attrs(attrs...))
+}
+
+func (t templateRatingDo) Assign(attrs ...field.AssignExpr) ITemplateRatingDo {
+	return t.withDO(t.DO.Assign(attrs...))
+}
+
+func (t templateRatingDo) Joins(fields ...field.RelationField) ITemplateRatingDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Joins(_f))
+	}
+	return &t
+}
+
+func (t templateRatingDo) Preload(fields ...field.RelationField) ITemplateRatingDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Preload(_f))
+	}
+	return &t
+}
+
+func (t templateRatingDo) FirstOrInit() (*model.TemplateRating, error) {
+	if result, err := t.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateRating), nil
+	}
+}
+
+func (t templateRatingDo) FirstOrCreate() (*model.TemplateRating, error) {
+	if result, err := t.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateRating), nil
+	}
+}
+
+func (t templateRatingDo) FindByPage(offset int, limit int) (result []*model.TemplateRating, count int64, err error) {
+	result, err = t.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = t.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (t templateRatingDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = t.Count()
+	if err != nil {
+		return
+	}
+
+	err = t.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (t templateRatingDo) Scan(result interface{}) (err error) {
+	return t.DO.Scan(result)
+}
+
+func (t templateRatingDo) Delete(models ...*model.TemplateRating) (result gen.ResultInfo, err error) {
+	return t.DO.Delete(models)
+}
+
+func (t *templateRatingDo) withDO(do gen.Dao) *templateRatingDo {
+	t.DO = *do.(*gen.DO)
+	return t
+}
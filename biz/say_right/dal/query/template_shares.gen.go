@@ -0,0 +1,427 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newTemplateShare(db *gorm.DB, opts ...gen.DOOption) templateShare {
+	_templateShare := templateShare{}
+
+	_templateShare.templateShareDo.UseDB(db, opts...)
+	_templateShare.templateShareDo.UseModel(&model.TemplateShare{})
+
+	tableName := _templateShare.templateShareDo.TableName()
+	_templateShare.ALL = field.NewAsterisk(tableName)
+	_templateShare.ID = field.NewInt32(tableName, "id")
+	_templateShare.Token = field.NewString(tableName, "token")
+	_templateShare.UserID = field.NewInt32(tableName, "user_id")
+	_templateShare.TemplateID = field.NewInt32(tableName, "template_id")
+	_templateShare.Locale = field.NewString(tableName, "locale")
+	_templateShare.Tone = field.NewString(tableName, "tone")
+	_templateShare.VariableValues = field.NewString(tableName, "variable_values")
+	_templateShare.ViewCount = field.NewInt32(tableName, "view_count")
+	_templateShare.LastViewedAt = field.NewTime(tableName, "last_viewed_at")
+	_templateShare.ExpiresAt = field.NewTime(tableName, "expires_at")
+	_templateShare.RevokedAt = field.NewTime(tableName, "revoked_at")
+	_templateShare.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_templateShare.fillFieldMap()
+
+	return _templateShare
+}
+
+type templateShare struct {
+	templateShareDo
+
+	ALL            field.Asterisk
+	ID             field.Int32
+	Token          field.String
+	UserID         field.Int32
+	TemplateID     field.Int32
+	Locale         field.String
+	Tone           field.String
+	VariableValues field.String
+	ViewCount      field.Int32
+	LastViewedAt   field.Time
+	ExpiresAt      field.Time
+	RevokedAt      field.Time
+	CreatedAt      field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (t templateShare) Table(newTableName string) *templateShare {
+	t.templateShareDo.UseTable(newTableName)
+	return t.updateTableName(newTableName)
+}
+
+func (t templateShare) As(alias string) *templateShare {
+	t.templateShareDo.DO = *(t.templateShareDo.As(alias).(*gen.DO))
+	return t.updateTableName(alias)
+}
+
+func (t *templateShare) updateTableName(table string) *templateShare {
+	t.ALL = field.NewAsterisk(table)
+	t.ID = field.NewInt32(table, "id")
+	t.Token = field.NewString(table, "token")
+	t.UserID = field.NewInt32(table, "user_id")
+	t.TemplateID = field.NewInt32(table, "template_id")
+	t.Locale = field.NewString(table, "locale")
+	t.Tone = field.NewString(table, "tone")
+	t.VariableValues = field.NewString(table, "variable_values")
+	t.ViewCount = field.NewInt32(table, "view_count")
+	t.LastViewedAt = field.NewTime(table, "last_viewed_at")
+	t.ExpiresAt = field.NewTime(table, "expires_at")
+	t.RevokedAt = field.NewTime(table, "revoked_at")
+	t.CreatedAt = field.NewTime(table, "created_at")
+
+	t.fillFieldMap()
+
+	return t
+}
+
+func (t *templateShare) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := t.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (t *templateShare) fillFieldMap() {
+	t.fieldMap = make(map[string]field.Expr, 12)
+	t.fieldMap["id"] = t.ID
+	t.fieldMap["token"] = t.Token
+	t.fieldMap["user_id"] = t.UserID
+	t.fieldMap["template_id"] = t.TemplateID
+	t.fieldMap["locale"] = t.Locale
+	t.fieldMap["tone"] = t.Tone
+	t.fieldMap["variable_values"] = t.VariableValues
+	t.fieldMap["view_count"] = t.ViewCount
+	t.fieldMap["last_viewed_at"] = t.LastViewedAt
+	t.fieldMap["expires_at"] = t.ExpiresAt
+	t.fieldMap["revoked_at"] = t.RevokedAt
+	t.fieldMap["created_at"] = t.CreatedAt
+}
+
+func (t templateShare) clone(db *gorm.DB) templateShare {
+	t.templateShareDo.ReplaceConnPool(db.Statement.ConnPool)
+	return t
+}
+
+func (t templateShare) replaceDB(db *gorm.DB) templateShare {
+	t.templateShareDo.ReplaceDB(db)
+	return t
+}
+
+type templateShareDo struct{ gen.DO }
+
+type ITemplateShareDo interface {
+	gen.SubQuery
+	Debug() ITemplateShareDo
+	WithContext(ctx context.Context) ITemplateShareDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ITemplateShareDo
+	WriteDB() ITemplateShareDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ITemplateShareDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ITemplateShareDo
+	Not(conds ...gen.Condition) ITemplateShareDo
+	Or(conds ...gen.Condition) ITemplateShareDo
+	Select(conds ...field.Expr) ITemplateShareDo
+	Where(conds ...gen.Condition) ITemplateShareDo
+	Order(conds ...field.Expr) ITemplateShareDo
+	Distinct(cols ...field.Expr) ITemplateShareDo
+	Omit(cols ...field.Expr) ITemplateShareDo
+	Join(table schema.Tabler, on ...field.Expr) ITemplateShareDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateShareDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ITemplateShareDo
+	Group(cols ...field.Expr) ITemplateShareDo
+	Having(conds ...gen.Condition) ITemplateShareDo
+	Limit(limit int) ITemplateShareDo
+	Offset(offset int) ITemplateShareDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateShareDo
+	Unscoped() ITemplateShareDo
+	Create(values ...*model.TemplateShare) error
+	CreateInBatches(values []*model.TemplateShare, batchSize int) error
+	Save(values ...*model.TemplateShare) error
+	First() (*model.TemplateShare, error)
+	Take() (*model.TemplateShare, error)
+	Last() (*model.TemplateShare, error)
+	Find() ([]*model.TemplateShare, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateShare, err error)
+	FindInBatches(result *[]*model.TemplateShare, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.TemplateShare) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ITemplateShareDo
+	Assign(attrs ...field.AssignExpr) ITemplateShareDo
+	Joins(fields ...field.RelationField) ITemplateShareDo
+	Preload(fields ...field.RelationField) ITemplateShareDo
+	FirstOrInit() (*model.TemplateShare, error)
+	FirstOrCreate() (*model.TemplateShare, error)
+	FindByPage(offset int, limit int) (result []*model.TemplateShare, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ITemplateShareDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (t templateShareDo) Debug() ITemplateShareDo {
+	return t.withDO(t.DO.Debug())
+}
+
+func (t templateShareDo) WithContext(ctx context.Context) ITemplateShareDo {
+	return t.withDO(t.DO.WithContext(ctx))
+}
+
+func (t templateShareDo) ReadDB() ITemplateShareDo {
+	return t.Clauses(dbresolver.Read)
+}
+
+func (t templateShareDo) WriteDB() ITemplateShareDo {
+	return t.Clauses(dbresolver.Write)
+}
+
+func (t templateShareDo) Session(config *gorm.Session) ITemplateShareDo {
+	return t.withDO(t.DO.Session(config))
+}
+
+func (t templateShareDo) Clauses(conds ...clause.Expression) ITemplateShareDo {
+	return t.withDO(t.DO.Clauses(conds...))
+}
+
+func (t templateShareDo) Returning(value interface{}, columns ...string) ITemplateShareDo {
+	return t.withDO(t.DO.Returning(value, columns...))
+}
+
+func (t templateShareDo) Not(conds ...gen.Condition) ITemplateShareDo {
+	return t.withDO(t.DO.Not(conds...))
+}
+
+func (t templateShareDo) Or(conds ...gen.Condition) ITemplateShareDo {
+	return t.withDO(t.DO.Or(conds...))
+}
+
+func (t templateShareDo) Select(conds ...field.Expr) ITemplateShareDo {
+	return t.withDO(t.DO.Select(conds...))
+}
+
+func (t templateShareDo) Where(conds ...gen.Condition) ITemplateShareDo {
+	return t.withDO(t.DO.Where(conds...))
+}
+
+func (t templateShareDo) Order(conds ...field.Expr) ITemplateShareDo {
+	return t.withDO(t.DO.Order(conds...))
+}
+
+func (t templateShareDo) Distinct(cols ...field.Expr) ITemplateShareDo {
+	return t.withDO(t.DO.Distinct(cols...))
+}
+
+func (t templateShareDo) Omit(cols ...field.Expr) ITemplateShareDo {
+	return t.withDO(t.DO.Omit(cols...))
+}
+
+func (t templateShareDo) Join(table schema.Tabler, on ...field.Expr) ITemplateShareDo {
+	return t.withDO(t.DO.Join(table, on...))
+}
+
+func (t templateShareDo) LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateShareDo {
+	return t.withDO(t.DO.LeftJoin(table, on...))
+}
+
+func (t templateShareDo) RightJoin(table schema.Tabler, on ...field.Expr) ITemplateShareDo {
+	return t.withDO(t.DO.RightJoin(table, on...))
+}
+
+func (t templateShareDo) Group(cols ...field.Expr) ITemplateShareDo {
+	return t.withDO(t.DO.Group(cols...))
+}
+
+func (t templateShareDo) Having(conds ...gen.Condition) ITemplateShareDo {
+	return t.withDO(t.DO.Having(conds...))
+}
+
+func (t templateShareDo) Limit(limit int) ITemplateShareDo {
+	return t.withDO(t.DO.Limit(limit))
+}
+
+func (t templateShareDo) Offset(offset int) ITemplateShareDo {
+	return t.withDO(t.DO.Offset(offset))
+}
+
+func (t templateShareDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateShareDo {
+	return t.withDO(t.DO.Scopes(funcs...))
+}
+
+func (t templateShareDo) Unscoped() ITemplateShareDo {
+	return t.withDO(t.DO.Unscoped())
+}
+
+func (t templateShareDo) Create(values ...*model.TemplateShare) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Create(values)
+}
+
+func (t templateShareDo) CreateInBatches(values []*model.TemplateShare, batchSize int) error {
+	return t.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (t templateShareDo) Save(values ...*model.TemplateShare) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Save(values)
+}
+
+func (t templateShareDo) First() (*model.TemplateShare, error) {
+	if result, err := t.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateShare), nil
+	}
+}
+
+func (t templateShareDo) Take() (*model.TemplateShare, error) {
+	if result, err := t.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateShare), nil
+	}
+}
+
+func (t templateShareDo) Last() (*model.TemplateShare, error) {
+	if result, err := t.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateShare), nil
+	}
+}
+
+func (t templateShareDo) Find() ([]*model.TemplateShare, error) {
+	result, err := t.DO.Find()
+	return result.([]*model.TemplateShare), err
+}
+
+func (t templateShareDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateShare, err error) {
+	buf := make([]*model.TemplateShare, 0, batchSize)
+	err = t.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (t templateShareDo) FindInBatches(result *[]*model.TemplateShare, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return t.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (t templateShareDo) Attrs(attrs ...field.AssignExpr) ITemplateShareDo {
+	return t.withDO(t.DO.Attrs(attrs...))
+}
+
+func (t templateShareDo) Assign(attrs ...field.AssignExpr) ITemplateShareDo {
+	return t.withDO(t.DO.Assign(attrs...))
+}
+
+func (t templateShareDo) Joins(fields ...field.RelationField) ITemplateShareDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Joins(_f))
+	}
+	return &t
+}
+
+func (t templateShareDo) Preload(fields ...field.RelationField) ITemplateShareDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Preload(_f))
+	}
+	return &t
+}
+
+func (t templateShareDo) FirstOrInit() (*model.TemplateShare, error) {
+	if result, err := t.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateShare), nil
+	}
+}
+
+func (t templateShareDo) FirstOrCreate() (*model.TemplateShare, error) {
+	if result, err := t.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateShare), nil
+	}
+}
+
+func (t templateShareDo) FindByPage(offset int, limit int) (result []*model.TemplateShare, count int64, err error) {
+	result, err = t.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = t.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (t templateShareDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = t.Count()
+	if err != nil {
+		return
+	}
+
+	err = t.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (t templateShareDo) Scan(result interface{}) (err error) {
+	return t.DO.Scan(result)
+}
+
+func (t templateShareDo) Delete(models ...*model.TemplateShare) (result gen.ResultInfo, err error) {
+	return t.DO.Delete(models)
+}
+
+func (t *templateShareDo) withDO(do gen.Dao) *templateShareDo {
+	t.DO = *do.(*gen.DO)
+	return t
+}
@@ -0,0 +1,395 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newTemplateSlugRedirect(db *gorm.DB, opts ...gen.DOOption) templateSlugRedirect {
+	_templateSlugRedirect := templateSlugRedirect{}
+
+	_templateSlugRedirect.templateSlugRedirectDo.UseDB(db, opts...)
+	_templateSlugRedirect.templateSlugRedirectDo.UseModel(&model.TemplateSlugRedirect{})
+
+	tableName := _templateSlugRedirect.templateSlugRedirectDo.TableName()
+	_templateSlugRedirect.ALL = field.NewAsterisk(tableName)
+	_templateSlugRedirect.ID = field.NewInt32(tableName, "id")
+	_templateSlugRedirect.OldSlug = field.NewString(tableName, "old_slug")
+	_templateSlugRedirect.TemplateID = field.NewInt32(tableName, "template_id")
+	_templateSlugRedirect.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_templateSlugRedirect.fillFieldMap()
+
+	return _templateSlugRedirect
+}
+
+type templateSlugRedirect struct {
+	templateSlugRedirectDo
+
+	ALL        field.Asterisk
+	ID         field.Int32
+	OldSlug    field.String
+	TemplateID field.Int32
+	CreatedAt  field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (t templateSlugRedirect) Table(newTableName string) *templateSlugRedirect {
+	t.templateSlugRedirectDo.UseTable(newTableName)
+	return t.updateTableName(newTableName)
+}
+
+func (t templateSlugRedirect) As(alias string) *templateSlugRedirect {
+	t.templateSlugRedirectDo.DO = *(t.templateSlugRedirectDo.As(alias).(*gen.DO))
+	return t.updateTableName(alias)
+}
+
+func (t *templateSlugRedirect) updateTableName(table string) *templateSlugRedirect {
+	t.ALL = field.NewAsterisk(table)
+	t.ID = field.NewInt32(table, "id")
+	t.OldSlug = field.NewString(table, "old_slug")
+	t.TemplateID = field.NewInt32(table, "template_id")
+	t.CreatedAt = field.NewTime(table, "created_at")
+
+	t.fillFieldMap()
+
+	return t
+}
+
+func (t *templateSlugRedirect) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := t.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (t *templateSlugRedirect) fillFieldMap() {
+	t.fieldMap = make(map[string]field.Expr, 4)
+	t.fieldMap["id"] = t.ID
+	t.fieldMap["old_slug"] = t.OldSlug
+	t.fieldMap["template_id"] = t.TemplateID
+	t.fieldMap["created_at"] = t.CreatedAt
+}
+
+func (t templateSlugRedirect) clone(db *gorm.DB) templateSlugRedirect {
+	t.templateSlugRedirectDo.ReplaceConnPool(db.Statement.ConnPool)
+	return t
+}
+
+func (t templateSlugRedirect) replaceDB(db *gorm.DB) templateSlugRedirect {
+	t.templateSlugRedirectDo.ReplaceDB(db)
+	return t
+}
+
+type templateSlugRedirectDo struct{ gen.DO }
+
+type ITemplateSlugRedirectDo interface {
+	gen.SubQuery
+	Debug() ITemplateSlugRedirectDo
+	WithContext(ctx context.Context) ITemplateSlugRedirectDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ITemplateSlugRedirectDo
+	WriteDB() ITemplateSlugRedirectDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ITemplateSlugRedirectDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ITemplateSlugRedirectDo
+	Not(conds ...gen.Condition) ITemplateSlugRedirectDo
+	Or(conds ...gen.Condition) ITemplateSlugRedirectDo
+	Select(conds ...field.Expr) ITemplateSlugRedirectDo
+	Where(conds ...gen.Condition) ITemplateSlugRedirectDo
+	Order(conds ...field.Expr) ITemplateSlugRedirectDo
+	Distinct(cols ...field.Expr) ITemplateSlugRedirectDo
+	Omit(cols ...field.Expr) ITemplateSlugRedirectDo
+	Join(table schema.Tabler, on ...field.Expr) ITemplateSlugRedirectDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateSlugRedirectDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ITemplateSlugRedirectDo
+	Group(cols ...field.Expr) ITemplateSlugRedirectDo
+	Having(conds ...gen.Condition) ITemplateSlugRedirectDo
+	Limit(limit int) ITemplateSlugRedirectDo
+	Offset(offset int) ITemplateSlugRedirectDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateSlugRedirectDo
+	Unscoped() ITemplateSlugRedirectDo
+	Create(values ...*model.TemplateSlugRedirect) error
+	CreateInBatches(values []*model.TemplateSlugRedirect, batchSize int) error
+	Save(values ...*model.TemplateSlugRedirect) error
+	First() (*model.TemplateSlugRedirect, error)
+	Take() (*model.TemplateSlugRedirect, error)
+	Last() (*model.TemplateSlugRedirect, error)
+	Find() ([]*model.TemplateSlugRedirect, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateSlugRedirect, err error)
+	FindInBatches(result *[]*model.TemplateSlugRedirect, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.TemplateSlugRedirect) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ITemplateSlugRedirectDo
+	Assign(attrs ...field.AssignExpr) ITemplateSlugRedirectDo
+	Joins(fields ...field.RelationField) ITemplateSlugRedirectDo
+	Preload(fields ...field.RelationField) ITemplateSlugRedirectDo
+	FirstOrInit() (*model.TemplateSlugRedirect, error)
+	FirstOrCreate() (*model.TemplateSlugRedirect, error)
+	FindByPage(offset int, limit int) (result []*model.TemplateSlugRedirect, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ITemplateSlugRedirectDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (t templateSlugRedirectDo) Debug() ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Debug())
+}
+
+func (t templateSlugRedirectDo) WithContext(ctx context.Context) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.WithContext(ctx))
+}
+
+func (t templateSlugRedirectDo) ReadDB() ITemplateSlugRedirectDo {
+	return t.Clauses(dbresolver.Read)
+}
+
+func (t templateSlugRedirectDo) WriteDB() ITemplateSlugRedirectDo {
+	return t.Clauses(dbresolver.Write)
+}
+
+func (t templateSlugRedirectDo) Session(config *gorm.Session) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Session(config))
+}
+
+func (t templateSlugRedirectDo) Clauses(conds ...clause.Expression) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Clauses(conds...))
+}
+
+func (t templateSlugRedirectDo) Returning(value interface{}, columns ...string) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Returning(value, columns...))
+}
+
+func (t templateSlugRedirectDo) Not(conds ...gen.Condition) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Not(conds...))
+}
+
+func (t templateSlugRedirectDo) Or(conds ...gen.Condition) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Or(conds...))
+}
+
+func (t templateSlugRedirectDo) Select(conds ...field.Expr) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Select(conds...))
+}
+
+func (t templateSlugRedirectDo) Where(conds ...gen.Condition) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Where(conds...))
+}
+
+func (t templateSlugRedirectDo) Order(conds ...field.Expr) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Order(conds...))
+}
+
+func (t templateSlugRedirectDo) Distinct(cols ...field.Expr) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Distinct(cols...))
+}
+
+func (t templateSlugRedirectDo) Omit(cols ...field.Expr) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Omit(cols...))
+}
+
+func (t templateSlugRedirectDo) Join(table schema.Tabler, on ...field.Expr) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Join(table, on...))
+}
+
+func (t templateSlugRedirectDo) LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.LeftJoin(table, on...))
+}
+
+func (t templateSlugRedirectDo) RightJoin(table schema.Tabler, on ...field.Expr) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.RightJoin(table, on...))
+}
+
+func (t templateSlugRedirectDo) Group(cols ...field.Expr) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Group(cols...))
+}
+
+func (t templateSlugRedirectDo) Having(conds ...gen.Condition) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Having(conds...))
+}
+
+func (t templateSlugRedirectDo) Limit(limit int) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Limit(limit))
+}
+
+func (t templateSlugRedirectDo) Offset(offset int) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Offset(offset))
+}
+
+func (t templateSlugRedirectDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Scopes(funcs...))
+}
+
+func (t templateSlugRedirectDo) Unscoped() ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Unscoped())
+}
+
+func (t templateSlugRedirectDo) Create(values ...*model.TemplateSlugRedirect) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Create(values)
+}
+
+func (t templateSlugRedirectDo) CreateInBatches(values []*model.TemplateSlugRedirect, batchSize int) error {
+	return t.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (t templateSlugRedirectDo) Save(values ...*model.TemplateSlugRedirect) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Save(values)
+}
+
+func (t templateSlugRedirectDo) First() (*model.TemplateSlugRedirect, error) {
+	if result, err := t.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateSlugRedirect), nil
+	}
+}
+
+func (t templateSlugRedirectDo) Take() (*model.TemplateSlugRedirect, error) {
+	if result, err := t.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateSlugRedirect), nil
+	}
+}
+
+func (t templateSlugRedirectDo) Last() (*model.TemplateSlugRedirect, error) {
+	if result, err := t.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateSlugRedirect), nil
+	}
+}
+
+func (t templateSlugRedirectDo) Find() ([]*model.TemplateSlugRedirect, error) {
+	result, err := t.DO.Find()
+	return result.([]*model.TemplateSlugRedirect), err
+}
+
+func (t templateSlugRedirectDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateSlugRedirect, err error) {
+	buf := make([]*model.TemplateSlugRedirect, 0, batchSize)
+	err = t.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (t templateSlugRedirectDo) FindInBatches(result *[]*model.TemplateSlugRedirect, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return t.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (t templateSlugRedirectDo) Attrs(attrs ...field.AssignExpr) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Attrs(attrs...))
+}
+
+func (t templateSlugRedirectDo) Assign(attrs ...field.AssignExpr) ITemplateSlugRedirectDo {
+	return t.withDO(t.DO.Assign(attrs...))
+}
+
+func (t templateSlugRedirectDo) Joins(fields ...field.RelationField) ITemplateSlugRedirectDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Joins(_f))
+	}
+	return &t
+}
+
+func (t templateSlugRedirectDo) Preload(fields ...field.RelationField) ITemplateSlugRedirectDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Preload(_f))
+	}
+	return &t
+}
+
+func (t templateSlugRedirectDo) FirstOrInit() (*model.TemplateSlugRedirect, error) {
+	if result, err := t.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateSlugRedirect), nil
+	}
+}
+
+func (t templateSlugRedirectDo) FirstOrCreate() (*model.TemplateSlugRedirect, error) {
+	if result, err := t.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateSlugRedirect), nil
+	}
+}
+
+func (t templateSlugRedirectDo) FindByPage(offset int, limit int) (result []*model.TemplateSlugRedirect, count int64, err error) {
+	result, err = t.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = t.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (t templateSlugRedirectDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = t.Count()
+	if err != nil {
+		return
+	}
+
+	err = t.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (t templateSlugRedirectDo) Scan(result interface{}) (err error) {
+	return t.DO.Scan(result)
+}
+
+func (t templateSlugRedirectDo) Delete(models ...*model.TemplateSlugRedirect) (result gen.ResultInfo, err error) {
+	return t.DO.Delete(models)
+}
+
+func (t *templateSlugRedirectDo) withDO(do gen.Dao) *templateSlugRedirectDo {
+	t.DO = *do.(*gen.DO)
+	return t
+}
@@ -0,0 +1,391 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newTemplateTag(db *gorm.DB, opts ...gen.DOOption) templateTag {
+	_templateTag := templateTag{}
+
+	_templateTag.templateTagDo.UseDB(db, opts...)
+	_templateTag.templateTagDo.UseModel(&model.TemplateTag{})
+
+	tableName := _templateTag.templateTagDo.TableName()
+	_templateTag.ALL = field.NewAsterisk(tableName)
+	_templateTag.TemplateID = field.NewInt32(tableName, "template_id")
+	_templateTag.TagID = field.NewInt32(tableName, "tag_id")
+	_templateTag.SortOrder = field.NewInt32(tableName, "sort_order")
+
+	_templateTag.fillFieldMap()
+
+	return _templateTag
+}
+
+type templateTag struct {
+	templateTagDo
+
+	ALL        field.Asterisk
+	TemplateID field.Int32
+	TagID      field.Int32
+	SortOrder  field.Int32
+
+	fieldMap map[string]field.Expr
+}
+
+func (t templateTag) Table(newTableName string) *templateTag {
+	t.templateTagDo.UseTable(newTableName)
+	return t.updateTableName(newTableName)
+}
+
+func (t templateTag) As(alias string) *templateTag {
+	t.templateTagDo.DO = *(t.templateTagDo.As(alias).(*gen.DO))
+	return t.updateTableName(alias)
+}
+
+func (t *templateTag) updateTableName(table string) *templateTag {
+	t.ALL = field.NewAsterisk(table)
+	t.TemplateID = field.NewInt32(table, "template_id")
+	t.TagID = field.NewInt32(table, "tag_id")
+	t.SortOrder = field.NewInt32(table, "sort_order")
+
+	t.fillFieldMap()
+
+	return t
+}
+
+func (t *templateTag) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := t.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (t *templateTag) fillFieldMap() {
+	t.fieldMap = make(map[string]field.Expr, 3)
+	t.fieldMap["template_id"] = t.TemplateID
+	t.fieldMap["tag_id"] = t.TagID
+	t.fieldMap["sort_order"] = t.SortOrder
+}
+
+func (t templateTag) clone(db *gorm.DB) templateTag {
+	t.templateTagDo.ReplaceConnPool(db.Statement.ConnPool)
+	return t
+}
+
+func (t templateTag) replaceDB(db *gorm.DB) templateTag {
+	t.templateTagDo.ReplaceDB(db)
+	return t
+}
+
+type templateTagDo struct{ gen.DO }
+
+type ITemplateTagDo interface {
+	gen.SubQuery
+	Debug() ITemplateTagDo
+	WithContext(ctx context.Context) ITemplateTagDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ITemplateTagDo
+	WriteDB() ITemplateTagDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ITemplateTagDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ITemplateTagDo
+	Not(conds ...gen.Condition) ITemplateTagDo
+	Or(conds ...gen.Condition) ITemplateTagDo
+	Select(conds ...field.Expr) ITemplateTagDo
+	Where(conds ...gen.Condition) ITemplateTagDo
+	Order(conds ...field.Expr) ITemplateTagDo
+	Distinct(cols ...field.Expr) ITemplateTagDo
+	Omit(cols ...field.Expr) ITemplateTagDo
+	Join(table schema.Tabler, on ...field.Expr) ITemplateTagDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateTagDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ITemplateTagDo
+	Group(cols ...field.Expr) ITemplateTagDo
+	Having(conds ...gen.Condition) ITemplateTagDo
+	Limit(limit int) ITemplateTagDo
+	Offset(offset int) ITemplateTagDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateTagDo
+	Unscoped() ITemplateTagDo
+	Create(values ...*model.TemplateTag) error
+	CreateInBatches(values []*model.TemplateTag, batchSize int) error
+	Save(values ...*model.TemplateTag) error
+	First() (*model.TemplateTag, error)
+	Take() (*model.TemplateTag, error)
+	Last() (*model.TemplateTag, error)
+	Find() ([]*model.TemplateTag, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateTag, err error)
+	FindInBatches(result *[]*model.TemplateTag, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.TemplateTag) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ITemplateTagDo
+	Assign(attrs ...field.AssignExpr) ITemplateTagDo
+	Joins(fields ...field.RelationField) ITemplateTagDo
+	Preload(fields ...field.RelationField) ITemplateTagDo
+	FirstOrInit() (*model.TemplateTag, error)
+	FirstOrCreate() (*model.TemplateTag, error)
+	FindByPage(offset int, limit int) (result []*model.TemplateTag, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ITemplateTagDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (t templateTagDo) Debug() ITemplateTagDo {
+	return t.withDO(t.DO.Debug())
+}
+
+func (t templateTagDo) WithContext(ctx context.Context) ITemplateTagDo {
+	return t.withDO(t.DO.WithContext(ctx))
+}
+
+func (t templateTagDo) ReadDB() ITemplateTagDo {
+	return t.Clauses(dbresolver.Read)
+}
+
+func (t templateTagDo) WriteDB() ITemplateTagDo {
+	return t.Clauses(dbresolver.Write)
+}
+
+func (t templateTagDo) Session(config *gorm.Session) ITemplateTagDo {
+	return t.withDO(t.DO.Session(config))
+}
+
+func (t templateTagDo) Clauses(conds ...clause.Expression) ITemplateTagDo {
+	return t.withDO(t.DO.Clauses(conds...))
+}
+
+func (t templateTagDo) Returning(value interface{}, columns ...string) ITemplateTagDo {
+	return t.withDO(t.DO.Returning(value, columns...))
+}
+
+func (t templateTagDo) Not(conds ...gen.Condition) ITemplateTagDo {
+	return t.withDO(t.DO.Not(conds...))
+}
+
+func (t templateTagDo) Or(conds ...gen.Condition) ITemplateTagDo {
+	return t.withDO(t.DO.Or(conds...))
+}
+
+func (t templateTagDo) Select(conds ...field.Expr) ITemplateTagDo {
+	return t.withDO(t.DO.Select(conds...))
+}
+
+func (t templateTagDo) Where(conds ...gen.Condition) ITemplateTagDo {
+	return t.withDO(t.DO.Where(conds...))
+}
+
+func (t templateTagDo) Order(conds ...field.Expr) ITemplateTagDo {
+	return t.withDO(t.DO.Order(conds...))
+}
+
+func (t templateTagDo) Distinct(cols ...field.Expr) ITemplateTagDo {
+	return t.withDO(t.DO.Distinct(cols...))
+}
+
+func (t templateTagDo) Omit(cols ...field.Expr) ITemplateTagDo {
+	return t.withDO(t.DO.Omit(cols...))
+}
+
+func (t templateTagDo) Join(table schema.Tabler, on ...field.Expr) ITemplateTagDo {
+	return t.withDO(t.DO.Join(table, on...))
+}
+
+func (t templateTagDo) LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateTagDo {
+	return t.withDO(t.DO.LeftJoin(table, on...))
+}
+
+func (t templateTagDo) RightJoin(table schema.Tabler, on ...field.Expr) ITemplateTagDo {
+	return t.withDO(t.DO.RightJoin(table, on...))
+}
+
+func (t templateTagDo) Group(cols ...field.Expr) ITemplateTagDo {
+	return t.withDO(t.DO.Group(cols...))
+}
+
+func (t templateTagDo) Having(conds ...gen.Condition) ITemplateTagDo {
+	return t.withDO(t.DO.Having(conds...))
+}
+
+func (t templateTagDo) Limit(limit int) ITemplateTagDo {
+	return t.withDO(t.DO.Limit(limit))
+}
+
+func (t templateTagDo) Offset(offset int) ITemplateTagDo {
+	return t.withDO(t.DO.Offset(offset))
+}
+
+func (t templateTagDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateTagDo {
+	return t.withDO(t.DO.Scopes(funcs...))
+}
+
+func (t templateTagDo) Unscoped() ITemplateTagDo {
+	return t.withDO(t.DO.Unscoped())
+}
+
+func (t templateTagDo) Create(values ...*model.TemplateTag) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Create(values)
+}
+
+func (t templateTagDo) CreateInBatches(values []*model.TemplateTag, batchSize int) error {
+	return t.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (t templateTagDo) Save(values ...*model.TemplateTag) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Save(values)
+}
+
+func (t templateTagDo) First() (*model.TemplateTag, error) {
+	if result, err := t.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateTag), nil
+	}
+}
+
+func (t templateTagDo) Take() (*model.TemplateTag, error) {
+	if result, err := t.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateTag), nil
+	}
+}
+
+func (t templateTagDo) Last() (*model.TemplateTag, error) {
+	if result, err := t.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateTag), nil
+	}
+}
+
+func (t templateTagDo) Find() ([]*model.TemplateTag, error) {
+	result, err := t.DO.Find()
+	return result.([]*model.TemplateTag), err
+}
+
+func (t templateTagDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateTag, err error) {
+	buf := make([]*model.TemplateTag, 0, batchSize)
+	err = t.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (t templateTagDo) FindInBatches(result *[]*model.TemplateTag, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return t.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (t templateTagDo) Attrs(attrs ...field.AssignExpr) ITemplateTagDo {
+	return t.withDO(t.DO.Attrs(attrs...))
+}
+
+func (t templateTagDo) Assign(attrs ...field.AssignExpr) ITemplateTagDo {
+	return t.withDO(t.DO.Assign(attrs...))
+}
+
+func (t templateTagDo) Joins(fields ...field.RelationField) ITemplateTagDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Joins(_f))
+	}
+	return &t
+}
+
+func (t templateTagDo) Preload(fields ...field.RelationField) ITemplateTagDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Preload(_f))
+	}
+	return &t
+}
+
+func (t templateTagDo) FirstOrInit() (*model.TemplateTag, error) {
+	if result, err := t.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateTag), nil
+	}
+}
+
+func (t templateTagDo) FirstOrCreate() (*model.TemplateTag, error) {
+	if result, err := t.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateTag), nil
+	}
+}
+
+func (t templateTagDo) FindByPage(offset int, limit int) (result []*model.TemplateTag, count int64, err error) {
+	result, err = t.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = t.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (t templateTagDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = t.Count()
+	if err != nil {
+		return
+	}
+
+	err = t.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (t templateTagDo) Scan(result interface{}) (err error) {
+	return t.DO.Scan(result)
+}
+
+func (t templateTagDo) Delete(models ...*model.TemplateTag) (result gen.ResultInfo, err error) {
+	return t.DO.Delete(models)
+}
+
+func (t *templateTagDo) withDO(do gen.Dao) *templateTagDo {
+	t.DO = *do.(*gen.DO)
+	return t
+}
@@ -0,0 +1,435 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newTemplateTranslation(db *gorm.DB, opts ...gen.DOOption) templateTranslation {
+	_templateTranslation := templateTranslation{}
+
+	_templateTranslation.templateTranslationDo.UseDB(db, opts...)
+	_templateTranslation.templateTranslationDo.UseModel(&model.TemplateTranslation{})
+
+	tableName := _templateTranslation.templateTranslationDo.TableName()
+	_templateTranslation.ALL = field.NewAsterisk(tableName)
+	_templateTranslation.ID = field.NewInt32(tableName, "id")
+	_templateTranslation.TemplateID = field.NewInt32(tableName, "template_id")
+	_templateTranslation.Locale = field.NewString(tableName, "locale")
+	_templateTranslation.Title = field.NewString(tableName, "title")
+	_templateTranslation.Description = field.NewString(tableName, "description")
+	_templateTranslation.Headline = field.NewString(tableName, "headline")
+	_templateTranslation.Summary = field.NewString(tableName, "summary")
+	_templateTranslation.ReplySoft = field.NewString(tableName, "reply_soft")
+	_templateTranslation.ReplyNeutral = field.NewString(tableName, "reply_neutral")
+	_templateTranslation.ReplyFirm = field.NewString(tableName, "reply_firm")
+	_templateTranslation.WhenNotToUse = field.NewString(tableName, "when_not_to_use")
+	_templateTranslation.BestPractices = field.NewString(tableName, "best_practices")
+	_templateTranslation.CreatedAt = field.NewTime(tableName, "created_at")
+	_templateTranslation.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_templateTranslation.fillFieldMap()
+
+	return _templateTranslation
+}
+
+type templateTranslation struct {
+	templateTranslationDo
+
+	ALL           field.Asterisk
+	ID            field.Int32
+	TemplateID    field.Int32
+	Locale        field.String
+	Title         field.String
+	Description   field.String
+	Headline      field.String
+	Summary       field.String
+	ReplySoft     field.String
+	ReplyNeutral  field.String
+	ReplyFirm     field.String
+	WhenNotToUse  field.String
+	BestPractices field.String
+	CreatedAt     field.Time
+	UpdatedAt     field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (t templateTranslation) Table(newTableName string) *templateTranslation {
+	t.templateTranslationDo.UseTable(newTableName)
+	return t.updateTableName(newTableName)
+}
+
+func (t templateTranslation) As(alias string) *templateTranslation {
+	t.templateTranslationDo.DO = *(t.templateTranslationDo.As(alias).(*gen.DO))
+	return t.updateTableName(alias)
+}
+
+func (t *templateTranslation) updateTableName(table string) *templateTranslation {
+	t.ALL = field.NewAsterisk(table)
+	t.ID = field.NewInt32(table, "id")
+	t.TemplateID = field.NewInt32(table, "template_id")
+	t.Locale = field.NewString(table, "locale")
+	t.Title = field.NewString(table, "title")
+	t.Description = field.NewString(table, "description")
+	t.Headline = field.NewString(table, "headline")
+	t.Summary = field.NewString(table, "summary")
+	t.ReplySoft = field.NewString(table, "reply_soft")
+	t.ReplyNeutral = field.NewString(table, "reply_neutral")
+	t.ReplyFirm = field.NewString(table, "reply_firm")
+	t.WhenNotToUse = field.NewString(table, "when_not_to_use")
+	t.BestPractices = field.NewString(table, "best_practices")
+	t.CreatedAt = field.NewTime(table, "created_at")
+	t.UpdatedAt = field.NewTime(table, "updated_at")
+
+	t.fillFieldMap()
+
+	return t
+}
+
+func (t *templateTranslation) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := t.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (t *templateTranslation) fillFieldMap() {
+	t.fieldMap = make(map[string]field.Expr, 14)
+	t.fieldMap["id"] = t.ID
+	t.fieldMap["template_id"] = t.TemplateID
+	t.fieldMap["locale"] = t.Locale
+	t.fieldMap["title"] = t.Title
+	t.fieldMap["description"] = t.Description
+	t.fieldMap["headline"] = t.Headline
+	t.fieldMap["summary"] = t.Summary
+	t.fieldMap["reply_soft"] = t.ReplySoft
+	t.fieldMap["reply_neutral"] = t.ReplyNeutral
+	t.fieldMap["reply_firm"] = t.ReplyFirm
+	t.fieldMap["when_not_to_use"] = t.WhenNotToUse
+	t.fieldMap["best_practices"] = t.BestPractices
+	t.fieldMap["created_at"] = t.CreatedAt
+	t.fieldMap["updated_at"] = t.UpdatedAt
+}
+
+func (t templateTranslation) clone(db *gorm.DB) templateTranslation {
+	t.templateTranslationDo.ReplaceConnPool(db.Statement.ConnPool)
+	return t
+}
+
+func (t templateTranslation) replaceDB(db *gorm.DB) templateTranslation {
+	t.templateTranslationDo.ReplaceDB(db)
+	return t
+}
+
+type templateTranslationDo struct{ gen.DO }
+
+type ITemplateTranslationDo interface {
+	gen.SubQuery
+	Debug() ITemplateTranslationDo
+	WithContext(ctx context.Context) ITemplateTranslationDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ITemplateTranslationDo
+	WriteDB() ITemplateTranslationDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ITemplateTranslationDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ITemplateTranslationDo
+	Not(conds ...gen.Condition) ITemplateTranslationDo
+	Or(conds ...gen.Condition) ITemplateTranslationDo
+	Select(conds ...field.Expr) ITemplateTranslationDo
+	Where(conds ...gen.Condition) ITemplateTranslationDo
+	Order(conds ...field.Expr) ITemplateTranslationDo
+	Distinct(cols ...field.Expr) ITemplateTranslationDo
+	Omit(cols ...field.Expr) ITemplateTranslationDo
+	Join(table schema.Tabler, on ...field.Expr) ITemplateTranslationDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateTranslationDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ITemplateTranslationDo
+	Group(cols ...field.Expr) ITemplateTranslationDo
+	Having(conds ...gen.Condition) ITemplateTranslationDo
+	Limit(limit int) ITemplateTranslationDo
+	Offset(offset int) ITemplateTranslationDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateTranslationDo
+	Unscoped() ITemplateTranslationDo
+	Create(values ...*model.TemplateTranslation) error
+	CreateInBatches(values []*model.TemplateTranslation, batchSize int) error
+	Save(values ...*model.TemplateTranslation) error
+	First() (*model.TemplateTranslation, error)
+	Take() (*model.TemplateTranslation, error)
+	Last() (*model.TemplateTranslation, error)
+	Find() ([]*model.TemplateTranslation, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateTranslation, err error)
+	FindInBatches(result *[]*model.TemplateTranslation, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.TemplateTranslation) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ITemplateTranslationDo
+	Assign(attrs ...field.AssignExpr) ITemplateTranslationDo
+	Joins(fields ...field.RelationField) ITemplateTranslationDo
+	Preload(fields ...field.RelationField) ITemplateTranslationDo
+	FirstOrInit() (*model.TemplateTranslation, error)
+	FirstOrCreate() (*model.TemplateTranslation, error)
+	FindByPage(offset int, limit int) (result []*model.TemplateTranslation, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ITemplateTranslationDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (t templateTranslationDo) Debug() ITemplateTranslationDo {
+	return t.withDO(t.DO.Debug())
+}
+
+func (t templateTranslationDo) WithContext(ctx context.Context) ITemplateTranslationDo {
+	return t.withDO(t.DO.WithContext(ctx))
+}
+
+func (t templateTranslationDo) ReadDB() ITemplateTranslationDo {
+	return t.Clauses(dbresolver.Read)
+}
+
+func (t templateTranslationDo) WriteDB() ITemplateTranslationDo {
+	return t.Clauses(dbresolver.Write)
+}
+
+func (t templateTranslationDo) Session(config *gorm.Session) ITemplateTranslationDo {
+	return t.withDO(t.DO.Session(config))
+}
+
+func (t templateTranslationDo) Clauses(conds ...clause.Expression) ITemplateTranslationDo {
+	return t.withDO(t.DO.Clauses(conds...))
+}
+
+func (t templateTranslationDo) Returning(value interface{}, columns ...string) ITemplateTranslationDo {
+	return t.withDO(t.DO.Returning(value, columns...))
+}
+
+func (t templateTranslationDo) Not(conds ...gen.Condition) ITemplateTranslationDo {
+	return t.withDO(t.DO.Not(conds...))
+}
+
+func (t templateTranslationDo) Or(conds ...gen.Condition) ITemplateTranslationDo {
+	return t.withDO(t.DO.Or(conds...))
+}
+
+func (t templateTranslationDo) Select(conds ...field.Expr) ITemplateTranslationDo {
+	return t.withDO(t.DO.Select(conds...))
+}
+
+func (t templateTranslationDo) Where(conds ...gen.Condition) ITemplateTranslationDo {
+	return t.withDO(t.DO.Where(conds...))
+}
+
+func (t templateTranslationDo) Order(conds ...field.Expr) ITemplateTranslationDo {
+	return t.withDO(t.DO.Order(conds...))
+}
+
+func (t templateTranslationDo) Distinct(cols ...field.Expr) ITemplateTranslationDo {
+	return t.withDO(t.DO.Distinct(cols...))
+}
+
+func (t templateTranslationDo) Omit(cols ...field.Expr) ITemplateTranslationDo {
+	return t.withDO(t.DO.Omit(cols...))
+}
+
+func (t templateTranslationDo) Join(table schema.Tabler, on ...field.Expr) ITemplateTranslationDo {
+	return t.withDO(t.DO.Join(table, on...))
+}
+
+func (t templateTranslationDo) LeftJoin(table schema.Tabler, on ...field.Expr) ITemplateTranslationDo {
+	return t.withDO(t.DO.LeftJoin(table, on...))
+}
+
+func (t templateTranslationDo) RightJoin(table schema.Tabler, on ...field.Expr) ITemplateTranslationDo {
+	return t.withDO(t.DO.RightJoin(table, on...))
+}
+
+func (t templateTranslationDo) Group(cols ...field.Expr) ITemplateTranslationDo {
+	return t.withDO(t.DO.Group(cols...))
+}
+
+func (t templateTranslationDo) Having(conds ...gen.Condition) ITemplateTranslationDo {
+	return t.withDO(t.DO.Having(conds...))
+}
+
+func (t templateTranslationDo) Limit(limit int) ITemplateTranslationDo {
+	return t.withDO(t.DO.Limit(limit))
+}
+
+func (t templateTranslationDo) Offset(offset int) ITemplateTranslationDo {
+	return t.withDO(t.DO.Offset(offset))
+}
+
+func (t templateTranslationDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ITemplateTranslationDo {
+	return t.withDO(t.DO.Scopes(funcs...))
+}
+
+func (t templateTranslationDo) Unscoped() ITemplateTranslationDo {
+	return t.withDO(t.DO.Unscoped())
+}
+
+func (t templateTranslationDo) Create(values ...*model.TemplateTranslation) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Create(values)
+}
+
+func (t templateTranslationDo) CreateInBatches(values []*model.TemplateTranslation, batchSize int) error {
+	return t.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (t templateTranslationDo) Save(values ...*model.TemplateTranslation) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return t.DO.Save(values)
+}
+
+func (t templateTranslationDo) First() (*model.TemplateTranslation, error) {
+	if result, err := t.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateTranslation), nil
+	}
+}
+
+func (t templateTranslationDo) Take() (*model.TemplateTranslation, error) {
+	if result, err := t.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateTranslation), nil
+	}
+}
+
+func (t templateTranslationDo) Last() (*model.TemplateTranslation, error) {
+	if result, err := t.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateTranslation), nil
+	}
+}
+
+func (t templateTranslationDo) Find() ([]*model.TemplateTranslation, error) {
+	result, err := t.DO.Find()
+	return result.([]*model.TemplateTranslation), err
+}
+
+func (t templateTranslationDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.TemplateTranslation, err error) {
+	buf := make([]*model.TemplateTranslation, 0, batchSize)
+	err = t.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (t templateTranslationDo) FindInBatches(result *[]*model.TemplateTranslation, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return t.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (t templateTranslationDo) Attrs(attrs ...field.AssignExpr) ITemplateTranslationDo {
+	return t.withDO(t.DO.Attrs(attrs...))
+}
+
+func (t templateTranslationDo) Assign(attrs ...field.AssignExpr) ITemplateTranslationDo {
+	return t.withDO(t.DO.Assign(attrs...))
+}
+
+func (t templateTranslationDo) Joins(fields ...field.RelationField) ITemplateTranslationDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Joins(_f))
+	}
+	return &t
+}
+
+func (t templateTranslationDo) Preload(fields ...field.RelationField) ITemplateTranslationDo {
+	for _, _f := range fields {
+		t = *t.withDO(t.DO.Preload(_f))
+	}
+	return &t
+}
+
+func (t templateTranslationDo) FirstOrInit() (*model.TemplateTranslation, error) {
+	if result, err := t.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateTranslation), nil
+	}
+}
+
+func (t templateTranslationDo) FirstOrCreate() (*model.TemplateTranslation, error) {
+	if result, err := t.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.TemplateTranslation), nil
+	}
+}
+
+func (t templateTranslationDo) FindByPage(offset int, limit int) (result []*model.TemplateTranslation, count int64, err error) {
+	result, err = t.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = t.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (t templateTranslationDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = t.Count()
+	if err != nil {
+		return
+	}
+
+	err = t.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (t templateTranslationDo) Scan(result interface{}) (err error) {
+	return t.DO.Scan(result)
+}
+
+func (t templateTranslationDo) Delete(models ...*model.TemplateTranslation) (result gen.ResultInfo, err error) {
+	return t.DO.Delete(models)
+}
+
+func (t *templateTranslationDo) withDO(do gen.Dao) *templateTranslationDo {
+	t.DO = *do.(*gen.DO)
+	return t
+}
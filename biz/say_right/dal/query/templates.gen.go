@@ -31,11 +31,15 @@ func newTemplate(db *gorm.DB, opts ...gen.DOOption) template {
 	_template.ID = field.NewInt32(tableName, "id")
 	_template.CategoryID = field.NewInt32(tableName, "category_id")
 	_template.Title = field.NewString(tableName, "title")
+	_template.Slug = field.NewString(tableName, "slug")
 	_template.Description = field.NewString(tableName, "description")
 	_template.TagsText = field.NewString(tableName, "tags_text")
 	_template.IsPro = field.NewInt32(tableName, "is_pro")
 	_template.SortOrder = field.NewInt32(tableName, "sort_order")
 	_template.IsActive = field.NewInt32(tableName, "is_active")
+	_template.PublishAt = field.NewTime(tableName, "publish_at")
+	_template.UnpublishAt = field.NewTime(tableName, "unpublish_at")
+	_template.DraftRevisionID = field.NewInt32(tableName, "draft_revision_id")
 	_template.CreatedAt = field.NewTime(tableName, "created_at")
 	_template.UpdatedAt = field.NewTime(tableName, "updated_at")
 
@@ -47,17 +51,21 @@ func newTemplate(db *gorm.DB, opts ...gen.DOOption) template {
 type template struct {
 	templateDo
 
-	ALL         field.Asterisk
-	ID          field.Int32
-	CategoryID  field.Int32
-	Title       field.String
-	Description field.String
-	TagsText    field.String
-	IsPro       field.Int32
-	SortOrder   field.Int32
-	IsActive    field.Int32
-	CreatedAt   field.Time
-	UpdatedAt   field.Time
+	ALL             field.Asterisk
+	ID              field.Int32
+	CategoryID      field.Int32
+	Title           field.String
+	Slug            field.String
+	Description     field.String
+	TagsText        field.String
+	IsPro           field.Int32
+	SortOrder       field.Int32
+	IsActive        field.Int32
+	PublishAt       field.Time
+	UnpublishAt     field.Time
+	DraftRevisionID field.Int32
+	CreatedAt       field.Time
+	UpdatedAt       field.Time
 
 	fieldMap map[string]field.Expr
 }
@@ -77,11 +85,15 @@ func (t *template) updateTableName(table string) *template {
 	t.ID = field.NewInt32(table, "id")
 	t.CategoryID = field.NewInt32(table, "category_id")
 	t.Title = field.NewString(table, "title")
+	t.Slug = field.NewString(table, "slug")
 	t.Description = field.NewString(table, "description")
 	t.TagsText = field.NewString(table, "tags_text")
 	t.IsPro = field.NewInt32(table, "is_pro")
 	t.SortOrder = field.NewInt32(table, "sort_order")
 	t.IsActive = field.NewInt32(table, "is_active")
+	t.PublishAt = field.NewTime(table, "publish_at")
+	t.UnpublishAt = field.NewTime(table, "unpublish_at")
+	t.DraftRevisionID = field.NewInt32(table, "draft_revision_id")
 	t.CreatedAt = field.NewTime(table, "created_at")
 	t.UpdatedAt = field.NewTime(table, "updated_at")
 
@@ -100,15 +112,19 @@ func (t *template) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (t *template) fillFieldMap() {
-	t.fieldMap = make(map[string]field.Expr, 10)
+	t.fieldMap = make(map[string]field.Expr, 14)
 	t.fieldMap["id"] = t.ID
 	t.fieldMap["category_id"] = t.CategoryID
 	t.fieldMap["title"] = t.Title
+	t.fieldMap["slug"] = t.Slug
 	t.fieldMap["description"] = t.Description
 	t.fieldMap["tags_text"] = t.TagsText
 	t.fieldMap["is_pro"] = t.IsPro
 	t.fieldMap["sort_order"] = t.SortOrder
 	t.fieldMap["is_active"] = t.IsActive
+	t.fieldMap["publish_at"] = t.PublishAt
+	t.fieldMap["unpublish_at"] = t.UnpublishAt
+	t.fieldMap["draft_revision_id"] = t.DraftRevisionID
 	t.fieldMap["created_at"] = t.CreatedAt
 	t.fieldMap["updated_at"] = t.UpdatedAt
 }
@@ -0,0 +1,455 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"api/biz/say_right/dal/model"
+)
+
+func newUserTemplate(db *gorm.DB, opts ...gen.DOOption) userTemplate {
+	_userTemplate := userTemplate{}
+
+	_userTemplate.userTemplateDo.UseDB(db, opts...)
+	_userTemplate.userTemplateDo.UseModel(&model.UserTemplate{})
+
+	tableName := _userTemplate.userTemplateDo.TableName()
+	_userTemplate.ALL = field.NewAsterisk(tableName)
+	_userTemplate.ID = field.NewInt32(tableName, "id")
+	_userTemplate.OwnerID = field.NewInt32(tableName, "owner_id")
+	_userTemplate.OrganizationID = field.NewInt32(tableName, "organization_id")
+	_userTemplate.SourceTemplateID = field.NewInt32(tableName, "source_template_id")
+	_userTemplate.SourceLocale = field.NewString(tableName, "source_locale")
+	_userTemplate.Title = field.NewString(tableName, "title")
+	_userTemplate.Description = field.NewString(tableName, "description")
+	_userTemplate.TagsText = field.NewString(tableName, "tags_text")
+	_userTemplate.Headline = field.NewString(tableName, "headline")
+	_userTemplate.Summary = field.NewString(tableName, "summary")
+	_userTemplate.ReplySoft = field.NewString(tableName, "reply_soft")
+	_userTemplate.ReplyNeutral = field.NewString(tableName, "reply_neutral")
+	_userTemplate.ReplyFirm = field.NewString(tableName, "reply_firm")
+	_userTemplate.WhenNotToUse = field.NewString(tableName, "when_not_to_use")
+	_userTemplate.BestPractices = field.NewString(tableName, "best_practices")
+	_userTemplate.Variables = field.NewString(tableName, "variables")
+	_userTemplate.SourceSnapshot = field.NewString(tableName, "source_snapshot")
+	_userTemplate.CreatedAt = field.NewTime(tableName, "created_at")
+	_userTemplate.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_userTemplate.fillFieldMap()
+
+	return _userTemplate
+}
+
+type userTemplate struct {
+	userTemplateDo
+
+	ALL              field.Asterisk
+	ID               field.Int32
+	OwnerID          field.Int32
+	OrganizationID   field.Int32
+	SourceTemplateID field.Int32
+	SourceLocale     field.String
+	Title            field.String
+	Description      field.String
+	TagsText         field.String
+	Headline         field.String
+	Summary          field.String
+	ReplySoft        field.String
+	ReplyNeutral     field.String
+	ReplyFirm        field.String
+	WhenNotToUse     field.String
+	BestPractices    field.String
+	Variables        field.String
+	SourceSnapshot   field.String
+	CreatedAt        field.Time
+	UpdatedAt        field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (u userTemplate) Table(newTableName string) *userTemplate {
+	u.userTemplateDo.UseTable(newTableName)
+	return u.updateTableName(newTableName)
+}
+
+func (u userTemplate) As(alias string) *userTemplate {
+	u.userTemplateDo.DO = *(u.userTemplateDo.As(alias).(*gen.DO))
+	return u.updateTableName(alias)
+}
+
+func (u *userTemplate) updateTableName(table string) *userTemplate {
+	u.ALL = field.NewAsterisk(table)
+	u.ID = field.NewInt32(table, "id")
+	u.OwnerID = field.NewInt32(table, "owner_id")
+	u.OrganizationID = field.NewInt32(table, "organization_id")
+	u.SourceTemplateID = field.NewInt32(table, "source_template_id")
+	u.SourceLocale = field.NewString(table, "source_locale")
+	u.Title = field.NewString(table, "title")
+	u.Description = field.NewString(table, "description")
+	u.TagsText = field.NewString(table, "tags_text")
+	u.Headline = field.NewString(table, "headline")
+	u.Summary = field.NewString(table, "summary")
+	u.ReplySoft = field.NewString(table, "reply_soft")
+	u.ReplyNeutral = field.NewString(table, "reply_neutral")
+	u.ReplyFirm = field.NewString(table, "reply_firm")
+	u.WhenNotToUse = field.NewString(table, "when_not_to_use")
+	u.BestPractices = field.NewString(table, "best_practices")
+	u.Variables = field.NewString(table, "variables")
+	u.SourceSnapshot = field.NewString(table, "source_snapshot")
+	u.CreatedAt = field.NewTime(table, "created_at")
+	u.UpdatedAt = field.NewTime(table, "updated_at")
+
+	u.fillFieldMap()
+
+	return u
+}
+
+func (u *userTemplate) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := u.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (u *userTemplate) fillFieldMap() {
+	u.fieldMap = make(map[string]field.Expr, 19)
+	u.fieldMap["id"] = u.ID
+	u.fieldMap["owner_id"] = u.OwnerID
+	u.fieldMap["organization_id"] = u.OrganizationID
+	u.fieldMap["source_template_id"] = u.SourceTemplateID
+	u.fieldMap["source_locale"] = u.SourceLocale
+	u.fieldMap["title"] = u.Title
+	u.fieldMap["description"] = u.Description
+	u.fieldMap["tags_text"] = u.TagsText
+	u.fieldMap["headline"] = u.Headline
+	u.fieldMap["summary"] = u.Summary
+	u.fieldMap["reply_soft"] = u.ReplySoft
+	u.fieldMap["reply_neutral"] = u.ReplyNeutral
+	u.fieldMap["reply_firm"] = u.ReplyFirm
+	u.fieldMap["when_not_to_use"] = u.WhenNotToUse
+	u.fieldMap["best_practices"] = u.BestPractices
+	u.fieldMap["variables"] = u.Variables
+	u.fieldMap["source_snapshot"] = u.SourceSnapshot
+	u.fieldMap["created_at"] = u.CreatedAt
+	u.fieldMap["updated_at"] = u.UpdatedAt
+}
+
+func (u userTemplate) clone(db *gorm.DB) userTemplate {
+	u.userTemplateDo.ReplaceConnPool(db.Statement.ConnPool)
+	return u
+}
+
+func (u userTemplate) replaceDB(db *gorm.DB) userTemplate {
+	u.userTemplateDo.ReplaceDB(db)
+	return u
+}
+
+type userTemplateDo struct{ gen.DO }
+
+type IUserTemplateDo interface {
+	gen.SubQuery
+	Debug() IUserTemplateDo
+	WithContext(ctx context.Context) IUserTemplateDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() IUserTemplateDo
+	WriteDB() IUserTemplateDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) IUserTemplateDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) IUserTemplateDo
+	Not(conds ...gen.Condition) IUserTemplateDo
+	Or(conds ...gen.Condition) IUserTemplateDo
+	Select(conds ...field.Expr) IUserTemplateDo
+	Where(conds ...gen.Condition) IUserTemplateDo
+	Order(conds ...field.Expr) IUserTemplateDo
+	Distinct(cols ...field.Expr) IUserTemplateDo
+	Omit(cols ...field.Expr) IUserTemplateDo
+	Join(table schema.Tabler, on ...field.Expr) IUserTemplateDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) IUserTemplateDo
+	RightJoin(table schema.Tabler, on ...field.Expr) IUserTemplateDo
+	Group(cols ...field.Expr) IUserTemplateDo
+	Having(conds ...gen.Condition) IUserTemplateDo
+	Limit(limit int) IUserTemplateDo
+	Offset(offset int) IUserTemplateDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) IUserTemplateDo
+	Unscoped() IUserTemplateDo
+	Create(values ...*model.UserTemplate) error
+	CreateInBatches(values []*model.UserTemplate, batchSize int) error
+	Save(values ...*model.UserTemplate) error
+	First() (*model.UserTemplate, error)
+	Take() (*model.UserTemplate, error)
+	Last() (*model.UserTemplate, error)
+	Find() ([]*model.UserTemplate, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.UserTemplate, err error)
+	FindInBatches(result *[]*model.UserTemplate, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*model.UserTemplate) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) IUserTemplateDo
+	Assign(attrs ...field.AssignExpr) IUserTemplateDo
+	Joins(fields ...field.RelationField) IUserTemplateDo
+	Preload(fields ...field.RelationField) IUserTemplateDo
+	FirstOrInit() (*model.UserTemplate, error)
+	FirstOrCreate() (*model.UserTemplate, error)
+	FindByPage(offset int, limit int) (result []*model.UserTemplate, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) IUserTemplateDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (u userTemplateDo) Debug() IUserTemplateDo {
+	return u.withDO(u.DO.Debug())
+}
+
+func (u userTemplateDo) WithContext(ctx context.Context) IUserTemplateDo {
+	return u.withDO(u.DO.WithContext(ctx))
+}
+
+func (u userTemplateDo) ReadDB() IUserTemplateDo {
+	return u.Clauses(dbresolver.Read)
+}
+
+func (u userTemplateDo) WriteDB() IUserTemplateDo {
+	return u.Clauses(dbresolver.Write)
+}
+
+func (u userTemplateDo) Session(config *gorm.Session) IUserTemplateDo {
+	return u.withDO(u.DO.Session(config))
+}
+
+func (u userTemplateDo) Clauses(conds ...clause.Expression) IUserTemplateDo {
+	return u.withDO(u.DO.Clauses(conds...))
+}
+
+func (u userTemplateDo) Returning(value interface{}, columns ...string) IUserTemplateDo {
+	return u.withDO(u.DO.Returning(value, columns...))
+}
+
+func (u userTemplateDo) Not(conds ...gen.Condition) IUserTemplateDo {
+	return u.withDO(u.DO.Not(conds...))
+}
+
+func (u userTemplateDo) Or(conds ...gen.Condition) IUserTemplateDo {
+	return u.withDO(u.DO.Or(conds...))
+}
+
+func (u userTemplateDo) Select(conds ...field.Expr) IUserTemplateDo {
+	return u.withDO(u.DO.Select(conds...))
+}
+
+func (u userTemplateDo) Where(conds ...gen.Condition) IUserTemplateDo {
+	return u.withDO(u.DO.Where(conds...))
+}
+
+func (u userTemplateDo) Order(conds ...field.Expr) IUserTemplateDo {
+	return u.withDO(u.DO.Order(conds...))
+}
+
+func (u userTemplateDo) Distinct(cols ...field.Expr) IUserTemplateDo {
+	return u.withDO(u.DO.Distinct(cols...))
+}
+
+func (u userTemplateDo) Omit(cols ...field.Expr) IUserTemplateDo {
+	return u.withDO(u.DO.Omit(cols...))
+}
+
+func (u userTemplateDo) Join(table schema.Tabler, on ...field.Expr) IUserTemplateDo {
+	return u.withDO(u.DO.Join(table, on...))
+}
+
+func (u userTemplateDo) LeftJoin(table schema.Tabler, on ...field.Expr) IUserTemplateDo {
+	return u.withDO(u.DO.LeftJoin(table, on...))
+}
+
+func (u userTemplateDo) RightJoin(table schema.Tabler, on ...field.Expr) IUserTemplateDo {
+	return u.withDO(u.DO.RightJoin(table, on...))
+}
+
+func (u userTemplateDo) Group(cols ...field.Expr) IUserTemplateDo {
+	return u.withDO(u.DO.Group(cols...))
+}
+
+func (u userTemplateDo) Having(conds ...gen.Condition) IUserTemplateDo {
+	return u.withDO(u.DO.Having(conds...))
+}
+
+func (u userTemplateDo) Limit(limit int) IUserTemplateDo {
+	return u.withDO(u.DO.Limit(limit))
+}
+
+func (u userTemplateDo) Offset(offset int) IUserTemplateDo {
+	return u.withDO(u.DO.Offset(offset))
+}
+
+func (u userTemplateDo) Scopes(funcs ...func(gen.Dao) gen.Dao) IUserTemplateDo {
+	return u.withDO(u.DO.Scopes(funcs...))
+}
+
+func (u userTemplateDo) Unscoped() IUserTemplateDo {
+	return u.withDO(u.DO.Unscoped())
+}
+
+func (u userTemplateDo) Create(values ...*model.UserTemplate) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return u.DO.Create(values)
+}
+
+func (u userTemplateDo) CreateInBatches(values []*model.UserTemplate, batchSize int) error {
+	return u.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (u userTemplateDo) Save(values ...*model.UserTemplate) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return u.DO.Save(values)
+}
+
+func (u userTemplateDo) First() (*model.UserTemplate, error) {
+	if result, err := u.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.UserTemplate), nil
+	}
+}
+
+func (u userTemplateDo) Take() (*model.UserTemplate, error) {
+	if result, err := u.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.UserTemplate), nil
+	}
+}
+
+func (u userTemplateDo) Last() (*model.UserTemplate, error) {
+	if result, err := u.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.UserTemplate), nil
+	}
+}
+
+func (u userTemplateDo) Find() ([]*model.UserTemplate, error) {
+	result, err := u.DO.Find()
+	return result.([]*model.UserTemplate), err
+}
+
+func (u userTemplateDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.UserTemplate, err error) {
+	buf := make([]*model.UserTemplate, 0, batchSize)
+	err = u.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (u userTemplateDo) FindInBatches(result *[]*model.UserTemplate, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return u.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (u userTemplateDo) Attrs(attrs ...field.AssignExpr) IUserTemplateDo {
+	return u.withDO(u.DO.Attrs(attrs...))
+}
+
+func (u userTemplateDo) Assign(attrs ...field.AssignExpr) IUserTemplateDo {
+	return u.withDO(u.DO.Assign(attrs...))
+}
+
+func (u userTemplateDo) Joins(fields ...field.RelationField) IUserTemplateDo {
+	for _, _f := range fields {
+		u = *u.withDO(u.DO.Joins(_f))
+	}
+	return &u
+}
+
+func (u userTemplateDo) Preload(fields ...field.RelationField) IUserTemplateDo {
+	for _, _f := range fields {
+		u = *u.withDO(u.DO.Preload(_f))
+	}
+	return &u
+}
+
+func (u userTemplateDo) FirstOrInit() (*model.UserTemplate, error) {
+	if result, err := u.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.UserTemplate), nil
+	}
+}
+
+func (u userTemplateDo) FirstOrCreate() (*model.UserTemplate, error) {
+	if result, err := u.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.UserTemplate), nil
+	}
+}
+
+func (u userTemplateDo) FindByPage(offset int, limit int) (result []*model.UserTemplate, count int64, err error) {
+	result, err = u.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = u.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (u userTemplateDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = u.Count()
+	if err != nil {
+		return
+	}
+
+	err = u.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (u userTemplateDo) Scan(result interface{}) (err error) {
+	return u.DO.Scan(result)
+}
+
+func (u userTemplateDo) Delete(models ...*model.UserTemplate) (result gen.ResultInfo, err error) {
+	return u.DO.Delete(models)
+}
+
+func (u *userTemplateDo) withDO(do gen.Dao) *userTemplateDo {
+	u.DO = *do.(*gen.DO)
+	return u
+}
@@ -34,6 +34,11 @@ func newUser(db *gorm.DB, opts ...gen.DOOption) user {
 	_user.EmailVerifiedAt = field.NewTime(tableName, "email_verified_at")
 	_user.Status = field.NewInt32(tableName, "status")
 	_user.IsPro = field.NewInt32(tableName, "is_pro")
+	_user.TeamPro = field.NewInt32(tableName, "team_pro")
+	_user.ProUntil = field.NewTime(tableName, "pro_until")
+	_user.Role = field.NewString(tableName, "role")
+	_user.Locale = field.NewString(tableName, "locale")
+	_user.ReferralCode = field.NewString(tableName, "referral_code")
 	_user.CreatedAt = field.NewTime(tableName, "created_at")
 	_user.UpdatedAt = field.NewTime(tableName, "updated_at")
 
@@ -52,6 +57,11 @@ type user struct {
 	EmailVerifiedAt field.Time
 	Status          field.Int32
 	IsPro           field.Int32
+	TeamPro         field.Int32
+	ProUntil        field.Time
+	Role            field.String
+	Locale          field.String
+	ReferralCode    field.String
 	CreatedAt       field.Time
 	UpdatedAt       field.Time
 
@@ -76,6 +86,11 @@ func (u *user) updateTableName(table string) *user {
 	u.EmailVerifiedAt = field.NewTime(table, "email_verified_at")
 	u.Status = field.NewInt32(table, "status")
 	u.IsPro = field.NewInt32(table, "is_pro")
+	u.TeamPro = field.NewInt32(table, "team_pro")
+	u.ProUntil = field.NewTime(table, "pro_until")
+	u.Role = field.NewString(table, "role")
+	u.Locale = field.NewString(table, "locale")
+	u.ReferralCode = field.NewString(table, "referral_code")
 	u.CreatedAt = field.NewTime(table, "created_at")
 	u.UpdatedAt = field.NewTime(table, "updated_at")
 
@@ -94,13 +109,18 @@ func (u *user) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (u *user) fillFieldMap() {
-	u.fieldMap = make(map[string]field.Expr, 8)
+	u.fieldMap = make(map[string]field.Expr, 13)
 	u.fieldMap["id"] = u.ID
 	u.fieldMap["email"] = u.Email
 	u.fieldMap["email_norm"] = u.EmailNorm
 	u.fieldMap["email_verified_at"] = u.EmailVerifiedAt
 	u.fieldMap["status"] = u.Status
 	u.fieldMap["is_pro"] = u.IsPro
+	u.fieldMap["team_pro"] = u.TeamPro
+	u.fieldMap["pro_until"] = u.ProUntil
+	u.fieldMap["role"] = u.Role
+	u.fieldMap["locale"] = u.Locale
+	u.fieldMap["referral_code"] = u.ReferralCode
 	u.fieldMap["created_at"] = u.CreatedAt
 	u.fieldMap["updated_at"] = u.UpdatedAt
 }
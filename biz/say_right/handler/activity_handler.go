@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ActivityHandler struct {
+	svc service.ActivityService
+}
+
+func NewActivityHandler(svc service.ActivityService) *ActivityHandler {
+	return &ActivityHandler{
+		svc: svc,
+	}
+}
+
+// AddFavorite handles PUT /templates/:id/favorite
+func (h *ActivityHandler) AddFavorite(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.AddFavorite(c.Request.Context(), userID, templateID); err != nil {
+		writeActivityError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveFavorite handles DELETE /templates/:id/favorite
+func (h *ActivityHandler) RemoveFavorite(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RemoveFavorite(c.Request.Context(), userID, templateID); err != nil {
+		writeActivityError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListFavorites handles GET /templates/favorites
+func (h *ActivityHandler) ListFavorites(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	list, err := h.svc.ListFavorites(c.Request.Context(), userID, localeRequest(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Language", list.Locale)
+	c.JSON(http.StatusOK, list)
+}
+
+// ListRecent handles GET /templates/recent
+func (h *ActivityHandler) ListRecent(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	list, err := h.svc.ListRecent(c.Request.Context(), userID, localeRequest(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Language", list.Locale)
+	c.JSON(http.StatusOK, list)
+}
+
+// RecordCopy handles POST /templates/:id/copied
+func (h *ActivityHandler) RecordCopy(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.CopyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.RecordCopy(c.Request.Context(), userID, templateID, input); err != nil {
+		writeActivityError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func writeActivityError(c *gin.Context, err error) {
+	var validationErr service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "field": validationErr.Field})
+		return
+	}
+	switch err {
+	case service.ErrProRequired:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Pro required"})
+	case service.ErrTemplateNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
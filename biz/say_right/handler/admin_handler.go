@@ -0,0 +1,320 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	svc       service.AdminService
+	templates service.TemplateService
+	audit     service.AuditService
+}
+
+func NewAdminHandler(svc service.AdminService, templates service.TemplateService, audit service.AuditService) *AdminHandler {
+	return &AdminHandler{
+		svc:       svc,
+		templates: templates,
+		audit:     audit,
+	}
+}
+
+func (h *AdminHandler) SearchUsers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+
+	result, err := h.svc.SearchUsers(c.Request.Context(), c.Query("q"), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.svc.GetUser(c.Request.Context(), userID)
+	if err != nil {
+		writeAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *AdminHandler) ListIdentities(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	identities, err := h.svc.ListIdentities(c.Request.Context(), userID)
+	if err != nil {
+		writeAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"identities": identities})
+}
+
+func (h *AdminHandler) ListBillingEvents(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	events, err := h.svc.ListBillingEvents(c.Request.Context(), userID)
+	if err != nil {
+		writeAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"billing_events": events})
+}
+
+func (h *AdminHandler) GrantPro(c *gin.Context) {
+	h.setPro(c, true)
+}
+
+func (h *AdminHandler) RevokePro(c *gin.Context) {
+	h.setPro(c, false)
+}
+
+func (h *AdminHandler) setPro(c *gin.Context, isPro bool) {
+	userID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.SetPro(auditContext(c), userID, isPro); err != nil {
+		writeAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+func (h *AdminHandler) SuspendUser(c *gin.Context) {
+	h.setSuspended(c, true)
+}
+
+func (h *AdminHandler) UnsuspendUser(c *gin.Context) {
+	h.setSuspended(c, false)
+}
+
+func (h *AdminHandler) setSuspended(c *gin.Context, suspended bool) {
+	userID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.SetSuspended(auditContext(c), userID, suspended); err != nil {
+		writeAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+type SetRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+func (h *AdminHandler) SetRole(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.SetRole(auditContext(c), userID, req.Role); err != nil {
+		writeAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+func (h *AdminHandler) ListRoles(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"roles": service.ListRoles()})
+}
+
+func (h *AdminHandler) ResendCode(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.ResendCode(auditContext(c), userID); err != nil {
+		var rateErr service.RateLimitError
+		if errors.As(err, &rateErr) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "retry_after": rateErr.RetryAfter})
+			return
+		}
+		writeAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Verification code sent"})
+}
+
+// ImpersonateTemplates returns the template list exactly as the given user sees it.
+// Impersonation is read-only: it never touches the admin's session.
+func (h *AdminHandler) ImpersonateTemplates(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RecordImpersonation(auditContext(c), userID, "templates"); err != nil {
+		writeAdminError(c, err)
+		return
+	}
+
+	categories, err := h.templates.ListTemplatesByCategory(c.Request.Context(), userID, nil, service.LocaleRequest{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+func (h *AdminHandler) ImpersonateTemplateDetail(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	templateID, ok := parseIDParam(c, "template_id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RecordImpersonation(auditContext(c), userID, "templates/"+c.Param("template_id")); err != nil {
+		writeAdminError(c, err)
+		return
+	}
+
+	result, err := h.templates.GetTemplateDetail(c.Request.Context(), userID, templateID, service.LocaleRequest{})
+	if err != nil {
+		if err == service.ErrProRequired {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Pro required"})
+			return
+		}
+		if err == service.ErrTemplateNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *AdminHandler) ListAuditLogs(c *gin.Context) {
+	filter, ok := parseAuditFilter(c)
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+
+	result, err := h.audit.List(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportAuditLogs streams matching entries as JSON Lines, one entry per line.
+func (h *AdminHandler) ExportAuditLogs(c *gin.Context) {
+	filter, ok := parseAuditFilter(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", `attachment; filename="audit_logs.jsonl"`)
+	c.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(c.Writer)
+	err := h.audit.Export(c.Request.Context(), filter, func(l *model.AuditLog) error {
+		return encoder.Encode(l)
+	})
+	if err != nil {
+		// Headers are already sent; the truncated body is the only signal left.
+		c.Error(err)
+	}
+}
+
+func parseAuditFilter(c *gin.Context) (service.AuditFilter, bool) {
+	filter := service.AuditFilter{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+	}
+	if v := c.Query("actor_id"); v != "" {
+		actorID, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid actor_id"})
+			return filter, false
+		}
+		filter.ActorID = int32(actorID)
+	}
+	for name, target := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if v := c.Query(name); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + ", expected RFC3339"})
+				return filter, false
+			}
+			*target = parsed.UTC()
+		}
+	}
+	return filter, true
+}
+
+func parseIDParam(c *gin.Context, name string) (int32, bool) {
+	value, err := strconv.Atoi(c.Param(name))
+	if err != nil || value <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name})
+		return 0, false
+	}
+	return int32(value), true
+}
+
+func writeAdminError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	case service.ErrInvalidRole, service.ErrOwnRole:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case service.ErrStaffTarget:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
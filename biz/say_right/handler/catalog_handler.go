@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// catalogImportMaxBytes caps uploaded catalog files.
+const catalogImportMaxBytes = 10 << 20
+
+// CatalogHandler serves bulk export and import of the template catalog.
+type CatalogHandler struct {
+	svc service.CatalogService
+}
+
+func NewCatalogHandler(svc service.CatalogService) *CatalogHandler {
+	return &CatalogHandler{
+		svc: svc,
+	}
+}
+
+func (h *CatalogHandler) Export(c *gin.Context) {
+	format := c.DefaultQuery("format", service.CatalogFormatJSON)
+
+	doc, err := h.svc.Export(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", service.CatalogContentType(format))
+	c.Header("Content-Disposition", `attachment; filename="catalog.`+format+`"`)
+	if err := service.EncodeCatalog(c.Writer, format, doc); err != nil {
+		if err == service.ErrUnsupportedFormat {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Error(err)
+	}
+}
+
+// Import reads the catalog from the raw request body. Row errors come back as
+// 400 with the full result so editors can fix every row in one pass.
+func (h *CatalogHandler) Import(c *gin.Context) {
+	format := c.DefaultQuery("format", service.CatalogFormatJSON)
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, catalogImportMaxBytes)
+	doc, err := service.DecodeCatalog(body, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.Import(auditContext(c), doc, dryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(result.Errors) > 0 {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,387 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentHandler serves the editor endpoints for the template catalog.
+type ContentHandler struct {
+	svc service.ContentService
+}
+
+func NewContentHandler(svc service.ContentService) *ContentHandler {
+	return &ContentHandler{
+		svc: svc,
+	}
+}
+
+type ReorderRequest struct {
+	Items []service.SortItem `json:"items" binding:"required,min=1"`
+}
+
+// PublishRequest carries the live template's updated_at for optimistic concurrency.
+type PublishRequest struct {
+	UpdatedAt time.Time `json:"updated_at" binding:"required"`
+}
+
+func (h *ContentHandler) ListCategories(c *gin.Context) {
+	categories, err := h.svc.ListCategories(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+func (h *ContentHandler) CreateCategory(c *gin.Context) {
+	var input service.CategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.svc.CreateCategory(auditContext(c), input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+func (h *ContentHandler) UpdateCategory(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.CategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.svc.UpdateCategory(auditContext(c), categoryID, input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+func (h *ContentHandler) ReorderCategories(c *gin.Context) {
+	var req ReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.ReorderCategories(auditContext(c), req.Items); err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+func (h *ContentHandler) ListCollections(c *gin.Context) {
+	collections, err := h.svc.ListCollections(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"collections": collections})
+}
+
+func (h *ContentHandler) CreateCollection(c *gin.Context) {
+	var input service.CollectionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collection, err := h.svc.CreateCollection(auditContext(c), input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, collection)
+}
+
+func (h *ContentHandler) UpdateCollection(c *gin.Context) {
+	collectionID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.CollectionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collection, err := h.svc.UpdateCollection(auditContext(c), collectionID, input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+func (h *ContentHandler) ListTemplates(c *gin.Context) {
+	categoryID, _ := strconv.Atoi(c.Query("category_id"))
+
+	templates, err := h.svc.ListTemplates(c.Request.Context(), int32(categoryID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+func (h *ContentHandler) GetTemplate(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.GetTemplate(c.Request.Context(), templateID)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ContentHandler) CreateTemplate(c *gin.Context) {
+	var input service.TemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.CreateTemplate(auditContext(c), input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *ContentHandler) UpdateTemplate(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.TemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.UpdateTemplate(auditContext(c), templateID, input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ContentHandler) SetTemplateFlags(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.TemplateFlagsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.svc.SetTemplateFlags(auditContext(c), templateID, input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (h *ContentHandler) ReorderTemplates(c *gin.Context) {
+	var req ReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.ReorderTemplates(auditContext(c), req.Items); err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+func (h *ContentHandler) ListRevisions(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	revisions, err := h.svc.ListRevisions(c.Request.Context(), templateID)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+func (h *ContentHandler) DiffRevisions(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	fromID, errFrom := strconv.Atoi(c.Query("from"))
+	toID, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil || fromID <= 0 || toID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to revision ids are required"})
+		return
+	}
+
+	changes, err := h.svc.DiffRevisions(c.Request.Context(), templateID, int32(fromID), int32(toID))
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"changes": changes})
+}
+
+func (h *ContentHandler) RestoreRevision(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	revisionID, ok := parseIDParam(c, "revision_id")
+	if !ok {
+		return
+	}
+
+	var req PublishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.RestoreRevision(auditContext(c), templateID, revisionID, req.UpdatedAt)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ContentHandler) SaveDraft(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.TemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	revision, err := h.svc.SaveDraft(auditContext(c), templateID, input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+func (h *ContentHandler) PreviewDraft(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.PreviewDraft(c.Request.Context(), templateID)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ContentHandler) PublishDraft(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req PublishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.PublishDraft(auditContext(c), templateID, req.UpdatedAt)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeContentError(c *gin.Context, err error) {
+	var validationErr service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "field": validationErr.Field})
+		return
+	}
+	switch err {
+	case service.ErrConflict:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case service.ErrTemplateNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+	case service.ErrCategoryNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+	case service.ErrRevisionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+	case service.ErrNoDraft:
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pending draft"})
+	case service.ErrCollectionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Collection not found"})
+	case service.ErrTagNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
+	case service.ErrTranslationNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Translation not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+
+	"api/biz/say_right/service"
+	"api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// auditContext attaches the logged-in user and client details to the request
+// context so services can write them into the audit log.
+func auditContext(c *gin.Context) context.Context {
+	actorID, _ := getSessionUserID(c)
+	return service.WithActor(c.Request.Context(), service.Actor{
+		ID:        actorID,
+		Type:      service.ActorTypeUser,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		RequestID: c.GetString(middleware.RequestIDKey),
+	})
+}
+
+// webhookContext is auditContext for calls made by a third party rather than a user.
+func webhookContext(c *gin.Context) context.Context {
+	return service.WithActor(c.Request.Context(), service.Actor{
+		Type:      service.ActorTypeWebhook,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		RequestID: c.GetString(middleware.RequestIDKey),
+	})
+}
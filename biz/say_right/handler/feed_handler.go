@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedHandler serves the unauthenticated sitemap and template feed for crawlers
+// and marketing pages.
+type FeedHandler struct {
+	svc service.FeedService
+}
+
+func NewFeedHandler(svc service.FeedService) *FeedHandler {
+	return &FeedHandler{
+		svc: svc,
+	}
+}
+
+func (h *FeedHandler) Sitemap(c *gin.Context) {
+	b, err := h.svc.Sitemap(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", b)
+}
+
+func (h *FeedHandler) Feed(c *gin.Context) {
+	b, err := h.svc.Feed(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, "application/json; charset=utf-8", b)
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultCostReportDays is the window of the cost report when since is omitted.
+const defaultCostReportDays = 30
+
+// sseHeartbeat is how often an idle stream sends a comment so proxies and
+// load balancers do not close it while the model is thinking.
+const sseHeartbeat = 15 * time.Second
+
+type GenerationHandler struct {
+	svc service.GenerationService
+}
+
+func NewGenerationHandler(svc service.GenerationService) *GenerationHandler {
+	return &GenerationHandler{
+		svc: svc,
+	}
+}
+
+// Generate handles POST /templates/:id/generate
+func (h *GenerationHandler) Generate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.GenerateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.Generate(c.Request.Context(), userID, templateID, input, localeRequest(c))
+	if err != nil {
+		writeGenerationError(c, err)
+		return
+	}
+
+	c.Header("Content-Language", result.Locale)
+	c.JSON(http.StatusOK, result)
+}
+
+// StreamGenerate handles POST /templates/:id/generate/stream. Errors found
+// before generation starts are plain JSON responses; after that the stream
+// ends with an error event.
+func (h *GenerationHandler) StreamGenerate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.GenerateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	stream, err := h.svc.StreamGenerate(ctx, userID, templateID, input, localeRequest(c))
+	if err != nil {
+		writeGenerationError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", sse.ContentType)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stop nginx-style proxies from buffering the whole response
+	c.Header("X-Accel-Buffering", "no")
+	c.Header("Content-Language", stream.Locale())
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	// Run emits from its own goroutine; the unbuffered channel hands each
+	// event over so only this goroutine writes to the response.
+	events := make(chan service.GenerationEvent)
+	finished := make(chan error, 1)
+	go func() {
+		finished <- stream.Run(ctx, func(event service.GenerationEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+	id := 0
+	write := func(name string, data any) {
+		id++
+		c.Render(-1, sse.Event{Id: strconv.Itoa(id), Event: name, Data: data})
+		c.Writer.Flush()
+	}
+	for {
+		select {
+		case event := <-events:
+			write(event.Name, event.Data)
+		case <-heartbeat.C:
+			c.Writer.WriteString(": ping\n\n")
+			c.Writer.Flush()
+		case err := <-finished:
+			if err != nil && ctx.Err() == nil {
+				status, message := generationErrorStatus(err)
+				write(service.EventError, gin.H{"error": message, "status": status})
+			}
+			return
+		}
+	}
+}
+
+// GetQuota handles GET /generation/quota
+func (h *GenerationHandler) GetQuota(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	quota, err := h.svc.GetQuota(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quota)
+}
+
+// CostReport handles GET /admin/generation/costs?since=&until= (RFC3339).
+func (h *GenerationHandler) CostReport(c *gin.Context) {
+	until := time.Now().UTC()
+	since := until.AddDate(0, 0, -defaultCostReportDays)
+	for name, target := range map[string]*time.Time{"since": &since, "until": &until} {
+		if v := c.Query(name); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + ", expected RFC3339"})
+				return
+			}
+			*target = parsed.UTC()
+		}
+	}
+	if !until.After(since) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "until must be after since"})
+		return
+	}
+
+	report, err := h.svc.CostReport(c.Request.Context(), since, until)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func writeGenerationError(c *gin.Context, err error) {
+	var validationErr service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "field": validationErr.Field})
+		return
+	}
+	var quotaErr service.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		c.Header("Retry-After", strconv.Itoa(quotaErr.RetryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "limit": quotaErr.Limit, "retry_after": quotaErr.RetryAfter})
+		return
+	}
+	status, message := generationErrorStatus(err)
+	c.JSON(status, gin.H{"error": message})
+}
+
+func generationErrorStatus(err error) (int, string) {
+	switch err {
+	case service.ErrProRequired:
+		return http.StatusForbidden, "Pro required"
+	case service.ErrTemplateNotFound:
+		return http.StatusNotFound, "Template not found"
+	case service.ErrGenerationDisabled:
+		return http.StatusServiceUnavailable, err.Error()
+	case service.ErrGenerationTimeout:
+		return http.StatusGatewayTimeout, err.Error()
+	case service.ErrGenerationFailed:
+		return http.StatusBadGateway, err.Error()
+	}
+	return http.StatusInternalServerError, err.Error()
+}
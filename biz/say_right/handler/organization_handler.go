@@ -0,0 +1,423 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	svc service.OrganizationService
+}
+
+func NewOrganizationHandler(svc service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		svc: svc,
+	}
+}
+
+// ListOrganizations handles GET /orgs
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	result, err := h.svc.ListOrganizations(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"organizations": result})
+}
+
+// CreateOrganization handles POST /orgs
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input service.OrganizationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.CreateOrganization(c.Request.Context(), userID, input)
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetOrganization handles GET /orgs/:id
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.GetOrganization(c.Request.Context(), userID, orgID)
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateOrganization handles PUT /orgs/:id
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.OrganizationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.UpdateOrganization(c.Request.Context(), userID, orgID, input)
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// InviteMember handles POST /orgs/:id/invitations
+func (h *OrganizationHandler) InviteMember(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.InvitationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.InviteMember(c.Request.Context(), userID, orgID, input)
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// RevokeInvitation handles DELETE /orgs/:id/invitations/:invitation_id
+func (h *OrganizationHandler) RevokeInvitation(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	invitationID, ok := parseIDParam(c, "invitation_id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RevokeInvitation(c.Request.Context(), userID, orgID, invitationID); err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// StartCheckout handles POST /orgs/:id/checkout
+func (h *OrganizationHandler) StartCheckout(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.StartCheckout(c.Request.Context(), userID, orgID)
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AcceptInvitation handles POST /invitations/:token/accept
+func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	result, err := h.svc.AcceptInvitation(c.Request.Context(), userID, c.Param("token"))
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SetMemberRole handles PUT /orgs/:id/members/:user_id/role
+func (h *OrganizationHandler) SetMemberRole(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	memberID, ok := parseIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+
+	var input service.RoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.SetMemberRole(c.Request.Context(), userID, orgID, memberID, input); err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveMember handles DELETE /orgs/:id/members/:user_id
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	memberID, ok := parseIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RemoveMember(c.Request.Context(), userID, orgID, memberID); err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListTeamTemplates handles GET /orgs/:id/templates
+func (h *OrganizationHandler) ListTeamTemplates(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.ListTeamTemplates(c.Request.Context(), userID, orgID)
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetTeamTemplate handles GET /orgs/:id/templates/:template_id
+func (h *OrganizationHandler) GetTeamTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	templateID, ok := parseIDParam(c, "template_id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.GetTeamTemplate(c.Request.Context(), userID, orgID, templateID)
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CreateTeamTemplate handles POST /orgs/:id/templates
+func (h *OrganizationHandler) CreateTeamTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.UserTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.CreateTeamTemplate(c.Request.Context(), userID, orgID, input)
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// UpdateTeamTemplate handles PUT /orgs/:id/templates/:template_id
+func (h *OrganizationHandler) UpdateTeamTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	templateID, ok := parseIDParam(c, "template_id")
+	if !ok {
+		return
+	}
+
+	var input service.UserTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.UpdateTeamTemplate(c.Request.Context(), userID, orgID, templateID, input)
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteTeamTemplate handles DELETE /orgs/:id/templates/:template_id
+func (h *OrganizationHandler) DeleteTeamTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	templateID, ok := parseIDParam(c, "template_id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteTeamTemplate(c.Request.Context(), userID, orgID, templateID); err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func writeOrganizationError(c *gin.Context, err error) {
+	var validationErr service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "field": validationErr.Field})
+		return
+	}
+	var seatErr service.OrgSeatLimitError
+	if errors.As(err, &seatErr) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "seats": seatErr.Seats})
+		return
+	}
+	var limitErr service.UserTemplateLimitError
+	if errors.As(err, &limitErr) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "limit": limitErr.Limit})
+		return
+	}
+	switch err {
+	case service.ErrOrgRoleForbidden, service.ErrInvitationEmail, service.ErrOrgSubscriptionOnly:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case service.ErrOrganizationNotFound, service.ErrOrgMemberNotFound, service.ErrInvitationNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.ErrTemplateNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+	case service.ErrAlreadyOrgMember, service.ErrOrgLastOwner, service.ErrOrgAlreadySubscribed:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case service.ErrInvitationExpired:
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -6,22 +6,32 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"io"
+	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"api/biz/say_right/dal/model"
 	"api/biz/say_right/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 type PaddleHandler struct {
-	svc service.UserService
+	svc       service.UserService
+	billing   service.BillingService
+	orgs      service.OrganizationService
+	referrals service.ReferralService
 }
 
-func NewPaddleHandler(svc service.UserService) *PaddleHandler {
+func NewPaddleHandler(svc service.UserService, billing service.BillingService, orgs service.OrganizationService, referrals service.ReferralService) *PaddleHandler {
 	return &PaddleHandler{
-		svc: svc,
+		svc:       svc,
+		billing:   billing,
+		orgs:      orgs,
+		referrals: referrals,
 	}
 }
 
@@ -66,14 +76,19 @@ func (h *PaddleHandler) HandleWebhook(c *gin.Context) {
 		return
 	}
 
-	// 3. Handle Event
+	// 3. Keep a copy of the event for billing history
+	if err := h.billing.RecordEvent(webhookContext(c), buildBillingEvent(event, body)); err != nil {
+		log.Printf("Failed to record paddle event %s: %v", event.EventID, err)
+	}
+
+	// 4. Handle Event
 	switch event.EventType {
 	case "transaction.completed":
 		h.handleTransactionCompleted(c, event.Data)
 	case "subscription.created":
-		h.handleSubscriptionCreated(c, event.Data)
-	case "subscription.updated":
-		h.handleSubscriptionUpdated(c, event.Data)
+		h.handleSubscriptionCreated(c, event)
+	case "subscription.updated", "subscription.canceled":
+		h.handleSubscriptionUpdated(c, event)
 	default:
 		// Ignore other events
 	}
@@ -82,13 +97,24 @@ func (h *PaddleHandler) HandleWebhook(c *gin.Context) {
 }
 
 func (h *PaddleHandler) handleTransactionCompleted(c *gin.Context, data map[string]interface{}) {
+	// Team purchases grant Pro through the subscription events instead
+	if _, ok := organizationIDOf(data); ok {
+		return
+	}
+
 	// Try to get email from custom_data
 	if customData, ok := data["custom_data"].(map[string]interface{}); ok {
 		if email, ok := customData["email"].(string); ok && email != "" {
-			if err := h.svc.UpgradeUserToPro(c.Request.Context(), email); err != nil {
+			if err := h.svc.UpgradeUserToPro(webhookContext(c), email); err != nil {
 				// Log error (in a real app, use a logger)
 				// fmt.Printf("Failed to upgrade user %s: %v\n", email, err)
 			}
+			if transactionPaid(data) {
+				transactionID, _ := data["id"].(string)
+				if err := h.referrals.RewardReferral(webhookContext(c), email, transactionID); err != nil {
+					log.Printf("Failed to reward referral for transaction %s: %v", transactionID, err)
+				}
+			}
 			return
 		}
 	}
@@ -96,14 +122,145 @@ func (h *PaddleHandler) handleTransactionCompleted(c *gin.Context, data map[stri
 	// TODO: Handle case where email is not in custom_data (e.g. check customer_id and lookup)
 }
 
-func (h *PaddleHandler) handleSubscriptionCreated(c *gin.Context, data map[string]interface{}) {
+func (h *PaddleHandler) handleSubscriptionCreated(c *gin.Context, event PaddleEvent) {
+	if sub, ok := organizationSubscriptionOf(event); ok {
+		if err := h.orgs.BindSubscription(webhookContext(c), sub); err != nil {
+			log.Printf("Failed to bind subscription %s to organization %d: %v", sub.SubscriptionID, sub.OrganizationID, err)
+		}
+		return
+	}
 	// TODO: Update user subscription status
 }
 
-func (h *PaddleHandler) handleSubscriptionUpdated(c *gin.Context, data map[string]interface{}) {
+func (h *PaddleHandler) handleSubscriptionUpdated(c *gin.Context, event PaddleEvent) {
+	if sub, ok := organizationSubscriptionOf(event); ok {
+		if err := h.orgs.ApplySubscription(webhookContext(c), sub); err != nil {
+			log.Printf("Failed to apply subscription %s: %v", sub.SubscriptionID, err)
+		}
+		return
+	}
 	// TODO: Handle updates (renewals, cancellations)
 }
 
+// organizationSubscriptionOf reads the seats and status of a team
+// subscription, which carries the organization id in custom_data. It reports
+// false for personal subscriptions. The organization id and checkout token
+// are client supplied, so the service only trusts them to bind a checkout it
+// issued.
+func organizationSubscriptionOf(event PaddleEvent) (service.OrganizationSubscription, bool) {
+	data := event.Data
+	orgID, ok := organizationIDOf(data)
+	if !ok {
+		return service.OrganizationSubscription{}, false
+	}
+
+	sub := service.OrganizationSubscription{OrganizationID: orgID}
+	if customData, ok := data["custom_data"].(map[string]interface{}); ok {
+		sub.CheckoutToken, _ = customData["checkout_token"].(string)
+	}
+	sub.SubscriptionID, _ = data["id"].(string)
+	sub.Status, _ = data["status"].(string)
+	if items, ok := data["items"].([]interface{}); ok {
+		for _, item := range items {
+			if item, ok := item.(map[string]interface{}); ok {
+				if quantity, ok := item["quantity"].(float64); ok {
+					sub.Seats += int(quantity)
+				}
+			}
+		}
+	}
+	if period, ok := data["current_billing_period"].(map[string]interface{}); ok {
+		if endsAt, ok := period["ends_at"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, endsAt); err == nil {
+				t = t.UTC()
+				sub.CurrentPeriodEnd = &t
+			}
+		}
+	}
+	if occurredAt, err := time.Parse(time.RFC3339, event.OccurredAt); err == nil {
+		sub.OccurredAt = occurredAt.UTC()
+	}
+	return sub, true
+}
+
+func transactionPaid(data map[string]interface{}) bool {
+	details, ok := data["details"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	totals, ok := details["totals"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	total, _ := totals["grand_total"].(string)
+	amount, err := strconv.ParseInt(total, 10, 64)
+	return err == nil && amount > 0
+}
+
+// organizationIDOf reads custom_data.organization_id, which checkout may send
+// as a string or a number.
+func organizationIDOf(data map[string]interface{}) (int32, bool) {
+	customData, ok := data["custom_data"].(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	switch v := customData["organization_id"].(type) {
+	case float64:
+		if v > 0 {
+			return int32(v), true
+		}
+	case string:
+		if id, err := strconv.ParseInt(v, 10, 32); err == nil && id > 0 {
+			return int32(id), true
+		}
+	}
+	return 0, false
+}
+
+// buildBillingEvent extracts the fields shown in the admin billing history from a webhook event.
+func buildBillingEvent(event PaddleEvent, body []byte) *model.BillingEvent {
+	record := &model.BillingEvent{
+		EventID:   event.EventID,
+		EventType: event.EventType,
+		Payload:   string(body),
+	}
+	if occurredAt, err := time.Parse(time.RFC3339, event.OccurredAt); err == nil {
+		record.OccurredAt = occurredAt.UTC()
+	}
+
+	data := event.Data
+	if customData, ok := data["custom_data"].(map[string]interface{}); ok {
+		if email, ok := customData["email"].(string); ok {
+			record.Email = strings.ToLower(strings.TrimSpace(email))
+		}
+	}
+	if id, ok := data["id"].(string); ok {
+		if strings.HasPrefix(event.EventType, "subscription.") {
+			record.SubscriptionID = id
+		} else {
+			record.TransactionID = id
+		}
+	}
+	if subscriptionID, ok := data["subscription_id"].(string); ok {
+		record.SubscriptionID = subscriptionID
+	}
+	if status, ok := data["status"].(string); ok {
+		record.Status = status
+	}
+	if currency, ok := data["currency_code"].(string); ok {
+		record.Currency = currency
+	}
+	if details, ok := data["details"].(map[string]interface{}); ok {
+		if totals, ok := details["totals"].(map[string]interface{}); ok {
+			if total, ok := totals["grand_total"].(string); ok {
+				record.Amount = total
+			}
+		}
+	}
+
+	return record
+}
+
 // Verify signature helper (placeholder implementation)
 // See https://developer.paddle.com/webhook-reference/verifying-webhooks
 func verifyPaddleSignature(signatureHeader string, body []byte, secret string) bool {
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RatingHandler struct {
+	svc service.RatingService
+}
+
+func NewRatingHandler(svc service.RatingService) *RatingHandler {
+	return &RatingHandler{
+		svc: svc,
+	}
+}
+
+// RateTemplate handles POST /templates/:id/ratings
+func (h *RatingHandler) RateTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.RatingInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.RateTemplate(c.Request.Context(), userID, templateID, input)
+	if err != nil {
+		writeRatingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListMyRatings handles GET /templates/:id/ratings
+func (h *RatingHandler) ListMyRatings(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ratings, err := h.svc.ListMyRatings(c.Request.Context(), userID, templateID)
+	if err != nil {
+		writeRatingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ratings": ratings})
+}
+
+// ListScores handles GET /admin/ratings/scores. Worst scores come first
+// unless order=best.
+func (h *RatingHandler) ListScores(c *gin.Context) {
+	filter := service.ScoreFilter{Best: c.Query("order") == "best"}
+	if v := c.Query("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since, expected RFC3339"})
+			return
+		}
+		filter.Since = since.UTC()
+	}
+	if v := c.Query("min_votes"); v != "" {
+		minVotes, err := strconv.Atoi(v)
+		if err != nil || minVotes < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_votes"})
+			return
+		}
+		filter.MinVotes = minVotes
+	}
+
+	scores, err := h.svc.ListScores(c.Request.Context(), filter)
+	if err != nil {
+		writeRatingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": scores})
+}
+
+// GetTemplateRatings handles GET /admin/templates/:id/ratings
+func (h *RatingHandler) GetTemplateRatings(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.GetTemplateRatings(c.Request.Context(), templateID)
+	if err != nil {
+		writeRatingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListComments handles GET /admin/rating-comments, the moderation queue by
+// default.
+func (h *RatingHandler) ListComments(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+
+	result, err := h.svc.ListComments(c.Request.Context(), c.Query("status"), page, pageSize)
+	if err != nil {
+		writeRatingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ModerateComment handles POST /admin/rating-comments/:id/moderate
+func (h *RatingHandler) ModerateComment(c *gin.Context) {
+	ratingID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Status string `json:"status"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.ModerateComment(auditContext(c), ratingID, input.Status)
+	if err != nil {
+		writeRatingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeRatingError(c *gin.Context, err error) {
+	var validationErr service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "field": validationErr.Field})
+		return
+	}
+	switch err {
+	case service.ErrProRequired:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Pro required"})
+	case service.ErrTemplateNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+	case service.ErrRatingNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Rating not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReferralHandler struct {
+	svc service.ReferralService
+}
+
+func NewReferralHandler(svc service.ReferralService) *ReferralHandler {
+	return &ReferralHandler{
+		svc: svc,
+	}
+}
+
+// GetDashboard handles GET /my/referrals
+func (h *ReferralHandler) GetDashboard(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	result, err := h.svc.GetDashboard(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RewriteHandler struct {
+	svc service.RewriteService
+}
+
+func NewRewriteHandler(svc service.RewriteService) *RewriteHandler {
+	return &RewriteHandler{
+		svc: svc,
+	}
+}
+
+// Rewrite handles POST /rewrite
+func (h *RewriteHandler) Rewrite(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input service.RewriteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.Rewrite(c.Request.Context(), userID, input)
+	if err != nil {
+		writeGenerationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetQuota handles GET /rewrite/quota
+func (h *RewriteHandler) GetQuota(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	quota, err := h.svc.GetQuota(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quota)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	svc service.SearchService
+}
+
+func NewSearchHandler(svc service.SearchService) *SearchHandler {
+	return &SearchHandler{
+		svc: svc,
+	}
+}
+
+// SearchTemplates handles GET /templates/search?q=&tag=&category_id=&pro=&limit=
+func (h *SearchHandler) SearchTemplates(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	params := service.SearchParams{
+		Query:  c.Query("q"),
+		Tag:    c.Query("tag"),
+		Locale: localeRequest(c),
+	}
+	if v := c.Query("category_id"); v != "" {
+		categoryID, err := strconv.Atoi(v)
+		if err != nil || categoryID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category_id"})
+			return
+		}
+		params.CategoryID = int32(categoryID)
+	}
+	if v := c.Query("pro"); v != "" {
+		isPro, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pro"})
+			return
+		}
+		params.IsPro = &isPro
+	}
+	params.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	result, err := h.svc.Search(c.Request.Context(), userID, params)
+	if err != nil {
+		if err == service.ErrEmptySearchQuery {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
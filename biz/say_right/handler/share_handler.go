@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShareHandler struct {
+	svc service.ShareService
+}
+
+func NewShareHandler(svc service.ShareService) *ShareHandler {
+	return &ShareHandler{
+		svc: svc,
+	}
+}
+
+// CreateShare handles POST /templates/:id/share
+func (h *ShareHandler) CreateShare(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.ShareInput
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	result, err := h.svc.CreateShare(c.Request.Context(), userID, templateID, input, localeRequest(c))
+	if err != nil {
+		writeShareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// ListShares handles GET /my/shares
+func (h *ShareHandler) ListShares(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	shares, err := h.svc.ListShares(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"shares": shares})
+}
+
+// RevokeShare handles DELETE /my/shares/:id
+func (h *ShareHandler) RevokeShare(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	shareID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RevokeShare(c.Request.Context(), userID, shareID); err != nil {
+		writeShareError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ViewShare handles GET /shares/:token. It is public; signed-in Pro
+// viewers see Pro replies in full.
+func (h *ShareHandler) ViewShare(c *gin.Context) {
+	viewerID, _ := getSessionUserID(c)
+
+	result, err := h.svc.ViewShare(c.Request.Context(), c.Param("token"), viewerID)
+	if err != nil {
+		writeShareError(c, err)
+		return
+	}
+
+	// The body depends on the viewer and links can be revoked at any time
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Content-Language", result.Locale)
+	c.JSON(http.StatusOK, result)
+}
+
+func writeShareError(c *gin.Context, err error) {
+	var validationErr service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "field": validationErr.Field})
+		return
+	}
+	switch err {
+	case service.ErrProRequired:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Pro required"})
+	case service.ErrTemplateNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+	case service.ErrShareNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Share link not found"})
+	case service.ErrShareGone:
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SuggestHandler struct {
+	svc service.SuggestService
+}
+
+func NewSuggestHandler(svc service.SuggestService) *SuggestHandler {
+	return &SuggestHandler{
+		svc: svc,
+	}
+}
+
+// SuggestTemplates handles POST /templates/suggest
+func (h *SuggestHandler) SuggestTemplates(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input service.SuggestInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.Suggest(c.Request.Context(), userID, input, localeRequest(c))
+	if err != nil {
+		var validationErr service.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "field": validationErr.Field})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Language", result.Locale)
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	svc service.TagService
+}
+
+func NewTagHandler(svc service.TagService) *TagHandler {
+	return &TagHandler{
+		svc: svc,
+	}
+}
+
+// ListTags handles GET /tags?locale=zh-CN
+func (h *TagHandler) ListTags(c *gin.Context) {
+	tags, err := h.svc.ListTags(c.Request.Context(), c.Query("locale"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+func (h *TagHandler) ListAllTags(c *gin.Context) {
+	tags, err := h.svc.ListAllTags(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	tagID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.TagInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.svc.UpdateTag(auditContext(c), tagID, input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
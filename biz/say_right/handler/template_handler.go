@@ -1,22 +1,28 @@
 package handler
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"api/biz/say_right/service"
+	"api/middleware"
 
-	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 )
 
 type TemplateHandler struct {
-	svc service.TemplateService
+	svc      service.TemplateService
+	activity service.ActivityService
 }
 
-func NewTemplateHandler(svc service.TemplateService) *TemplateHandler {
+func NewTemplateHandler(svc service.TemplateService, activity service.ActivityService) *TemplateHandler {
 	return &TemplateHandler{
-		svc: svc,
+		svc:      svc,
+		activity: activity,
 	}
 }
 
@@ -27,7 +33,7 @@ func (h *TemplateHandler) ListTemplates(c *gin.Context) {
 		return
 	}
 
-	categories, err := h.svc.ListTemplatesByCategory(c.Request.Context(), userID)
+	categories, err := h.svc.ListTemplatesByCategory(c.Request.Context(), userID, queryList(c, "tag"), localeRequest(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -36,6 +42,24 @@ func (h *TemplateHandler) ListTemplates(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"categories": categories})
 }
 
+func (h *TemplateHandler) GetCatalogTree(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	tree, err := h.svc.GetCatalogTree(c.Request.Context(), userID, localeRequest(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Language", tree.Locale)
+
+	c.JSON(http.StatusOK, tree)
+}
+
 func (h *TemplateHandler) GetTemplateDetail(c *gin.Context) {
 	userID, ok := getSessionUserID(c)
 	if !ok {
@@ -50,7 +74,7 @@ func (h *TemplateHandler) GetTemplateDetail(c *gin.Context) {
 		return
 	}
 
-	result, err := h.svc.GetTemplateDetail(c.Request.Context(), userID, int32(idValue))
+	result, err := h.svc.GetTemplateDetail(c.Request.Context(), userID, int32(idValue), localeRequest(c))
 	if err != nil {
 		if err == service.ErrProRequired {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Pro required"})
@@ -64,31 +88,149 @@ func (h *TemplateHandler) GetTemplateDetail(c *gin.Context) {
 		return
 	}
 
+	h.recordView(c, userID, result.ID)
+	c.Header("Content-Language", result.Locale)
 	c.JSON(http.StatusOK, result)
 }
 
-func getSessionUserID(c *gin.Context) (int32, bool) {
-	session := sessions.Default(c)
-	value := session.Get("user_id")
-	if value == nil {
-		return 0, false
-	}
-	switch v := value.(type) {
-	case int32:
-		return v, v > 0
-	case int:
-		return int32(v), v > 0
-	case int64:
-		return int32(v), v > 0
-	case float64:
-		return int32(v), v > 0
-	case string:
-		parsed, err := strconv.Atoi(v)
-		if err != nil {
-			return 0, false
+func (h *TemplateHandler) GetTemplateBySlug(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	result, err := h.svc.GetTemplateDetailBySlug(c.Request.Context(), userID, c.Param("slug"), localeRequest(c))
+	if err != nil {
+		var moved *service.SlugMovedError
+		if errors.As(err, &moved) {
+			redirectToSlug(c, moved.Slug)
+			return
+		}
+		if err == service.ErrProRequired {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Pro required"})
+			return
+		}
+		if err == service.ErrTemplateNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.recordView(c, userID, result.ID)
+	c.Header("Content-Language", result.Locale)
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *TemplateHandler) RenderTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.RenderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.RenderTemplate(c.Request.Context(), userID, templateID, input, localeRequest(c))
+	if err != nil {
+		var validationErr service.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "field": validationErr.Field})
+			return
+		}
+		if err == service.ErrProRequired {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Pro required"})
+			return
+		}
+		if err == service.ErrTemplateNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Language", result.Locale)
+	c.JSON(http.StatusOK, result)
+}
+
+// GetTemplatePreview is public: it serves the teaser used by marketing pages,
+// including Pro templates, without requiring a session.
+func (h *TemplateHandler) GetTemplatePreview(c *gin.Context) {
+	result, err := h.svc.GetTemplatePreview(c.Request.Context(), c.Param("slug"), localeRequest(c))
+	if err != nil {
+		var moved *service.SlugMovedError
+		if errors.As(err, &moved) {
+			redirectToSlug(c, moved.Slug)
+			return
+		}
+		if err == service.ErrTemplateNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Header("Vary", "Accept-Language")
+	c.Header("Content-Language", result.Locale)
+	c.JSON(http.StatusOK, result)
+}
+
+// recordView adds the template to the user's recently viewed list. Failing
+// to record it must not fail the page.
+func (h *TemplateHandler) recordView(c *gin.Context, userID, templateID int32) {
+	if err := h.activity.RecordView(c.Request.Context(), userID, templateID); err != nil {
+		log.Printf("Failed to record view of template %d by user %d: %v", templateID, userID, err)
+	}
+}
+
+// redirectToSlug answers with a permanent redirect to the same route under the new slug.
+func redirectToSlug(c *gin.Context, slug string) {
+	base := strings.TrimSuffix(c.Request.URL.Path, c.Param("slug"))
+	location := base + url.PathEscape(slug)
+	// Keep ?locale= and the rest of the query on the new URL
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+	c.Redirect(http.StatusMovedPermanently, location)
+}
+
+// queryList collects a repeatable, comma-separated query parameter:
+// ?tag=a,b and ?tag=a&tag=b both give [a b].
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, v := range c.QueryArray(key) {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
 		}
-		return int32(parsed), parsed > 0
-	default:
-		return 0, false
 	}
+	return values
+}
+
+// localeRequest reads the content language the client asked for, either as
+// ?locale= or through Accept-Language.
+func localeRequest(c *gin.Context) service.LocaleRequest {
+	return service.LocaleRequest{
+		Locale:         c.Query("locale"),
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+	}
+}
+
+func getSessionUserID(c *gin.Context) (int32, bool) {
+	return middleware.SessionUserID(c)
 }
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TranslationHandler serves the editor endpoints for per-locale catalog text.
+type TranslationHandler struct {
+	svc service.TranslationService
+}
+
+func NewTranslationHandler(svc service.TranslationService) *TranslationHandler {
+	return &TranslationHandler{
+		svc: svc,
+	}
+}
+
+func (h *TranslationHandler) ListCategoryTranslations(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	translations, err := h.svc.ListCategoryTranslations(c.Request.Context(), categoryID)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"translations": translations})
+}
+
+func (h *TranslationHandler) SaveCategoryTranslation(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.CategoryTranslationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	translation, err := h.svc.SaveCategoryTranslation(auditContext(c), categoryID, c.Param("locale"), input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+func (h *TranslationHandler) DeleteCategoryTranslation(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteCategoryTranslation(auditContext(c), categoryID, c.Param("locale")); err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TranslationHandler) ListTemplateTranslations(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	translations, err := h.svc.ListTemplateTranslations(c.Request.Context(), templateID)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"translations": translations})
+}
+
+func (h *TranslationHandler) SaveTemplateTranslation(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.TemplateTranslationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	translation, err := h.svc.SaveTemplateTranslation(auditContext(c), templateID, c.Param("locale"), input)
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+func (h *TranslationHandler) DeleteTemplateTranslation(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteTemplateTranslation(auditContext(c), templateID, c.Param("locale")); err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MissingTranslations handles GET /translations/missing?locale=zh-CN
+func (h *TranslationHandler) MissingTranslations(c *gin.Context) {
+	report, err := h.svc.MissingTranslations(c.Request.Context(), c.Query("locale"))
+	if err != nil {
+		writeContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ListLocales handles GET /translations/locales.
+func (h *TranslationHandler) ListLocales(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"source": service.SourceLocale, "locales": service.SupportedLocales})
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"log"
 	"net/http"
 	"strings"
 
@@ -36,7 +37,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 	// Note: In a real application, you should handle password hashing, validation, etc.
 	// This is just a generated example.
 
-	if err := h.svc.CreateUser(c.Request.Context(), &user); err != nil {
+	if err := h.svc.CreateUser(auditContext(c), &user); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -87,7 +88,7 @@ func (h *UserHandler) SendVerificationCode(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.SendVerificationCode(c.Request.Context(), emailNorm); err != nil {
+	if err := h.svc.SendVerificationCode(auditContext(c), emailNorm); err != nil {
 		var rateErr service.RateLimitError
 		if errors.As(err, &rateErr) {
 			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "retry_after": rateErr.RetryAfter})
@@ -103,6 +104,8 @@ func (h *UserHandler) SendVerificationCode(c *gin.Context) {
 type LoginRequest struct {
 	Email string `json:"email" binding:"required,email"`
 	Code  string `json:"code" binding:"required"`
+	// ReferralCode credits whoever referred a user signing in for the first time.
+	ReferralCode string `json:"referral_code"`
 }
 
 func (h *UserHandler) Login(c *gin.Context) {
@@ -114,21 +117,29 @@ func (h *UserHandler) Login(c *gin.Context) {
 
 	emailNorm := strings.ToLower(strings.TrimSpace(req.Email))
 
-	valid, err := h.svc.VerifyCode(c.Request.Context(), emailNorm, req.Code)
+	ctx := auditContext(c)
+	valid, err := h.svc.VerifyCode(ctx, emailNorm, req.Code)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	if !valid {
+		if err := h.svc.RecordLogin(ctx, emailNorm, nil); err != nil {
+			log.Printf("Failed to record login attempt for %s: %v", emailNorm, err)
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid verification code"})
 		return
 	}
 
-	user, err := h.svc.FindOrCreateUser(c.Request.Context(), emailNorm)
+	user, err := h.svc.FindOrCreateUser(ctx, emailNorm, req.ReferralCode)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if user.Status == service.UserStatusSuspended {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Account suspended"})
+		return
+	}
 
 	// Save session
 	session := sessions.Default(c)
@@ -139,5 +150,41 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
+	if err := h.svc.RecordLogin(ctx, emailNorm, user); err != nil {
+		log.Printf("Failed to record login for user %d: %v", user.ID, err)
+	}
+
 	c.JSON(http.StatusOK, user)
 }
+
+type SetLocaleRequest struct {
+	Locale string `json:"locale"`
+}
+
+// SetLocale handles PUT /users/locale for the signed-in user.
+func (h *UserHandler) SetLocale(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SetLocaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	locale, err := h.svc.SetLocale(c.Request.Context(), userID, req.Locale)
+	if err != nil {
+		var validationErr service.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "supported": service.SupportedLocales})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"locale": locale})
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserTemplateHandler struct {
+	svc service.UserTemplateService
+}
+
+func NewUserTemplateHandler(svc service.UserTemplateService) *UserTemplateHandler {
+	return &UserTemplateHandler{
+		svc: svc,
+	}
+}
+
+// ListUserTemplates handles GET /my/templates
+func (h *UserTemplateHandler) ListUserTemplates(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	result, err := h.svc.ListUserTemplates(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetUserTemplate handles GET /my/templates/:id
+func (h *UserTemplateHandler) GetUserTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.GetUserTemplate(c.Request.Context(), userID, templateID)
+	if err != nil {
+		writeUserTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CreateUserTemplate handles POST /my/templates
+func (h *UserTemplateHandler) CreateUserTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input service.UserTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.CreateUserTemplate(c.Request.Context(), userID, input)
+	if err != nil {
+		writeUserTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// UpdateUserTemplate handles PUT /my/templates/:id
+func (h *UserTemplateHandler) UpdateUserTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.UserTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.svc.UpdateUserTemplate(c.Request.Context(), userID, templateID, input)
+	if err != nil {
+		writeUserTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteUserTemplate handles DELETE /my/templates/:id
+func (h *UserTemplateHandler) DeleteUserTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteUserTemplate(c.Request.Context(), userID, templateID); err != nil {
+		writeUserTemplateError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ForkTemplate handles POST /templates/:id/fork
+func (h *UserTemplateHandler) ForkTemplate(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.ForkTemplate(c.Request.Context(), userID, templateID, localeRequest(c))
+	if err != nil {
+		writeUserTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// UpstreamDiff handles GET /my/templates/:id/upstream
+func (h *UserTemplateHandler) UpstreamDiff(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	diff, err := h.svc.UpstreamDiff(c.Request.Context(), userID, templateID)
+	if err != nil {
+		writeUserTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// PullUpstream handles POST /my/templates/:id/pull. An empty body takes
+// every upstream change that does not conflict with the user's edits.
+func (h *UserTemplateHandler) PullUpstream(c *gin.Context) {
+	userID, ok := getSessionUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templateID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var input service.PullInput
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	result, err := h.svc.PullUpstream(c.Request.Context(), userID, templateID, input)
+	if err != nil {
+		writeUserTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeUserTemplateError(c *gin.Context, err error) {
+	var validationErr service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "field": validationErr.Field})
+		return
+	}
+	var limitErr service.UserTemplateLimitError
+	if errors.As(err, &limitErr) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "limit": limitErr.Limit})
+		return
+	}
+	switch err {
+	case service.ErrProRequired:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Pro required"})
+	case service.ErrTemplateNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+	case service.ErrNotForked:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case service.ErrUpstreamGone:
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecentTemplatesLimit caps the recently viewed list.
+const RecentTemplatesLimit = 20
+
+type CopyInput struct {
+	// Tone is the reply the user copied: soft, neutral or firm.
+	Tone string `json:"tone"`
+}
+
+type TemplateList struct {
+	Locale    string         `json:"locale"`
+	Templates []TemplateItem `json:"templates"`
+}
+
+// ActivityService keeps each user's own state on catalog templates:
+// favorites, what they viewed and which replies they copied.
+type ActivityService interface {
+	AddFavorite(ctx context.Context, userID int32, templateID int32) error
+	RemoveFavorite(ctx context.Context, userID int32, templateID int32) error
+	// ListFavorites returns favorites still in the catalog, newest first.
+	ListFavorites(ctx context.Context, userID int32, locale LocaleRequest) (*TemplateList, error)
+	// ListRecent returns the templates the user viewed last, newest first.
+	ListRecent(ctx context.Context, userID int32, locale LocaleRequest) (*TemplateList, error)
+	RecordView(ctx context.Context, userID int32, templateID int32) error
+	RecordCopy(ctx context.Context, userID int32, templateID int32, input CopyInput) error
+}
+
+type activityService struct {
+	q *query.Query
+}
+
+func NewActivityService() ActivityService {
+	return &activityService{
+		q: query.Q,
+	}
+}
+
+func (s *activityService) AddFavorite(ctx context.Context, userID int32, templateID int32) error {
+	if _, err := s.visibleTemplate(ctx, templateID); err != nil {
+		return err
+	}
+	favorite := &model.TemplateFavorite{UserID: userID, TemplateID: templateID, CreatedAt: time.Now().UTC()}
+	// Starring twice keeps the original time
+	return s.q.TemplateFavorite.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(favorite)
+}
+
+func (s *activityService) RemoveFavorite(ctx context.Context, userID int32, templateID int32) error {
+	f := s.q.TemplateFavorite
+	_, err := f.WithContext(ctx).Where(f.UserID.Eq(userID), f.TemplateID.Eq(templateID)).Delete()
+	return err
+}
+
+func (s *activityService) ListFavorites(ctx context.Context, userID int32, locale LocaleRequest) (*TemplateList, error) {
+	f := s.q.TemplateFavorite
+	favorites, err := f.WithContext(ctx).Where(f.UserID.Eq(userID)).Order(f.CreatedAt.Desc(), f.TemplateID.Desc()).Find()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, 0, len(favorites))
+	for _, fav := range favorites {
+		ids = append(ids, fav.TemplateID)
+	}
+	return s.templateList(ctx, userID, ids, locale)
+}
+
+func (s *activityService) ListRecent(ctx context.Context, userID int32, locale LocaleRequest) (*TemplateList, error) {
+	a := s.q.TemplateActivity
+	// Hidden templates are dropped after the query, so read a few extra
+	activities, err := a.WithContext(ctx).
+		Where(a.UserID.Eq(userID), a.LastViewedAt.IsNotNull()).
+		Order(a.LastViewedAt.Desc()).
+		Limit(RecentTemplatesLimit * 2).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, 0, len(activities))
+	for _, activity := range activities {
+		ids = append(ids, activity.TemplateID)
+	}
+	list, err := s.templateList(ctx, userID, ids, locale)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Templates) > RecentTemplatesLimit {
+		list.Templates = list.Templates[:RecentTemplatesLimit]
+	}
+	return list, nil
+}
+
+func (s *activityService) RecordView(ctx context.Context, userID int32, templateID int32) error {
+	now := time.Now().UTC()
+	return touchActivity(ctx, s.q, &model.TemplateActivity{
+		UserID:       userID,
+		TemplateID:   templateID,
+		ViewCount:    1,
+		LastViewedAt: &now,
+		LastUsedAt:   now,
+	}, map[string]any{
+		"view_count":     gorm.Expr("view_count + 1"),
+		"last_viewed_at": now,
+		"last_used_at":   now,
+	})
+}
+
+// RecordCopy logs which reply was copied. Locked templates cannot be copied
+// from, so they are refused rather than counted.
+func (s *activityService) RecordCopy(ctx context.Context, userID int32, templateID int32, input CopyInput) error {
+	if input.Tone == "" {
+		return ValidationError{Field: "tone", Message: "is required"}
+	}
+	tone, err := normalizeTone(input.Tone)
+	if err != nil {
+		return err
+	}
+	template, err := s.visibleTemplate(ctx, templateID)
+	if err != nil {
+		return err
+	}
+	if template.IsPro != 0 {
+		user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+		if err != nil {
+			return err
+		}
+		if !hasPro(user) {
+			return ErrProRequired
+		}
+	}
+
+	now := time.Now().UTC()
+	return s.q.Transaction(func(tx *query.Query) error {
+		copied := &model.TemplateCopy{UserID: userID, TemplateID: templateID, Tone: tone, CreatedAt: now}
+		if err := tx.TemplateCopy.WithContext(ctx).Create(copied); err != nil {
+			return err
+		}
+		return touchActivity(ctx, tx, &model.TemplateActivity{
+			UserID:       userID,
+			TemplateID:   templateID,
+			CopyCount:    1,
+			LastCopiedAt: &now,
+			LastUsedAt:   now,
+		}, map[string]any{
+			"copy_count":     gorm.Expr("copy_count + 1"),
+			"last_copied_at": now,
+			"last_used_at":   now,
+		})
+	})
+}
+
+// touchActivity inserts the user's first activity on a template or applies
+// updates to the existing row. It goes through gorm directly because gen
+// refuses expressions such as the counter increments in an upsert.
+func touchActivity(ctx context.Context, q *query.Query, first *model.TemplateActivity, updates map[string]any) error {
+	return q.TemplateActivity.WithContext(ctx).UnderlyingDB().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "template_id"}},
+		DoUpdates: clause.Assignments(updates),
+	}).Create(first).Error
+}
+
+// visibleTemplate applies the visibility rules of the catalog detail pages,
+// which include the template's category and all of its ancestors.
+func (s *activityService) visibleTemplate(ctx context.Context, templateID int32) (*model.Template, error) {
+	templates := &templateService{q: s.q}
+	template, _, _, err := templates.findVisibleTemplate(ctx, s.q.Template.ID.Eq(templateID))
+	return template, err
+}
+
+// templateList builds catalog items for ids in the given order, skipping
+// templates that are no longer visible. The catalog snapshot only holds
+// templates whose category and its ancestors are live, as findVisibleTemplate
+// requires.
+func (s *activityService) templateList(ctx context.Context, userID int32, ids []int32, locale LocaleRequest) (*TemplateList, error) {
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := loadCatalog(ctx, s.q)
+	if err != nil {
+		return nil, err
+	}
+	resolved := locale.Resolve(user.Locale)
+	catalog = catalog.localized(resolved)
+	activity, err := loadTemplateActivity(ctx, s.q, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := catalog.tagsByTemplate()
+	byID := make(map[int32]*model.Template, len(catalog.Templates))
+	for _, t := range catalog.Templates {
+		byID[t.ID] = t
+	}
+	list := &TemplateList{Locale: resolved, Templates: make([]TemplateItem, 0, len(ids))}
+	for _, id := range ids {
+		if t, ok := byID[id]; ok {
+			list.Templates = append(list.Templates, toTemplateItem(t, toTemplateTagItems(tags[id], resolved), user, activity))
+		}
+	}
+	return list, nil
+}
+
+// templateActivity is a user's state on catalog templates as shown on
+// TemplateItem.
+type templateActivity struct {
+	favorites map[int32]bool
+	lastUsed  map[int32]time.Time
+}
+
+func loadTemplateActivity(ctx context.Context, q *query.Query, userID int32) (templateActivity, error) {
+	activity := templateActivity{favorites: map[int32]bool{}, lastUsed: map[int32]time.Time{}}
+
+	favorites, err := q.TemplateFavorite.WithContext(ctx).Where(q.TemplateFavorite.UserID.Eq(userID)).Find()
+	if err != nil {
+		return activity, err
+	}
+	for _, f := range favorites {
+		activity.favorites[f.TemplateID] = true
+	}
+
+	rows, err := q.TemplateActivity.WithContext(ctx).Where(q.TemplateActivity.UserID.Eq(userID)).Find()
+	if err != nil {
+		return activity, err
+	}
+	for _, r := range rows {
+		activity.lastUsed[r.TemplateID] = r.LastUsedAt
+	}
+	return activity, nil
+}
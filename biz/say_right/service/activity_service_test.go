@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+func TestActivityListsSkipHiddenCategories(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	content := NewContentService(NewAuditService())
+
+	parent, err := content.CreateCategory(ctx, CategoryInput{Name: "Work", IsActive: true})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	child, err := content.CreateCategory(ctx, CategoryInput{Name: "Meetings", ParentID: &parent.ID, IsActive: true})
+	if err != nil {
+		t.Fatalf("create subcategory: %v", err)
+	}
+	created, err := content.CreateTemplate(ctx, TemplateInput{
+		CategoryID:  child.ID,
+		Title:       "Decline a meeting",
+		Description: "Say no politely",
+		IsActive:    true,
+		Detail: TemplateDetailInput{
+			Headline:     "Decline",
+			Summary:      "A polite no",
+			ReplySoft:    "soft",
+			ReplyNeutral: "neutral",
+			ReplyFirm:    "firm",
+		},
+	})
+	if err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+
+	now := time.Now().UTC()
+	user := &model.User{Email: "a@example.com", EmailNorm: "a@example.com", Role: RoleUser, Status: UserStatusActive, CreatedAt: now, UpdatedAt: now}
+	if err := query.Q.User.WithContext(ctx).Create(user); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewActivityService()
+	id := created.Template.ID
+	if err := svc.AddFavorite(ctx, user.ID, id); err != nil {
+		t.Fatalf("add favorite: %v", err)
+	}
+	if err := svc.RecordView(ctx, user.ID, id); err != nil {
+		t.Fatalf("record view: %v", err)
+	}
+
+	_, err = content.UpdateCategory(ctx, parent.ID, CategoryInput{Name: "Work", IsActive: false, UpdatedAt: parent.UpdatedAt})
+	if err != nil {
+		t.Fatalf("hide parent: %v", err)
+	}
+
+	favorites, err := svc.ListFavorites(ctx, user.ID, LocaleRequest{})
+	if err != nil {
+		t.Fatalf("list favorites: %v", err)
+	}
+	recent, err := svc.ListRecent(ctx, user.ID, LocaleRequest{})
+	if err != nil {
+		t.Fatalf("list recent: %v", err)
+	}
+	if len(favorites.Templates) != 0 || len(recent.Templates) != 0 {
+		t.Errorf("favorites %d, recent %d; want the hidden template left out", len(favorites.Templates), len(recent.Templates))
+	}
+	if err := svc.AddFavorite(ctx, user.ID, id); err != ErrTemplateNotFound {
+		t.Errorf("add favorite err = %v, want ErrTemplateNotFound", err)
+	}
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+var ErrInvalidRole = errors.New("invalid role")
+var ErrOwnRole = errors.New("cannot change your own role")
+
+// ErrStaffTarget keeps staff who cannot manage roles from acting on other
+// staff accounts, e.g. support suspending or impersonating an admin.
+var ErrStaffTarget = errors.New("only staff who manage roles can act on staff accounts")
+
+const (
+	AdminDefaultPageSize = 20
+	AdminMaxPageSize     = 100
+)
+
+type UserSearchResult struct {
+	Users    []*model.User `json:"users"`
+	Total    int64         `json:"total"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"page_size"`
+}
+
+type AdminService interface {
+	SearchUsers(ctx context.Context, keyword string, page, pageSize int) (*UserSearchResult, error)
+	GetUser(ctx context.Context, userID int32) (*model.User, error)
+	ListIdentities(ctx context.Context, userID int32) ([]*model.UserIdentity, error)
+	ListBillingEvents(ctx context.Context, userID int32) ([]*model.BillingEvent, error)
+	SetPro(ctx context.Context, userID int32, isPro bool) error
+	SetSuspended(ctx context.Context, userID int32, suspended bool) error
+	SetRole(ctx context.Context, userID int32, role string) error
+	ResendCode(ctx context.Context, userID int32) error
+	RecordImpersonation(ctx context.Context, userID int32, resource string) error
+}
+
+type adminService struct {
+	q       *query.Query
+	users   UserService
+	billing BillingService
+	audit   AuditService
+}
+
+func NewAdminService(users UserService, billing BillingService, audit AuditService) AdminService {
+	return &adminService{
+		q:       query.Q,
+		users:   users,
+		billing: billing,
+		audit:   audit,
+	}
+}
+
+// SearchUsers matches the keyword against user ids and normalized emails.
+func (s *adminService) SearchUsers(ctx context.Context, keyword string, page, pageSize int) (*UserSearchResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = AdminDefaultPageSize
+	}
+	if pageSize > AdminMaxPageSize {
+		pageSize = AdminMaxPageSize
+	}
+
+	do := s.q.User.WithContext(ctx)
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	if keyword != "" {
+		if id, err := strconv.Atoi(keyword); err == nil {
+			do = do.Where(s.q.User.ID.Eq(int32(id))).Or(s.q.User.EmailNorm.Like("%" + keyword + "%"))
+		} else {
+			do = do.Where(s.q.User.EmailNorm.Like("%" + keyword + "%"))
+		}
+	}
+
+	users, total, err := do.Order(s.q.User.ID.Desc()).FindByPage((page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserSearchResult{
+		Users:    users,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func (s *adminService) GetUser(ctx context.Context, userID int32) (*model.User, error) {
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *adminService) ListIdentities(ctx context.Context, userID int32) ([]*model.UserIdentity, error) {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.q.UserIdentity.WithContext(ctx).
+		Where(s.q.UserIdentity.UserID.Eq(userID)).
+		Order(s.q.UserIdentity.ID).
+		Find()
+}
+
+func (s *adminService) ListBillingEvents(ctx context.Context, userID int32) ([]*model.BillingEvent, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.billing.ListEventsByUser(ctx, user)
+}
+
+func (s *adminService) SetPro(ctx context.Context, userID int32, isPro bool) error {
+	action := "admin.user.revoke_pro"
+	value := int32(0)
+	if isPro {
+		action = "admin.user.grant_pro"
+		value = 1
+	}
+	return s.updateUser(ctx, userID, action, map[string]interface{}{
+		"is_pro": value,
+	})
+}
+
+func (s *adminService) SetSuspended(ctx context.Context, userID int32, suspended bool) error {
+	action := "admin.user.unsuspend"
+	status := UserStatusActive
+	if suspended {
+		action = "admin.user.suspend"
+		status = UserStatusSuspended
+	}
+	return s.updateUser(ctx, userID, action, map[string]interface{}{
+		"status": status,
+	})
+}
+
+// SetRole assigns a role to the user. Staff cannot change their own role so an
+// admin cannot lock everyone out by accident.
+func (s *adminService) SetRole(ctx context.Context, userID int32, role string) error {
+	if !IsValidRole(role) {
+		return ErrInvalidRole
+	}
+	if ActorFromContext(ctx).ID == userID {
+		return ErrOwnRole
+	}
+	return s.updateUser(ctx, userID, "admin.user.set_role", map[string]interface{}{
+		"role": role,
+	})
+}
+
+func (s *adminService) ResendCode(ctx context.Context, userID int32) error {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkStaffTarget(ctx, s.q, user); err != nil {
+		return err
+	}
+	if err := s.users.SendVerificationCode(ctx, user.EmailNorm); err != nil {
+		return err
+	}
+	return s.audit.Record(ctx, nil, AuditEntry{
+		Action:     "admin.user.resend_code",
+		TargetType: "user",
+		TargetID:   strconv.Itoa(int(userID)),
+		Detail:     map[string]interface{}{"email": user.EmailNorm},
+	})
+}
+
+// RecordImpersonation logs that an admin viewed the product as the given user.
+func (s *adminService) RecordImpersonation(ctx context.Context, userID int32, resource string) error {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkStaffTarget(ctx, s.q, user); err != nil {
+		return err
+	}
+	return s.audit.Record(ctx, nil, AuditEntry{
+		Action:     "admin.user.impersonate",
+		TargetType: "user",
+		TargetID:   strconv.Itoa(int(userID)),
+		Detail:     map[string]interface{}{"resource": resource},
+	})
+}
+
+// updateUser applies the column changes and the audit entry in one transaction.
+func (s *adminService) updateUser(ctx context.Context, userID int32, action string, changes map[string]interface{}) error {
+	return s.q.Transaction(func(tx *query.Query) error {
+		user, err := tx.User.WithContext(ctx).Where(tx.User.ID.Eq(userID)).First()
+		if err != nil {
+			return ErrUserNotFound
+		}
+		if err := s.checkStaffTarget(ctx, tx, user); err != nil {
+			return err
+		}
+
+		changes["updated_at"] = time.Now().UTC()
+		if _, err := tx.User.WithContext(ctx).Where(tx.User.ID.Eq(userID)).Updates(changes); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     action,
+			TargetType: "user",
+			TargetID:   strconv.Itoa(int(userID)),
+			Before:     user,
+			After:      changes,
+		})
+	})
+}
+
+// checkStaffTarget allows acting on a staff account only to actors who may
+// manage roles. Regular users can be acted on by anyone let through the route.
+func (s *adminService) checkStaffTarget(ctx context.Context, q *query.Query, target *model.User) error {
+	if target.Role == RoleUser || !IsValidRole(target.Role) {
+		return nil
+	}
+	actor, err := q.User.WithContext(ctx).Where(q.User.ID.Eq(ActorFromContext(ctx).ID)).First()
+	if err != nil {
+		if isNotFound(err) {
+			return ErrStaffTarget
+		}
+		return err
+	}
+	if !RoleHasPermission(actor.Role, PermRolesManage) {
+		return ErrStaffTarget
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+func TestStaffTargetsNeedRolesManage(t *testing.T) {
+	setupTestDB(t)
+	now := time.Now().UTC()
+	users := map[string]*model.User{}
+	for _, role := range []string{RoleAdmin, RoleSupport, RoleUser} {
+		u := &model.User{Email: role + "@example.com", EmailNorm: role + "@example.com", Role: role, Status: UserStatusActive, CreatedAt: now, UpdatedAt: now}
+		if err := query.Q.User.WithContext(context.Background()).Create(u); err != nil {
+			t.Fatal(err)
+		}
+		users[role] = u
+	}
+	svc := NewAdminService(NewUserService(), NewBillingService(), NewAuditService())
+	as := func(role string) context.Context {
+		return WithActor(context.Background(), Actor{ID: users[role].ID, Type: ActorTypeUser})
+	}
+
+	tests := []struct {
+		name   string
+		actor  string
+		target string
+		want   error
+	}{
+		{"support suspends user", RoleSupport, RoleUser, nil},
+		{"support suspends admin", RoleSupport, RoleAdmin, ErrStaffTarget},
+		{"admin suspends support", RoleAdmin, RoleSupport, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := svc.SetSuspended(as(tt.actor), users[tt.target].ID, true); err != tt.want {
+				t.Errorf("SetSuspended = %v, want %v", err, tt.want)
+			}
+			if err := svc.SetPro(as(tt.actor), users[tt.target].ID, true); err != tt.want {
+				t.Errorf("SetPro = %v, want %v", err, tt.want)
+			}
+			if err := svc.RecordImpersonation(as(tt.actor), users[tt.target].ID, "templates"); err != tt.want {
+				t.Errorf("RecordImpersonation = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+const (
+	ActorTypeUser    = "user"
+	ActorTypeSystem  = "system"
+	ActorTypeWebhook = "webhook"
+)
+
+const auditExportBatchSize = 500
+
+// Actor describes who triggered a change and from where.
+type Actor struct {
+	ID        int32
+	Type      string
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+type actorContextKey struct{}
+
+// WithActor attaches the acting user to ctx so audit entries can pick it up.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, or a system actor when none is set.
+func ActorFromContext(ctx context.Context) Actor {
+	actor, ok := ctx.Value(actorContextKey{}).(Actor)
+	if !ok {
+		return Actor{Type: ActorTypeSystem}
+	}
+	if actor.Type == "" {
+		actor.Type = ActorTypeUser
+	}
+	return actor
+}
+
+// AuditEntry describes one change. Before and After are any JSON-serializable
+// values (models, maps); only the fields that differ between them are stored.
+type AuditEntry struct {
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     interface{}
+	After      interface{}
+	Detail     interface{}
+}
+
+type AuditFilter struct {
+	ActorID    int32
+	Action     string
+	TargetType string
+	TargetID   string
+	Since      time.Time
+	Until      time.Time
+}
+
+type AuditLogPage struct {
+	Logs     []*model.AuditLog `json:"logs"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"page_size"`
+}
+
+type AuditService interface {
+	// Record appends an entry to the audit log. Pass the transaction the change
+	// is made in as tx so both are committed together; nil uses the default connection.
+	Record(ctx context.Context, tx *query.Query, entry AuditEntry) error
+	List(ctx context.Context, filter AuditFilter, page, pageSize int) (*AuditLogPage, error)
+	// Export calls fn for every matching entry in id order, reading in batches.
+	Export(ctx context.Context, filter AuditFilter, fn func(*model.AuditLog) error) error
+}
+
+// auditService is append-only on purpose: there is no way to update or delete entries.
+type auditService struct {
+	q *query.Query
+}
+
+func NewAuditService() AuditService {
+	return &auditService{
+		q: query.Q,
+	}
+}
+
+func (s *auditService) Record(ctx context.Context, tx *query.Query, entry AuditEntry) error {
+	if tx == nil {
+		tx = s.q
+	}
+
+	before, after, err := diffFields(entry.Before, entry.After)
+	if err != nil {
+		return err
+	}
+
+	detail := "{}"
+	if entry.Detail != nil {
+		b, err := json.Marshal(entry.Detail)
+		if err != nil {
+			return err
+		}
+		detail = string(b)
+	}
+
+	actor := ActorFromContext(ctx)
+	return tx.AuditLog.WithContext(ctx).Create(&model.AuditLog{
+		ActorID:    actor.ID,
+		ActorType:  actor.Type,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		BeforeData: before,
+		AfterData:  after,
+		Detail:     detail,
+		IP:         actor.IP,
+		UserAgent:  truncate(actor.UserAgent, 512),
+		RequestID:  actor.RequestID,
+	})
+}
+
+func (s *auditService) List(ctx context.Context, filter AuditFilter, page, pageSize int) (*AuditLogPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = AdminDefaultPageSize
+	}
+	if pageSize > AdminMaxPageSize {
+		pageSize = AdminMaxPageSize
+	}
+
+	logs, total, err := s.filtered(ctx, filter).
+		Order(s.q.AuditLog.ID.Desc()).
+		FindByPage((page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditLogPage{
+		Logs:     logs,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func (s *auditService) Export(ctx context.Context, filter AuditFilter, fn func(*model.AuditLog) error) error {
+	var lastID int32
+	for {
+		logs, err := s.filtered(ctx, filter).
+			Where(s.q.AuditLog.ID.Gt(lastID)).
+			Order(s.q.AuditLog.ID).
+			Limit(auditExportBatchSize).
+			Find()
+		if err != nil {
+			return err
+		}
+		for _, l := range logs {
+			if err := fn(l); err != nil {
+				return err
+			}
+			lastID = l.ID
+		}
+		if len(logs) < auditExportBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *auditService) filtered(ctx context.Context, filter AuditFilter) query.IAuditLogDo {
+	a := s.q.AuditLog
+	do := a.WithContext(ctx)
+	if filter.ActorID > 0 {
+		do = do.Where(a.ActorID.Eq(filter.ActorID))
+	}
+	if filter.Action != "" {
+		do = do.Where(a.Action.Eq(filter.Action))
+	}
+	if filter.TargetType != "" {
+		do = do.Where(a.TargetType.Eq(filter.TargetType))
+	}
+	if filter.TargetID != "" {
+		do = do.Where(a.TargetID.Eq(filter.TargetID))
+	}
+	if !filter.Since.IsZero() {
+		do = do.Where(a.CreatedAt.Gte(filter.Since))
+	}
+	if !filter.Until.IsZero() {
+		do = do.Where(a.CreatedAt.Lt(filter.Until))
+	}
+	return do
+}
+
+// auditIgnoredFields are bookkeeping columns that change on every write.
+var auditIgnoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// diffFields serializes before and after and keeps only the fields whose values
+// changed. A nil side (create or delete) keeps the other side in full. Both
+// sides go through JSON first, so an int32 model field and the same number
+// decoded into a map compare equal.
+func diffFields(before, after interface{}) (string, string, error) {
+	beforeMap, err := toFieldMap(before)
+	if err != nil {
+		return "", "", err
+	}
+	afterMap, err := toFieldMap(after)
+	if err != nil {
+		return "", "", err
+	}
+
+	if beforeMap != nil && afterMap != nil {
+		changedBefore := make(map[string]interface{})
+		changedAfter := make(map[string]interface{})
+		for k, v := range afterMap {
+			if auditIgnoredFields[k] {
+				continue
+			}
+			if old, ok := beforeMap[k]; !ok || !reflect.DeepEqual(old, v) {
+				changedBefore[k] = beforeMap[k]
+				changedAfter[k] = v
+			}
+		}
+		beforeMap, afterMap = changedBefore, changedAfter
+	}
+
+	beforeJSON, err := marshalFieldMap(beforeMap)
+	if err != nil {
+		return "", "", err
+	}
+	afterJSON, err := marshalFieldMap(afterMap)
+	if err != nil {
+		return "", "", err
+	}
+	return beforeJSON, afterJSON, nil
+}
+
+func toFieldMap(value interface{}) (map[string]interface{}, error) {
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil()) {
+		return nil, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	// Numbers stay as their JSON text so every numeric type compares alike
+	// and large ids keep their precision
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var result map[string]interface{}
+	if err := dec.Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func marshalFieldMap(value map[string]interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
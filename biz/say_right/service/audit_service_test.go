@@ -0,0 +1,23 @@
+package service
+
+import (
+	"testing"
+
+	"api/biz/say_right/dal/model"
+)
+
+func TestDiffFieldsComparesNumbersByValue(t *testing.T) {
+	before := &model.Template{ID: 3, SortOrder: 2, IsActive: 1}
+	after := map[string]interface{}{
+		"id":         float64(3),
+		"sort_order": 2,
+		"is_active":  int64(0),
+	}
+	changedBefore, changedAfter, err := diffFields(before, after)
+	if err != nil {
+		t.Fatalf("diffFields: %v", err)
+	}
+	if changedBefore != `{"is_active":1}` || changedAfter != `{"is_active":0}` {
+		t.Errorf("diff = %s -> %s, want only is_active", changedBefore, changedAfter)
+	}
+}
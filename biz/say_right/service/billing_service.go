@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+type BillingService interface {
+	RecordEvent(ctx context.Context, event *model.BillingEvent) error
+	ListEventsByUser(ctx context.Context, user *model.User) ([]*model.BillingEvent, error)
+}
+
+type billingService struct {
+	q *query.Query
+}
+
+func NewBillingService() BillingService {
+	return &billingService{
+		q: query.Q,
+	}
+}
+
+// RecordEvent stores a webhook event once; Paddle retries deliveries, so
+// events that were already recorded are ignored.
+func (s *billingService) RecordEvent(ctx context.Context, event *model.BillingEvent) error {
+	count, err := s.q.BillingEvent.WithContext(ctx).
+		Where(s.q.BillingEvent.EventID.Eq(event.EventID)).
+		Count()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if event.UserID == 0 && event.Email != "" {
+		user, err := s.q.User.WithContext(ctx).Where(s.q.User.EmailNorm.Eq(event.Email)).First()
+		if err == nil {
+			event.UserID = user.ID
+		}
+	}
+
+	return s.q.BillingEvent.WithContext(ctx).Create(event)
+}
+
+// ListEventsByUser returns events linked to the user, including ones received
+// before the account existed and only matched by email.
+func (s *billingService) ListEventsByUser(ctx context.Context, user *model.User) ([]*model.BillingEvent, error) {
+	return s.q.BillingEvent.WithContext(ctx).
+		Where(s.q.BillingEvent.UserID.Eq(user.ID)).
+		Or(s.q.BillingEvent.Email.Eq(user.EmailNorm)).
+		Order(s.q.BillingEvent.CreatedAt.Desc(), s.q.BillingEvent.ID.Desc()).
+		Find()
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+	"api/infra/redis"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+const (
+	KeyCatalog      = "biz:say_right:catalog:v5" // visible categories, templates, tags, collections and translations as JSON
+	CatalogCacheTTL = 10 * time.Minute
+)
+
+// catalogSnapshot is the part of the catalog every user sees; per-user state
+// such as Pro locking is applied on top of it. A category is only in it when
+// its ancestors are too, and a template only when its category is.
+type catalogSnapshot struct {
+	Categories   []*model.Category    `json:"categories"`
+	Templates    []*model.Template    `json:"templates"`
+	Tags         []*model.Tag         `json:"tags"`
+	TemplateTags []*model.TemplateTag `json:"template_tags"`
+	// Collections holds active collections; their items may point at hidden templates.
+	Collections     []*model.Collection     `json:"collections"`
+	CollectionItems []*model.CollectionItem `json:"collection_items"`
+	// Translations cover every locale; template rows carry only title and description.
+	CategoryTranslations []*model.CategoryTranslation `json:"category_translations"`
+	TemplateTranslations []*model.TemplateTranslation `json:"template_translations"`
+}
+
+// loadCatalog returns the currently visible catalog, served from Redis when possible.
+// Cache errors are logged and fall through to the database.
+func loadCatalog(ctx context.Context, q *query.Query) (*catalogSnapshot, error) {
+	if cached, err := redis.Client.Get(ctx, KeyCatalog).Bytes(); err == nil {
+		var snapshot catalogSnapshot
+		if err := json.Unmarshal(cached, &snapshot); err == nil {
+			return &snapshot, nil
+		}
+	}
+
+	now := time.Now().UTC()
+	categories, err := q.Category.WithContext(ctx).
+		Where(q.Category.IsActive.Eq(1)).
+		Where(categoryScheduleVisible(q, now)...).
+		Order(q.Category.SortOrder, q.Category.ID).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	categories = withVisibleAncestors(categories)
+	categoryIDs := make([]int32, 0, len(categories))
+	for _, c := range categories {
+		categoryIDs = append(categoryIDs, c.ID)
+	}
+
+	templates, err := q.Template.WithContext(ctx).
+		Where(q.Template.IsActive.Eq(1)).
+		Where(templateScheduleVisible(q, now)...).
+		Where(q.Template.CategoryID.In(categoryIDs...)).
+		Order(q.Template.CategoryID, q.Template.SortOrder, q.Template.ID).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := q.Tag.WithContext(ctx).Order(q.Tag.Name).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	templateTags, err := q.TemplateTag.WithContext(ctx).
+		Order(q.TemplateTag.TemplateID, q.TemplateTag.SortOrder).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := q.Collection.WithContext(ctx).
+		Where(q.Collection.IsActive.Eq(1)).
+		Order(q.Collection.SortOrder, q.Collection.ID).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	collectionItems, err := q.CollectionItem.WithContext(ctx).
+		Order(q.CollectionItem.CollectionID, q.CollectionItem.SortOrder).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	categoryTranslations, err := q.CategoryTranslation.WithContext(ctx).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	templateTranslations, err := q.TemplateTranslation.WithContext(ctx).
+		Select(q.TemplateTranslation.ID, q.TemplateTranslation.TemplateID, q.TemplateTranslation.Locale,
+			q.TemplateTranslation.Title, q.TemplateTranslation.Description).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &catalogSnapshot{
+		Categories:           categories,
+		Templates:            templates,
+		Tags:                 tags,
+		TemplateTags:         templateTags,
+		Collections:          collections,
+		CollectionItems:      collectionItems,
+		CategoryTranslations: categoryTranslations,
+		TemplateTranslations: templateTranslations,
+	}
+	if b, err := json.Marshal(snapshot); err == nil {
+		if err := redis.Client.Set(ctx, KeyCatalog, b, CatalogCacheTTL).Err(); err != nil {
+			log.Printf("Failed to cache catalog: %v", err)
+		}
+	}
+	return snapshot, nil
+}
+
+// InvalidateCatalogCache drops the cached catalog, and everything rendered
+// from it, so the next read rebuilds it. Template embeddings are refreshed
+// in the background.
+func InvalidateCatalogCache(ctx context.Context) {
+	catalogGeneration.Add(1)
+	if err := redis.Client.Del(ctx, KeyCatalog, KeySitemap, KeyTemplateFeed).Err(); err != nil {
+		log.Printf("Failed to invalidate catalog cache: %v", err)
+	}
+	refreshEmbeddingsInBackground()
+}
+
+// templateScheduleVisible matches templates whose publishing window contains now.
+func templateScheduleVisible(q *query.Query, now time.Time) []gen.Condition {
+	return []gen.Condition{
+		field.Or(q.Template.PublishAt.IsNull(), q.Template.PublishAt.Lte(now)),
+		field.Or(q.Template.UnpublishAt.IsNull(), q.Template.UnpublishAt.Gt(now)),
+	}
+}
+
+// categoryScheduleVisible matches categories whose publishing window contains now.
+func categoryScheduleVisible(q *query.Query, now time.Time) []gen.Condition {
+	return []gen.Condition{
+		field.Or(q.Category.PublishAt.IsNull(), q.Category.PublishAt.Lte(now)),
+		field.Or(q.Category.UnpublishAt.IsNull(), q.Category.UnpublishAt.Gt(now)),
+	}
+}
+
+// withVisibleAncestors keeps the live categories whose ancestors are all
+// live as well; a parent missing from live is hidden.
+func withVisibleAncestors(live []*model.Category) []*model.Category {
+	byID := make(map[int32]*model.Category, len(live))
+	for _, c := range live {
+		byID[c.ID] = c
+	}
+	result := make([]*model.Category, 0, len(live))
+	for _, c := range live {
+		visible := true
+		parent := c.ParentID
+		for depth := 0; parent != nil && depth <= categoryMaxDepth; depth++ {
+			p, ok := byID[*parent]
+			if !ok {
+				visible = false
+				break
+			}
+			parent = p.ParentID
+		}
+		if visible && parent == nil {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// findVisibleCategory loads a live category whose ancestors are all live,
+// the rule withVisibleAncestors applies to the cached catalog.
+func findVisibleCategory(ctx context.Context, q *query.Query, categoryID int32, now time.Time) (*model.Category, error) {
+	c := q.Category
+	var category *model.Category
+	id := &categoryID
+	for depth := 0; id != nil && depth <= categoryMaxDepth; depth++ {
+		current, err := c.WithContext(ctx).
+			Where(c.ID.Eq(*id), c.IsActive.Eq(1)).
+			Where(categoryScheduleVisible(q, now)...).
+			First()
+		if err != nil {
+			return nil, err
+		}
+		if category == nil {
+			category = current
+		}
+		id = current.ParentID
+	}
+	if id != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return category, nil
+}
+
+// templatesWithTags returns the ids of templates linked to every tag slug in slugs.
+func (c *catalogSnapshot) templatesWithTags(slugs []string) map[int32]bool {
+	tagIDs := make(map[int32]bool, len(slugs))
+	for _, tag := range c.Tags {
+		for _, slug := range slugs {
+			if tag.Slug == slug {
+				tagIDs[tag.ID] = true
+			}
+		}
+	}
+	if len(tagIDs) < len(slugs) {
+		return map[int32]bool{}
+	}
+
+	matched := make(map[int32]int)
+	for _, link := range c.TemplateTags {
+		if tagIDs[link.TagID] {
+			matched[link.TemplateID]++
+		}
+	}
+	result := make(map[int32]bool, len(matched))
+	for templateID, n := range matched {
+		if n == len(tagIDs) {
+			result[templateID] = true
+		}
+	}
+	return result
+}
+
+// tagsByTemplate groups the tags linked to each template, in the template's
+// tag order.
+func (c *catalogSnapshot) tagsByTemplate() map[int32][]*model.Tag {
+	tags := make(map[int32]*model.Tag, len(c.Tags))
+	for _, tag := range c.Tags {
+		tags[tag.ID] = tag
+	}
+	result := make(map[int32][]*model.Tag)
+	for _, link := range c.TemplateTags {
+		if tag, ok := tags[link.TagID]; ok {
+			result[link.TemplateID] = append(result[link.TemplateID], tag)
+		}
+	}
+	return result
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	CatalogFormatJSON = "json"
+	CatalogFormatYAML = "yaml"
+	CatalogFormatCSV  = "csv"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported catalog format")
+
+// catalogCSVHeader is the single-sheet CSV layout. Category rows use
+// parent_slug, name and icon; template rows use the remaining columns. Tags are
+// comma separated, best practices one per line within the cell and variables
+// a JSON array.
+var catalogCSVHeader = []string{
+	"type", "slug", "parent_slug", "category_slug", "name", "title", "description", "icon", "tags",
+	"is_pro", "sort_order", "is_active", "publish_at", "unpublish_at",
+	"headline", "summary", "reply_soft", "reply_neutral", "reply_firm",
+	"when_not_to_use", "best_practices", "variables", "prompt",
+}
+
+// CatalogContentType returns the MIME type used when serving format.
+func CatalogContentType(format string) string {
+	switch format {
+	case CatalogFormatYAML:
+		return "application/yaml; charset=utf-8"
+	case CatalogFormatCSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+func EncodeCatalog(w io.Writer, format string, doc *CatalogDocument) error {
+	switch format {
+	case CatalogFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	case CatalogFormatYAML:
+		return yaml.NewEncoder(w).Encode(doc)
+	case CatalogFormatCSV:
+		return encodeCatalogCSV(w, doc)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+// DecodeCatalog parses a catalog file. Malformed files fail as a whole; per-row
+// problems are left for Import to report.
+func DecodeCatalog(r io.Reader, format string) (*CatalogDocument, error) {
+	var doc CatalogDocument
+	switch format {
+	case CatalogFormatJSON:
+		if err := json.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, err
+		}
+	case CatalogFormatYAML:
+		if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, err
+		}
+	case CatalogFormatCSV:
+		return decodeCatalogCSV(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	return &doc, nil
+}
+
+func encodeCatalogCSV(w io.Writer, doc *CatalogDocument) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(catalogCSVHeader); err != nil {
+		return err
+	}
+	for _, c := range doc.Categories {
+		record := map[string]string{
+			"type":         CatalogKindCategory,
+			"slug":         c.Slug,
+			"parent_slug":  c.ParentSlug,
+			"name":         c.Name,
+			"description":  c.Description,
+			"icon":         c.Icon,
+			"sort_order":   strconv.Itoa(int(c.SortOrder)),
+			"is_active":    strconv.FormatBool(c.IsActive),
+			"publish_at":   formatScheduleTime(c.PublishAt),
+			"unpublish_at": formatScheduleTime(c.UnpublishAt),
+		}
+		if err := writer.Write(csvRecord(record)); err != nil {
+			return err
+		}
+	}
+	for _, t := range doc.Templates {
+		record := map[string]string{
+			"type":            CatalogKindTemplate,
+			"slug":            t.Slug,
+			"category_slug":   t.CategorySlug,
+			"title":           t.Title,
+			"description":     t.Description,
+			"tags":            strings.Join(t.Tags, ","),
+			"is_pro":          strconv.FormatBool(t.IsPro),
+			"sort_order":      strconv.Itoa(int(t.SortOrder)),
+			"is_active":       strconv.FormatBool(t.IsActive),
+			"publish_at":      formatScheduleTime(t.PublishAt),
+			"unpublish_at":    formatScheduleTime(t.UnpublishAt),
+			"headline":        t.Detail.Headline,
+			"summary":         t.Detail.Summary,
+			"reply_soft":      t.Detail.ReplySoft,
+			"reply_neutral":   t.Detail.ReplyNeutral,
+			"reply_firm":      t.Detail.ReplyFirm,
+			"when_not_to_use": t.Detail.WhenNotToUse,
+			"best_practices":  strings.Join(t.Detail.BestPractices, "\n"),
+			"variables":       csvVariables(t.Detail.Variables),
+			"prompt":          t.Detail.Prompt,
+		}
+		if err := writer.Write(csvRecord(record)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvVariables(variables []TemplateVariable) string {
+	if len(variables) == 0 {
+		return ""
+	}
+	return encodeVariables(variables)
+}
+
+func csvRecord(values map[string]string) []string {
+	record := make([]string, len(catalogCSVHeader))
+	for i, column := range catalogCSVHeader {
+		record[i] = values[column]
+	}
+	return record
+}
+
+// decodeCatalogCSV reads rows by header name so columns may be reordered or
+// omitted. Row numbers count the header as line 1, as spreadsheets do.
+func decodeCatalogCSV(r io.Reader) (*CatalogDocument, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	if _, ok := columns["type"]; !ok {
+		return nil, errors.New("csv header must include a type column")
+	}
+
+	doc := &CatalogDocument{
+		Categories: make([]*CatalogCategory, 0),
+		Templates:  make([]*CatalogTemplate, 0),
+	}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return doc, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		get := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		row := csvRow{line: line, get: get}
+		switch kind := strings.TrimSpace(get("type")); kind {
+		case CatalogKindCategory:
+			category := &CatalogCategory{
+				Slug:        strings.TrimSpace(get("slug")),
+				ParentSlug:  strings.TrimSpace(get("parent_slug")),
+				Name:        get("name"),
+				Description: get("description"),
+				Icon:        get("icon"),
+				SortOrder:   row.int32("sort_order"),
+				IsActive:    row.bool("is_active"),
+				PublishAt:   row.time("publish_at"),
+				UnpublishAt: row.time("unpublish_at"),
+				row:         line,
+			}
+			if row.err != nil {
+				return nil, row.err
+			}
+			doc.Categories = append(doc.Categories, category)
+		case CatalogKindTemplate:
+			template := &CatalogTemplate{
+				Slug:         strings.TrimSpace(get("slug")),
+				CategorySlug: strings.TrimSpace(get("category_slug")),
+				Title:        get("title"),
+				Description:  get("description"),
+				Tags:         splitTags(get("tags")),
+				IsPro:        row.bool("is_pro"),
+				SortOrder:    row.int32("sort_order"),
+				IsActive:     row.bool("is_active"),
+				PublishAt:    row.time("publish_at"),
+				UnpublishAt:  row.time("unpublish_at"),
+				Detail: TemplateDetailInput{
+					Headline:      get("headline"),
+					Summary:       get("summary"),
+					ReplySoft:     get("reply_soft"),
+					ReplyNeutral:  get("reply_neutral"),
+					ReplyFirm:     get("reply_firm"),
+					WhenNotToUse:  get("when_not_to_use"),
+					BestPractices: splitLines(get("best_practices")),
+					Variables:     row.variables("variables"),
+					Prompt:        get("prompt"),
+				},
+				row: line,
+			}
+			if row.err != nil {
+				return nil, row.err
+			}
+			doc.Templates = append(doc.Templates, template)
+		case "":
+			continue
+		default:
+			return nil, fmt.Errorf("row %d: unknown type %q", line, kind)
+		}
+	}
+}
+
+// csvRow converts typed cells, keeping the first parse error.
+type csvRow struct {
+	line int
+	get  func(string) string
+	err  error
+}
+
+func (r *csvRow) fail(column string, err error) {
+	if r.err == nil {
+		r.err = fmt.Errorf("row %d: %s: %w", r.line, column, err)
+	}
+}
+
+func (r *csvRow) int32(column string) int32 {
+	value := strings.TrimSpace(r.get(column))
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		r.fail(column, err)
+	}
+	return int32(n)
+}
+
+func (r *csvRow) bool(column string) bool {
+	value := strings.TrimSpace(r.get(column))
+	if value == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		r.fail(column, err)
+	}
+	return b
+}
+
+func (r *csvRow) variables(column string) []TemplateVariable {
+	value := strings.TrimSpace(r.get(column))
+	if value == "" {
+		return nil
+	}
+	var variables []TemplateVariable
+	if err := json.Unmarshal([]byte(value), &variables); err != nil {
+		r.fail(column, err)
+	}
+	return variables
+}
+
+func (r *csvRow) time(column string) *time.Time {
+	value := strings.TrimSpace(r.get(column))
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		r.fail(column, err)
+		return nil
+	}
+	return &t
+}
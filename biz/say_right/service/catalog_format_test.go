@@ -0,0 +1,83 @@
+package service
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCatalogCSVRoundTrip(t *testing.T) {
+	publishAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	doc := &CatalogDocument{
+		Categories: []*CatalogCategory{
+			{Slug: "work", Name: "Work", Description: "At the office", Icon: "briefcase", SortOrder: 1, IsActive: true, row: 2},
+			{Slug: "meetings", ParentSlug: "work", Name: "Meetings, calls", SortOrder: 2, PublishAt: &publishAt, row: 3},
+		},
+		Templates: []*CatalogTemplate{
+			{
+				Slug:         "decline-meeting",
+				CategorySlug: "meetings",
+				Title:        `Decline a "quick" meeting`,
+				Description:  "Say no, politely",
+				Tags:         []string{"boundaries", "拒绝"},
+				IsPro:        true,
+				SortOrder:    3,
+				IsActive:     true,
+				UnpublishAt:  &publishAt,
+				Detail: TemplateDetailInput{
+					Headline:      "Decline",
+					Summary:       "A polite no",
+					ReplySoft:     "Hi {{name}},\nI can't make it, sorry!",
+					ReplyNeutral:  "I won't attend.",
+					ReplyFirm:     "No.",
+					WhenNotToUse:  "When it's your manager's one-on-one",
+					BestPractices: []string{"Offer another time", "Keep it short"},
+					Variables:     []TemplateVariable{{Name: "name", Type: VariableText, Required: true}},
+					Prompt:        "Write a reply to {{name}}",
+				},
+				row: 4,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeCatalog(&buf, CatalogFormatCSV, doc); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	got, err := DecodeCatalog(&buf, CatalogFormatCSV)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(got.Categories, doc.Categories) {
+		for i := range got.Categories {
+			t.Logf("category %d: %+v", i, *got.Categories[i])
+		}
+		t.Error("categories changed in the round trip")
+	}
+	if !reflect.DeepEqual(got.Templates, doc.Templates) {
+		for i := range got.Templates {
+			t.Logf("template %d: %+v", i, *got.Templates[i])
+		}
+		t.Error("templates changed in the round trip")
+	}
+}
+
+func TestDecodeCatalogCSVErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{"missing type column", "slug,name\nwork,Work\n"},
+		{"unknown type", "type,slug\nwidget,work\n"},
+		{"bad number", "type,slug,sort_order\ncategory,work,first\n"},
+		{"bad variables", "type,slug,variables\ntemplate,decline,not json\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCatalog(bytes.NewBufferString(tt.csv), CatalogFormatCSV); err == nil {
+				t.Error("DecodeCatalog succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,556 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+const (
+	ImportActionCreate    = "create"
+	ImportActionUpdate    = "update"
+	ImportActionUnchanged = "unchanged"
+)
+
+const (
+	CatalogKindCategory = "category"
+	CatalogKindTemplate = "template"
+)
+
+// errImportRollback aborts the import transaction for dry runs and invalid files.
+var errImportRollback = errors.New("catalog import rolled back")
+
+// CatalogDocument is the portable form of the whole catalog. Categories and
+// templates are matched by slug on import, never by id.
+type CatalogDocument struct {
+	Categories []*CatalogCategory `json:"categories"`
+	Templates  []*CatalogTemplate `json:"templates"`
+}
+
+type CatalogCategory struct {
+	Slug string `json:"slug"`
+	// ParentSlug must name a category listed earlier in the file or already stored.
+	ParentSlug  string     `json:"parent_slug,omitempty"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Icon        string     `json:"icon"`
+	SortOrder   int32      `json:"sort_order"`
+	IsActive    bool       `json:"is_active"`
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+
+	row int // position in the source file, for error reporting
+}
+
+type CatalogTemplate struct {
+	Slug         string              `json:"slug"`
+	CategorySlug string              `json:"category_slug"`
+	Title        string              `json:"title"`
+	Description  string              `json:"description"`
+	Tags         []string            `json:"tags"`
+	IsPro        bool                `json:"is_pro"`
+	SortOrder    int32               `json:"sort_order"`
+	IsActive     bool                `json:"is_active"`
+	PublishAt    *time.Time          `json:"publish_at,omitempty"`
+	UnpublishAt  *time.Time          `json:"unpublish_at,omitempty"`
+	Detail       TemplateDetailInput `json:"detail"`
+
+	row int
+}
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Kind    string `json:"kind"`
+	Slug    string `json:"slug"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ImportChange struct {
+	Kind   string        `json:"kind"`
+	Slug   string        `json:"slug"`
+	Action string        `json:"action"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// ImportResult describes what an import did, or would do for a dry run.
+// Nothing is written unless Applied is true.
+type ImportResult struct {
+	DryRun  bool             `json:"dry_run"`
+	Applied bool             `json:"applied"`
+	Errors  []ImportRowError `json:"errors"`
+	Changes []ImportChange   `json:"changes"`
+}
+
+type CatalogService interface {
+	Export(ctx context.Context) (*CatalogDocument, error)
+	// Import upserts categories and templates by slug in a single transaction.
+	// Any row error rolls the whole import back.
+	Import(ctx context.Context, doc *CatalogDocument, dryRun bool) (*ImportResult, error)
+}
+
+type catalogService struct {
+	*contentService
+}
+
+func NewCatalogService(audit AuditService) CatalogService {
+	return &catalogService{
+		contentService: &contentService{
+			q:     query.Q,
+			audit: audit,
+		},
+	}
+}
+
+func (s *catalogService) Export(ctx context.Context) (*CatalogDocument, error) {
+	categories, err := s.q.Category.WithContext(ctx).
+		Order(s.q.Category.SortOrder, s.q.Category.ID).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	templates, err := s.q.Template.WithContext(ctx).
+		Order(s.q.Template.CategoryID, s.q.Template.SortOrder, s.q.Template.ID).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.q.TemplateDetail.WithContext(ctx).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &CatalogDocument{
+		Categories: make([]*CatalogCategory, 0, len(categories)),
+		Templates:  make([]*CatalogTemplate, 0, len(templates)),
+	}
+	categorySlugs := make(map[int32]string, len(categories))
+	for _, c := range categories {
+		categorySlugs[c.ID] = c.Slug
+	}
+	for _, c := range parentsFirst(categories) {
+		doc.Categories = append(doc.Categories, toCatalogCategory(c, categorySlugs))
+	}
+	detailsByTemplate := make(map[int32]*model.TemplateDetail, len(details))
+	for _, d := range details {
+		detailsByTemplate[d.TemplateID] = d
+	}
+	for _, t := range templates {
+		detail, ok := detailsByTemplate[t.ID]
+		if !ok {
+			detail = &model.TemplateDetail{TemplateID: t.ID}
+		}
+		doc.Templates = append(doc.Templates, toCatalogTemplate(snapshotOf(t, detail), categorySlugs[t.CategoryID]))
+	}
+	return doc, nil
+}
+
+func (s *catalogService) Import(ctx context.Context, doc *CatalogDocument, dryRun bool) (*ImportResult, error) {
+	result := &ImportResult{
+		DryRun:  dryRun,
+		Errors:  make([]ImportRowError, 0),
+		Changes: make([]ImportChange, 0),
+	}
+
+	// Everything runs for real inside the transaction so a dry run sees the
+	// same constraint failures an actual import would; it is then rolled back.
+	err := s.q.Transaction(func(tx *query.Query) error {
+		categoryIDs, err := s.importCategories(ctx, tx, doc.Categories, result)
+		if err != nil {
+			return err
+		}
+		if err := s.importTemplates(ctx, tx, doc.Templates, categoryIDs, result); err != nil {
+			return err
+		}
+		if dryRun || len(result.Errors) > 0 {
+			return errImportRollback
+		}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.catalog.import",
+			TargetType: "catalog",
+			Detail:     importSummary(result),
+		})
+	})
+	if err != nil && err != errImportRollback {
+		return nil, err
+	}
+	if err == nil {
+		result.Applied = true
+		InvalidateCatalogCache(ctx)
+	}
+	return result, nil
+}
+
+func (s *catalogService) importCategories(ctx context.Context, tx *query.Query, rows []*CatalogCategory, result *ImportResult) (map[string]int32, error) {
+	ids := make(map[string]int32, len(rows))
+	seen := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		rowNum := rowNumber(row.row, i)
+		fail := func(err error) error {
+			return result.rowError(rowNum, CatalogKindCategory, row.Slug, err)
+		}
+
+		if row.Slug == "" {
+			if err := fail(ValidationError{Field: "slug", Message: "is required"}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if seen[row.Slug] {
+			if err := fail(ValidationError{Field: "slug", Message: "appears more than once in the file"}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		seen[row.Slug] = true
+		parentID, err := resolveParentSlug(ctx, tx, row.ParentSlug, ids)
+		if err != nil {
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		input := row.input(parentID)
+		if err := validateCategory(input); err != nil {
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		current, err := tx.Category.WithContext(ctx).Where(tx.Category.Slug.Eq(row.Slug)).First()
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+
+		change := ImportChange{Kind: CatalogKindCategory, Slug: row.Slug, Action: ImportActionCreate}
+		var category *model.Category
+		if current == nil {
+			category, err = s.createCategory(ctx, tx, input)
+		} else {
+			var currentParent string
+			currentParent, err = categorySlugByID(ctx, tx, current.ParentID)
+			if err != nil {
+				return nil, err
+			}
+			stored := toCatalogCategory(current, nil)
+			stored.ParentSlug = currentParent
+			next := normalizeCatalogCategory(input)
+			next.ParentSlug = row.ParentSlug
+			change.Fields = diffCatalogCategories(stored, next)
+			category = current
+			if len(change.Fields) == 0 {
+				change.Action = ImportActionUnchanged
+			} else {
+				change.Action = ImportActionUpdate
+				category, err = s.updateCategory(ctx, tx, current.ID, input, current.UpdatedAt)
+			}
+		}
+		if err != nil {
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		ids[row.Slug] = category.ID
+		result.Changes = append(result.Changes, change)
+	}
+	return ids, nil
+}
+
+func (s *catalogService) importTemplates(ctx context.Context, tx *query.Query, rows []*CatalogTemplate, categoryIDs map[string]int32, result *ImportResult) error {
+	seen := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		rowNum := rowNumber(row.row, i)
+		fail := func(err error) error {
+			return result.rowError(rowNum, CatalogKindTemplate, row.Slug, err)
+		}
+
+		if row.Slug == "" {
+			if err := fail(ValidationError{Field: "slug", Message: "is required"}); err != nil {
+				return err
+			}
+			continue
+		}
+		if seen[row.Slug] {
+			if err := fail(ValidationError{Field: "slug", Message: "appears more than once in the file"}); err != nil {
+				return err
+			}
+			continue
+		}
+		seen[row.Slug] = true
+
+		categoryID, err := resolveCategorySlug(ctx, tx, row.CategorySlug, categoryIDs)
+		if err != nil {
+			if err := fail(err); err != nil {
+				return err
+			}
+			continue
+		}
+		input := row.input(categoryID)
+		if err := validateTemplate(input); err != nil {
+			if err := fail(err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		current, err := tx.Template.WithContext(ctx).Where(tx.Template.Slug.Eq(row.Slug)).First()
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+
+		change := ImportChange{Kind: CatalogKindTemplate, Slug: row.Slug, Action: ImportActionCreate}
+		if current == nil {
+			_, err = s.createTemplate(ctx, tx, input)
+		} else {
+			detail, detailErr := tx.TemplateDetail.WithContext(ctx).Where(tx.TemplateDetail.TemplateID.Eq(current.ID)).First()
+			if detailErr != nil {
+				detail = &model.TemplateDetail{TemplateID: current.ID}
+			}
+			change.Fields = diffSnapshots(snapshotOf(current, detail), normalizeTemplateInput(input))
+			if len(change.Fields) == 0 {
+				change.Action = ImportActionUnchanged
+			} else {
+				change.Action = ImportActionUpdate
+				_, err = s.applyTemplate(ctx, tx, current.ID, input, current.UpdatedAt, "content.template.import", "catalog import")
+			}
+		}
+		if err != nil {
+			if err := fail(err); err != nil {
+				return err
+			}
+			continue
+		}
+		result.Changes = append(result.Changes, change)
+	}
+	return nil
+}
+
+// rowError records validation failures against the row and passes anything
+// else back so the import aborts.
+func (r *ImportResult) rowError(row int, kind, slug string, err error) error {
+	var validationErr ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		r.Errors = append(r.Errors, ImportRowError{Row: row, Kind: kind, Slug: slug, Field: validationErr.Field, Message: validationErr.Message})
+	case err == ErrConflict:
+		r.Errors = append(r.Errors, ImportRowError{Row: row, Kind: kind, Slug: slug, Message: err.Error()})
+	default:
+		return err
+	}
+	return nil
+}
+
+func resolveCategorySlug(ctx context.Context, tx *query.Query, slug string, imported map[string]int32) (int32, error) {
+	if slug == "" {
+		return 0, ValidationError{Field: "category_slug", Message: "is required"}
+	}
+	if id, ok := imported[slug]; ok {
+		return id, nil
+	}
+	category, err := tx.Category.WithContext(ctx).Where(tx.Category.Slug.Eq(slug)).First()
+	if err != nil {
+		if isNotFound(err) {
+			return 0, ValidationError{Field: "category_slug", Message: "category does not exist"}
+		}
+		return 0, err
+	}
+	return category.ID, nil
+}
+
+func resolveParentSlug(ctx context.Context, tx *query.Query, slug string, imported map[string]int32) (*int32, error) {
+	if slug == "" {
+		return nil, nil
+	}
+	if id, ok := imported[slug]; ok {
+		return &id, nil
+	}
+	category, err := tx.Category.WithContext(ctx).Where(tx.Category.Slug.Eq(slug)).First()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ValidationError{Field: "parent_slug", Message: "category does not exist"}
+		}
+		return nil, err
+	}
+	return &category.ID, nil
+}
+
+func categorySlugByID(ctx context.Context, tx *query.Query, categoryID *int32) (string, error) {
+	if categoryID == nil {
+		return "", nil
+	}
+	category, err := tx.Category.WithContext(ctx).Where(tx.Category.ID.Eq(*categoryID)).First()
+	if err != nil {
+		return "", err
+	}
+	return category.Slug, nil
+}
+
+// parentsFirst orders categories so every parent precedes its children,
+// keeping the original order otherwise. Import relies on this.
+func parentsFirst(categories []*model.Category) []*model.Category {
+	byID := make(map[int32]*model.Category, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+
+	result := make([]*model.Category, 0, len(categories))
+	placed := make(map[int32]bool, len(categories))
+	var place func(c *model.Category)
+	place = func(c *model.Category) {
+		if placed[c.ID] {
+			return
+		}
+		placed[c.ID] = true
+		if c.ParentID != nil {
+			if parent, ok := byID[*c.ParentID]; ok {
+				place(parent)
+			}
+		}
+		result = append(result, c)
+	}
+	for _, c := range categories {
+		place(c)
+	}
+	return result
+}
+
+func rowNumber(row, index int) int {
+	if row > 0 {
+		return row
+	}
+	return index + 1
+}
+
+func importSummary(result *ImportResult) map[string]int {
+	summary := map[string]int{}
+	for _, change := range result.Changes {
+		summary[change.Kind+"."+change.Action]++
+	}
+	return summary
+}
+
+func (c *CatalogCategory) input(parentID *int32) CategoryInput {
+	return CategoryInput{
+		Slug:        c.Slug,
+		ParentID:    parentID,
+		Name:        c.Name,
+		Description: c.Description,
+		Icon:        c.Icon,
+		SortOrder:   c.SortOrder,
+		IsActive:    c.IsActive,
+		PublishAt:   c.PublishAt,
+		UnpublishAt: c.UnpublishAt,
+	}
+}
+
+func (t *CatalogTemplate) input(categoryID int32) TemplateInput {
+	return TemplateInput{
+		Slug:        t.Slug,
+		CategoryID:  categoryID,
+		Title:       t.Title,
+		Description: t.Description,
+		Tags:        t.Tags,
+		IsPro:       t.IsPro,
+		SortOrder:   t.SortOrder,
+		IsActive:    t.IsActive,
+		PublishAt:   t.PublishAt,
+		UnpublishAt: t.UnpublishAt,
+		Detail:      t.Detail,
+	}
+}
+
+// toCatalogCategory converts c; slugs maps category ids to slugs for the parent
+// reference and may be nil when the caller fills ParentSlug itself.
+func toCatalogCategory(c *model.Category, slugs map[int32]string) *CatalogCategory {
+	parentSlug := ""
+	if c.ParentID != nil {
+		parentSlug = slugs[*c.ParentID]
+	}
+	return &CatalogCategory{
+		Slug:        c.Slug,
+		ParentSlug:  parentSlug,
+		Name:        c.Name,
+		Description: c.Description,
+		Icon:        c.Icon,
+		SortOrder:   c.SortOrder,
+		IsActive:    c.IsActive != 0,
+		PublishAt:   c.PublishAt,
+		UnpublishAt: c.UnpublishAt,
+	}
+}
+
+func toCatalogTemplate(snapshot TemplateInput, categorySlug string) *CatalogTemplate {
+	return &CatalogTemplate{
+		Slug:         snapshot.Slug,
+		CategorySlug: categorySlug,
+		Title:        snapshot.Title,
+		Description:  snapshot.Description,
+		Tags:         snapshot.Tags,
+		IsPro:        snapshot.IsPro,
+		SortOrder:    snapshot.SortOrder,
+		IsActive:     snapshot.IsActive,
+		PublishAt:    snapshot.PublishAt,
+		UnpublishAt:  snapshot.UnpublishAt,
+		Detail:       snapshot.Detail,
+	}
+}
+
+// normalizeCatalogCategory and normalizeTemplateInput apply the same
+// trimming a save would, so diffs only show real changes.
+func normalizeCatalogCategory(input CategoryInput) *CatalogCategory {
+	return &CatalogCategory{
+		Slug:        input.Slug,
+		Name:        strings.TrimSpace(input.Name),
+		Description: strings.TrimSpace(input.Description),
+		Icon:        strings.TrimSpace(input.Icon),
+		SortOrder:   input.SortOrder,
+		IsActive:    input.IsActive,
+		PublishAt:   utcOrNil(input.PublishAt),
+		UnpublishAt: utcOrNil(input.UnpublishAt),
+	}
+}
+
+func normalizeTemplateInput(input TemplateInput) TemplateInput {
+	template := &model.Template{Slug: input.Slug}
+	applyTemplateInput(template, input, time.Time{})
+	detail := &model.TemplateDetail{}
+	applyTemplateDetailInput(detail, input.Detail, time.Time{})
+	return snapshotOf(template, detail)
+}
+
+func diffCatalogCategories(from, to *CatalogCategory) []FieldChange {
+	fromFields := catalogCategoryFields(from)
+	toFields := catalogCategoryFields(to)
+
+	changes := make([]FieldChange, 0)
+	for _, name := range catalogCategoryFieldOrder {
+		if fromFields[name] != toFields[name] {
+			changes = append(changes, FieldChange{Field: name, From: fromFields[name], To: toFields[name]})
+		}
+	}
+	return changes
+}
+
+var catalogCategoryFieldOrder = []string{
+	"parent_slug", "name", "description", "icon", "sort_order", "is_active", "publish_at", "unpublish_at",
+}
+
+func catalogCategoryFields(c *CatalogCategory) map[string]string {
+	return map[string]string{
+		"parent_slug":  c.ParentSlug,
+		"name":         c.Name,
+		"description":  c.Description,
+		"icon":         c.Icon,
+		"sort_order":   strconv.Itoa(int(c.SortOrder)),
+		"is_active":    strconv.FormatBool(c.IsActive),
+		"publish_at":   formatScheduleTime(c.PublishAt),
+		"unpublish_at": formatScheduleTime(c.UnpublishAt),
+	}
+}
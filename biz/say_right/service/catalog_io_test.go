@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestImportExistingCategoryWithTakenName(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	svc := NewCatalogService(NewAuditService())
+
+	doc := &CatalogDocument{Categories: []*CatalogCategory{
+		{Slug: "work", Name: "Work", IsActive: true},
+		{Slug: "family", Name: "Family", IsActive: true},
+	}}
+	if result, err := svc.Import(ctx, doc, false); err != nil || !result.Applied {
+		t.Fatalf("seed import: result %+v, err %v", result, err)
+	}
+
+	// Renaming "family" to the name "work" already uses must fail on the row
+	doc = &CatalogDocument{Categories: []*CatalogCategory{
+		{Slug: "family", Name: "Work", IsActive: true},
+	}}
+	result, err := svc.Import(ctx, doc, false)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result.Applied {
+		t.Error("import was applied despite a row error")
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Slug != "family" || result.Errors[0].Field != "name" {
+		t.Errorf("errors = %+v, want one name error for family", result.Errors)
+	}
+}
+
+func TestImportCategoryParentCycle(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	svc := NewCatalogService(NewAuditService())
+
+	doc := &CatalogDocument{Categories: []*CatalogCategory{
+		{Slug: "work", Name: "Work", IsActive: true},
+		{Slug: "meetings", ParentSlug: "work", Name: "Meetings", IsActive: true},
+	}}
+	if result, err := svc.Import(ctx, doc, false); err != nil || !result.Applied {
+		t.Fatalf("seed import: result %+v, err %v", result, err)
+	}
+
+	doc = &CatalogDocument{Categories: []*CatalogCategory{
+		{Slug: "work", ParentSlug: "meetings", Name: "Work", IsActive: true},
+	}}
+	result, err := svc.Import(ctx, doc, true)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Slug != "work" {
+		t.Errorf("errors = %+v, want one error for work", result.Errors)
+	}
+}
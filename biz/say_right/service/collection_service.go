@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+
+	"gorm.io/gen/field"
+	"gorm.io/gorm/clause"
+)
+
+var ErrCollectionNotFound = errors.New("collection not found")
+
+// CollectionInput creates or replaces a curated collection. TemplateIDs is the
+// full ordered list of templates in it.
+type CollectionInput struct {
+	// Slug is generated from the title when empty on create and kept as is when empty on update.
+	Slug        string  `json:"slug"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	SortOrder   int32   `json:"sort_order"`
+	IsActive    bool    `json:"is_active"`
+	TemplateIDs []int32 `json:"template_ids"`
+	// UpdatedAt must echo the value returned when the collection was loaded; ignored on create.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CollectionWithItems struct {
+	Collection  *model.Collection `json:"collection"`
+	TemplateIDs []int32           `json:"template_ids"`
+}
+
+func (s *contentService) ListCollections(ctx context.Context) ([]*CollectionWithItems, error) {
+	collections, err := s.q.Collection.WithContext(ctx).
+		Order(s.q.Collection.SortOrder, s.q.Collection.ID).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.q.CollectionItem.WithContext(ctx).
+		Order(s.q.CollectionItem.CollectionID, s.q.CollectionItem.SortOrder).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	templateIDs := make(map[int32][]int32)
+	for _, item := range items {
+		templateIDs[item.CollectionID] = append(templateIDs[item.CollectionID], item.TemplateID)
+	}
+	result := make([]*CollectionWithItems, 0, len(collections))
+	for _, c := range collections {
+		ids := templateIDs[c.ID]
+		if ids == nil {
+			ids = []int32{}
+		}
+		result = append(result, &CollectionWithItems{Collection: c, TemplateIDs: ids})
+	}
+	return result, nil
+}
+
+func (s *contentService) CreateCollection(ctx context.Context, input CollectionInput) (*CollectionWithItems, error) {
+	if err := validateCollection(input); err != nil {
+		return nil, err
+	}
+	if input.TemplateIDs == nil {
+		input.TemplateIDs = []int32{}
+	}
+
+	var result *CollectionWithItems
+	err := s.write(ctx, func(tx *query.Query) error {
+		now := editTimestamp()
+		collection := &model.Collection{
+			Title:       strings.TrimSpace(input.Title),
+			Description: strings.TrimSpace(input.Description),
+			SortOrder:   input.SortOrder,
+			IsActive:    boolToInt(input.IsActive),
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		slug, err := collectionSlug(ctx, tx, input.Slug, collection.Title, 0)
+		if err != nil {
+			return err
+		}
+		collection.Slug = slug
+
+		if err := createCollectionRow(ctx, tx, collection); err != nil {
+			return err
+		}
+		if err := setCollectionItems(ctx, tx, collection.ID, input.TemplateIDs); err != nil {
+			return err
+		}
+
+		result = &CollectionWithItems{Collection: collection, TemplateIDs: input.TemplateIDs}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.collection.create",
+			TargetType: "collection",
+			TargetID:   strconv.Itoa(int(collection.ID)),
+			After:      result,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *contentService) UpdateCollection(ctx context.Context, collectionID int32, input CollectionInput) (*CollectionWithItems, error) {
+	if err := validateCollection(input); err != nil {
+		return nil, err
+	}
+	if input.TemplateIDs == nil {
+		input.TemplateIDs = []int32{}
+	}
+
+	var result *CollectionWithItems
+	err := s.write(ctx, func(tx *query.Query) error {
+		current, err := tx.Collection.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(tx.Collection.ID.Eq(collectionID)).
+			First()
+		if err != nil {
+			return ErrCollectionNotFound
+		}
+		if !sameEditTimestamp(current.UpdatedAt, input.UpdatedAt) {
+			return ErrConflict
+		}
+		currentItems, err := tx.CollectionItem.WithContext(ctx).
+			Where(tx.CollectionItem.CollectionID.Eq(collectionID)).
+			Order(tx.CollectionItem.SortOrder).
+			Find()
+		if err != nil {
+			return err
+		}
+		before := &CollectionWithItems{Collection: current, TemplateIDs: make([]int32, 0, len(currentItems))}
+		for _, item := range currentItems {
+			before.TemplateIDs = append(before.TemplateIDs, item.TemplateID)
+		}
+
+		next := *current
+		if input.Slug != "" {
+			if next.Slug, err = collectionSlug(ctx, tx, input.Slug, input.Title, collectionID); err != nil {
+				return err
+			}
+		}
+		next.Title = strings.TrimSpace(input.Title)
+		next.Description = strings.TrimSpace(input.Description)
+		next.SortOrder = input.SortOrder
+		next.IsActive = boolToInt(input.IsActive)
+		next.UpdatedAt = editTimestamp()
+		if err := updateCollectionRow(ctx, tx, &next); err != nil {
+			return err
+		}
+		if err := setCollectionItems(ctx, tx, collectionID, input.TemplateIDs); err != nil {
+			return err
+		}
+
+		result = &CollectionWithItems{Collection: &next, TemplateIDs: input.TemplateIDs}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.collection.update",
+			TargetType: "collection",
+			TargetID:   strconv.Itoa(int(collectionID)),
+			Before:     before,
+			After:      result,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// setCollectionItems replaces the collection's templates, keeping the given order.
+// createCollectionRow is createTemplateRow for collections.
+func createCollectionRow(ctx context.Context, tx *query.Query, c *model.Collection) error {
+	isActive := c.IsActive
+	if err := tx.Collection.WithContext(ctx).Create(c); err != nil {
+		return err
+	}
+	if isActive == 0 {
+		c.IsActive = 0
+		return updateCollectionRow(ctx, tx, c)
+	}
+	return nil
+}
+
+// updateCollectionRow is updateTemplateRow for collections.
+func updateCollectionRow(ctx context.Context, tx *query.Query, c *model.Collection) error {
+	_, err := tx.Collection.WithContext(ctx).Where(tx.Collection.ID.Eq(c.ID)).Select(field.Star).Updates(c)
+	return err
+}
+
+func setCollectionItems(ctx context.Context, tx *query.Query, collectionID int32, templateIDs []int32) error {
+	if len(templateIDs) > 0 {
+		count, err := tx.Template.WithContext(ctx).Where(tx.Template.ID.In(templateIDs...)).Count()
+		if err != nil {
+			return err
+		}
+		if int(count) != len(templateIDs) {
+			return ValidationError{Field: "template_ids", Message: "contains unknown or duplicate templates"}
+		}
+	}
+
+	if _, err := tx.CollectionItem.WithContext(ctx).Where(tx.CollectionItem.CollectionID.Eq(collectionID)).Delete(); err != nil {
+		return err
+	}
+	for i, templateID := range templateIDs {
+		err := tx.CollectionItem.WithContext(ctx).Create(&model.CollectionItem{
+			CollectionID: collectionID,
+			TemplateID:   templateID,
+			SortOrder:    int32(i),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func collectionSlug(ctx context.Context, tx *query.Query, slug, title string, exceptID int32) (string, error) {
+	return resolveSlug(slug, title, "collection", func(candidate string) (int64, error) {
+		return tx.Collection.WithContext(ctx).
+			Where(tx.Collection.Slug.Eq(candidate), tx.Collection.ID.Neq(exceptID)).
+			Count()
+	})
+}
+
+func validateCollection(input CollectionInput) error {
+	checks := []error{
+		requireText("title", input.Title, 128),
+		limitText("description", input.Description, 512),
+		validateSlug(input.Slug),
+	}
+	for _, err := range checks {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
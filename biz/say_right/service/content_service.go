@@ -0,0 +1,760 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+
+	"gorm.io/gen/field"
+	"gorm.io/gorm/clause"
+)
+
+var ErrCategoryNotFound = errors.New("category not found")
+
+// categoryMaxDepth caps how many ancestors a category may have.
+const categoryMaxDepth = 16
+
+// ErrConflict is returned when the record changed since the editor loaded it.
+var ErrConflict = errors.New("record was modified by someone else")
+
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+type CategoryInput struct {
+	// Slug is generated from the name when empty on create and kept as is when empty on update.
+	Slug string `json:"slug"`
+	// ParentID nests the category under another one; nil makes it top level.
+	ParentID    *int32 `json:"parent_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	SortOrder   int32  `json:"sort_order"`
+	IsActive    bool   `json:"is_active"`
+	// PublishAt and UnpublishAt optionally limit when the category is visible; nil means no limit.
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	// UpdatedAt must echo the value returned when the category was loaded; ignored on create.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TemplateDetailInput struct {
+	Headline      string   `json:"headline"`
+	Summary       string   `json:"summary"`
+	ReplySoft     string   `json:"reply_soft"`
+	ReplyNeutral  string   `json:"reply_neutral"`
+	ReplyFirm     string   `json:"reply_firm"`
+	WhenNotToUse  string   `json:"when_not_to_use"`
+	BestPractices []string `json:"best_practices"`
+	// Variables declares the {{name}} placeholders the replies may use.
+	Variables []TemplateVariable `json:"variables"`
+	// Prompt instructs the model when users generate replies from this
+	// template; empty uses the default prompt.
+	Prompt string `json:"prompt"`
+}
+
+type TemplateInput struct {
+	// Slug is generated from the title when empty on create and kept as is when empty on update.
+	Slug        string              `json:"slug"`
+	CategoryID  int32               `json:"category_id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Tags        []string            `json:"tags"`
+	IsPro       bool                `json:"is_pro"`
+	SortOrder   int32               `json:"sort_order"`
+	IsActive    bool                `json:"is_active"`
+	PublishAt   *time.Time          `json:"publish_at"`
+	UnpublishAt *time.Time          `json:"unpublish_at"`
+	Detail      TemplateDetailInput `json:"detail"`
+	// UpdatedAt must echo the template's value returned when it was loaded; ignored on create.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TemplateFlagsInput toggles publishing flags without sending the whole template.
+type TemplateFlagsInput struct {
+	IsActive  *bool     `json:"is_active"`
+	IsPro     *bool     `json:"is_pro"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SortItem struct {
+	ID        int32 `json:"id"`
+	SortOrder int32 `json:"sort_order"`
+}
+
+type TemplateWithDetail struct {
+	Template *model.Template       `json:"template"`
+	Detail   *model.TemplateDetail `json:"detail"`
+}
+
+type ContentService interface {
+	ListCategories(ctx context.Context) ([]*model.Category, error)
+	CreateCategory(ctx context.Context, input CategoryInput) (*model.Category, error)
+	UpdateCategory(ctx context.Context, categoryID int32, input CategoryInput) (*model.Category, error)
+	ReorderCategories(ctx context.Context, items []SortItem) error
+
+	ListCollections(ctx context.Context) ([]*CollectionWithItems, error)
+	CreateCollection(ctx context.Context, input CollectionInput) (*CollectionWithItems, error)
+	UpdateCollection(ctx context.Context, collectionID int32, input CollectionInput) (*CollectionWithItems, error)
+
+	ListTemplates(ctx context.Context, categoryID int32) ([]*model.Template, error)
+	GetTemplate(ctx context.Context, templateID int32) (*TemplateWithDetail, error)
+	CreateTemplate(ctx context.Context, input TemplateInput) (*TemplateWithDetail, error)
+	UpdateTemplate(ctx context.Context, templateID int32, input TemplateInput) (*TemplateWithDetail, error)
+	SetTemplateFlags(ctx context.Context, templateID int32, input TemplateFlagsInput) (*model.Template, error)
+	ReorderTemplates(ctx context.Context, items []SortItem) error
+
+	ListRevisions(ctx context.Context, templateID int32) ([]*TemplateRevisionItem, error)
+	DiffRevisions(ctx context.Context, templateID, fromID, toID int32) ([]FieldChange, error)
+	RestoreRevision(ctx context.Context, templateID, revisionID int32, expectedUpdatedAt time.Time) (*TemplateWithDetail, error)
+	SaveDraft(ctx context.Context, templateID int32, input TemplateInput) (*TemplateRevisionItem, error)
+	PreviewDraft(ctx context.Context, templateID int32) (*TemplateDetailResult, error)
+	PublishDraft(ctx context.Context, templateID int32, expectedUpdatedAt time.Time) (*TemplateWithDetail, error)
+}
+
+type contentService struct {
+	q     *query.Query
+	audit AuditService
+}
+
+func NewContentService(audit AuditService) ContentService {
+	return &contentService{
+		q:     query.Q,
+		audit: audit,
+	}
+}
+
+// write runs fn in a transaction and drops the catalog cache once it commits.
+func (s *contentService) write(ctx context.Context, fn func(tx *query.Query) error) error {
+	if err := s.q.Transaction(fn); err != nil {
+		return err
+	}
+	InvalidateCatalogCache(ctx)
+	return nil
+}
+
+func (s *contentService) ListCategories(ctx context.Context) ([]*model.Category, error) {
+	return s.q.Category.WithContext(ctx).
+		Order(s.q.Category.SortOrder, s.q.Category.ID).
+		Find()
+}
+
+func (s *contentService) CreateCategory(ctx context.Context, input CategoryInput) (*model.Category, error) {
+	if err := validateCategory(input); err != nil {
+		return nil, err
+	}
+
+	var category *model.Category
+	err := s.write(ctx, func(tx *query.Query) error {
+		var err error
+		category, err = s.createCategory(ctx, tx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *contentService) UpdateCategory(ctx context.Context, categoryID int32, input CategoryInput) (*model.Category, error) {
+	if err := validateCategory(input); err != nil {
+		return nil, err
+	}
+
+	var category *model.Category
+	err := s.write(ctx, func(tx *query.Query) error {
+		var err error
+		category, err = s.updateCategory(ctx, tx, categoryID, input, input.UpdatedAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *contentService) createCategory(ctx context.Context, tx *query.Query, input CategoryInput) (*model.Category, error) {
+	now := editTimestamp()
+	category := &model.Category{
+		Name:        strings.TrimSpace(input.Name),
+		Description: strings.TrimSpace(input.Description),
+		Icon:        strings.TrimSpace(input.Icon),
+		SortOrder:   input.SortOrder,
+		IsActive:    boolToInt(input.IsActive),
+		PublishAt:   utcOrNil(input.PublishAt),
+		UnpublishAt: utcOrNil(input.UnpublishAt),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.ensureCategoryNameFree(ctx, tx, category.Name, 0); err != nil {
+		return nil, err
+	}
+	slug, err := categorySlug(ctx, tx, input.Slug, category.Name, 0)
+	if err != nil {
+		return nil, err
+	}
+	category.Slug = slug
+	if category.ParentID, err = categoryParent(ctx, tx, 0, input.ParentID); err != nil {
+		return nil, err
+	}
+
+	if err := createCategoryRow(ctx, tx, category); err != nil {
+		return nil, err
+	}
+	err = s.audit.Record(ctx, tx, AuditEntry{
+		Action:     "content.category.create",
+		TargetType: "category",
+		TargetID:   strconv.Itoa(int(category.ID)),
+		After:      category,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *contentService) updateCategory(ctx context.Context, tx *query.Query, categoryID int32, input CategoryInput, expectedUpdatedAt time.Time) (*model.Category, error) {
+	current, err := tx.Category.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(tx.Category.ID.Eq(categoryID)).
+		First()
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	if !sameEditTimestamp(current.UpdatedAt, expectedUpdatedAt) {
+		return nil, ErrConflict
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if err := s.ensureCategoryNameFree(ctx, tx, name, categoryID); err != nil {
+		return nil, err
+	}
+
+	next := *current
+	if input.Slug != "" {
+		if next.Slug, err = categorySlug(ctx, tx, input.Slug, name, categoryID); err != nil {
+			return nil, err
+		}
+	}
+	if next.ParentID, err = categoryParent(ctx, tx, categoryID, input.ParentID); err != nil {
+		return nil, err
+	}
+	next.Name = name
+	next.Description = strings.TrimSpace(input.Description)
+	next.Icon = strings.TrimSpace(input.Icon)
+	next.SortOrder = input.SortOrder
+	next.IsActive = boolToInt(input.IsActive)
+	next.PublishAt = utcOrNil(input.PublishAt)
+	next.UnpublishAt = utcOrNil(input.UnpublishAt)
+	next.UpdatedAt = editTimestamp()
+	if err := updateCategoryRow(ctx, tx, &next); err != nil {
+		return nil, err
+	}
+
+	err = s.audit.Record(ctx, tx, AuditEntry{
+		Action:     "content.category.update",
+		TargetType: "category",
+		TargetID:   strconv.Itoa(int(categoryID)),
+		Before:     current,
+		After:      &next,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+func (s *contentService) ReorderCategories(ctx context.Context, items []SortItem) error {
+	return s.write(ctx, func(tx *query.Query) error {
+		for _, item := range items {
+			info, err := tx.Category.WithContext(ctx).
+				Where(tx.Category.ID.Eq(item.ID)).
+				UpdateSimple(tx.Category.SortOrder.Value(item.SortOrder), tx.Category.UpdatedAt.Value(editTimestamp()))
+			if err != nil {
+				return err
+			}
+			if info.RowsAffected == 0 {
+				return ErrCategoryNotFound
+			}
+		}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.category.reorder",
+			TargetType: "category",
+			Detail:     items,
+		})
+	})
+}
+
+// ListTemplates returns every template including inactive ones; categoryID 0 lists all categories.
+func (s *contentService) ListTemplates(ctx context.Context, categoryID int32) ([]*model.Template, error) {
+	do := s.q.Template.WithContext(ctx)
+	if categoryID > 0 {
+		do = do.Where(s.q.Template.CategoryID.Eq(categoryID))
+	}
+	return do.Order(s.q.Template.CategoryID, s.q.Template.SortOrder, s.q.Template.ID).Find()
+}
+
+func (s *contentService) GetTemplate(ctx context.Context, templateID int32) (*TemplateWithDetail, error) {
+	template, err := s.q.Template.WithContext(ctx).Where(s.q.Template.ID.Eq(templateID)).First()
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+	detail, err := s.q.TemplateDetail.WithContext(ctx).Where(s.q.TemplateDetail.TemplateID.Eq(templateID)).First()
+	if err != nil {
+		// A template created by hand may still lack its detail row
+		detail = &model.TemplateDetail{TemplateID: templateID}
+	}
+	return &TemplateWithDetail{Template: template, Detail: detail}, nil
+}
+
+func (s *contentService) CreateTemplate(ctx context.Context, input TemplateInput) (*TemplateWithDetail, error) {
+	if err := validateTemplate(input); err != nil {
+		return nil, err
+	}
+
+	var result *TemplateWithDetail
+	err := s.write(ctx, func(tx *query.Query) error {
+		var err error
+		result, err = s.createTemplate(ctx, tx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *contentService) createTemplate(ctx context.Context, tx *query.Query, input TemplateInput) (*TemplateWithDetail, error) {
+	now := editTimestamp()
+	template := &model.Template{CreatedAt: now}
+	applyTemplateInput(template, input, now)
+	detail := &model.TemplateDetail{CreatedAt: now}
+	applyTemplateDetailInput(detail, input.Detail, now)
+
+	if err := ensureCategoryExists(ctx, tx, input.CategoryID); err != nil {
+		return nil, err
+	}
+	slug, err := templateSlug(ctx, tx, input.Slug, template.Title, 0)
+	if err != nil {
+		return nil, err
+	}
+	template.Slug = slug
+
+	if err := createTemplateRow(ctx, tx, template); err != nil {
+		return nil, err
+	}
+	if err := moveTemplateSlug(ctx, tx, template.ID, "", template.Slug); err != nil {
+		return nil, err
+	}
+	if err := syncTemplateTags(ctx, tx, template.ID, splitTags(template.TagsText)); err != nil {
+		return nil, err
+	}
+	detail.TemplateID = template.ID
+	if err := tx.TemplateDetail.WithContext(ctx).Create(detail); err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(ctx, tx, template.ID, RevisionPublished, snapshotOf(template, detail), "created"); err != nil {
+		return nil, err
+	}
+
+	result := &TemplateWithDetail{Template: template, Detail: detail}
+	err = s.audit.Record(ctx, tx, AuditEntry{
+		Action:     "content.template.create",
+		TargetType: "template",
+		TargetID:   strconv.Itoa(int(template.ID)),
+		After:      result,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *contentService) UpdateTemplate(ctx context.Context, templateID int32, input TemplateInput) (*TemplateWithDetail, error) {
+	if err := validateTemplate(input); err != nil {
+		return nil, err
+	}
+
+	var result *TemplateWithDetail
+	err := s.write(ctx, func(tx *query.Query) error {
+		var err error
+		result, err = s.applyTemplate(ctx, tx, templateID, input, input.UpdatedAt, "content.template.update", "")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyTemplate overwrites the live template and detail with input, records a
+// published revision and the audit entry. It must run inside a transaction.
+func (s *contentService) applyTemplate(ctx context.Context, tx *query.Query, templateID int32, input TemplateInput, expectedUpdatedAt time.Time, action, note string) (*TemplateWithDetail, error) {
+	current, err := lockTemplate(ctx, tx, templateID, expectedUpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureCategoryExists(ctx, tx, input.CategoryID); err != nil {
+		return nil, err
+	}
+
+	currentDetail, err := tx.TemplateDetail.WithContext(ctx).Where(tx.TemplateDetail.TemplateID.Eq(templateID)).First()
+	if err != nil {
+		currentDetail = &model.TemplateDetail{TemplateID: templateID}
+	}
+
+	now := editTimestamp()
+	nextTemplate := *current
+	applyTemplateInput(&nextTemplate, input, now)
+	// Any content write supersedes a pending draft, including publishing it
+	nextTemplate.DraftRevisionID = nil
+	if input.Slug != "" {
+		if nextTemplate.Slug, err = templateSlug(ctx, tx, input.Slug, nextTemplate.Title, templateID); err != nil {
+			return nil, err
+		}
+	}
+	nextDetail := *currentDetail
+	if nextDetail.ID == 0 {
+		nextDetail.CreatedAt = now
+	}
+	applyTemplateDetailInput(&nextDetail, input.Detail, now)
+
+	if err := updateTemplateRow(ctx, tx, &nextTemplate); err != nil {
+		return nil, err
+	}
+	if err := moveTemplateSlug(ctx, tx, templateID, current.Slug, nextTemplate.Slug); err != nil {
+		return nil, err
+	}
+	if err := syncTemplateTags(ctx, tx, templateID, splitTags(nextTemplate.TagsText)); err != nil {
+		return nil, err
+	}
+	if err := tx.TemplateDetail.WithContext(ctx).Save(&nextDetail); err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(ctx, tx, templateID, RevisionPublished, snapshotOf(&nextTemplate, &nextDetail), note); err != nil {
+		return nil, err
+	}
+
+	result := &TemplateWithDetail{Template: &nextTemplate, Detail: &nextDetail}
+	err = s.audit.Record(ctx, tx, AuditEntry{
+		Action:     action,
+		TargetType: "template",
+		TargetID:   strconv.Itoa(int(templateID)),
+		Before:     TemplateWithDetail{Template: current, Detail: currentDetail},
+		After:      result,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *contentService) SetTemplateFlags(ctx context.Context, templateID int32, input TemplateFlagsInput) (*model.Template, error) {
+	if input.IsActive == nil && input.IsPro == nil {
+		return nil, ValidationError{Field: "is_active", Message: "nothing to change"}
+	}
+
+	var updated *model.Template
+	err := s.write(ctx, func(tx *query.Query) error {
+		current, err := lockTemplate(ctx, tx, templateID, input.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		next := *current
+		if input.IsActive != nil {
+			next.IsActive = boolToInt(*input.IsActive)
+		}
+		if input.IsPro != nil {
+			next.IsPro = boolToInt(*input.IsPro)
+		}
+		next.UpdatedAt = editTimestamp()
+		if err := updateTemplateRow(ctx, tx, &next); err != nil {
+			return err
+		}
+		updated = &next
+
+		if err := s.recordLiveRevision(ctx, tx, templateID, "flags changed"); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.template.flags",
+			TargetType: "template",
+			TargetID:   strconv.Itoa(int(templateID)),
+			Before:     current,
+			After:      &next,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *contentService) ReorderTemplates(ctx context.Context, items []SortItem) error {
+	return s.write(ctx, func(tx *query.Query) error {
+		for _, item := range items {
+			info, err := tx.Template.WithContext(ctx).
+				Where(tx.Template.ID.Eq(item.ID)).
+				UpdateSimple(tx.Template.SortOrder.Value(item.SortOrder), tx.Template.UpdatedAt.Value(editTimestamp()))
+			if err != nil {
+				return err
+			}
+			if info.RowsAffected == 0 {
+				return ErrTemplateNotFound
+			}
+			if err := s.recordLiveRevision(ctx, tx, item.ID, "reordered"); err != nil {
+				return err
+			}
+		}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.template.reorder",
+			TargetType: "template",
+			Detail:     items,
+		})
+	})
+}
+
+func (s *contentService) ensureCategoryNameFree(ctx context.Context, tx *query.Query, name string, exceptID int32) error {
+	count, err := tx.Category.WithContext(ctx).
+		Where(tx.Category.Name.Eq(name), tx.Category.ID.Neq(exceptID)).
+		Count()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ValidationError{Field: "name", Message: "a category with this name already exists"}
+	}
+	return nil
+}
+
+// categoryParent checks parentID exists and would not make categoryID its own
+// ancestor. A nil or zero parentID means top level.
+func categoryParent(ctx context.Context, tx *query.Query, categoryID int32, parentID *int32) (*int32, error) {
+	if parentID == nil || *parentID == 0 {
+		return nil, nil
+	}
+
+	id := *parentID
+	for seen := 0; ; seen++ {
+		if id == categoryID {
+			return nil, ValidationError{Field: "parent_id", Message: "would create a cycle"}
+		}
+		if seen >= categoryMaxDepth {
+			return nil, ValidationError{Field: "parent_id", Message: "is nested too deeply"}
+		}
+		parent, err := tx.Category.WithContext(ctx).Where(tx.Category.ID.Eq(id)).First()
+		if err != nil {
+			if isNotFound(err) {
+				return nil, ValidationError{Field: "parent_id", Message: "category does not exist"}
+			}
+			return nil, err
+		}
+		if parent.ParentID == nil {
+			break
+		}
+		id = *parent.ParentID
+	}
+	result := *parentID
+	return &result, nil
+}
+
+func ensureCategoryExists(ctx context.Context, tx *query.Query, categoryID int32) error {
+	count, err := tx.Category.WithContext(ctx).Where(tx.Category.ID.Eq(categoryID)).Count()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ValidationError{Field: "category_id", Message: "category does not exist"}
+	}
+	return nil
+}
+
+// lockTemplate loads the template for update and checks the editor's copy is still current.
+func lockTemplate(ctx context.Context, tx *query.Query, templateID int32, expectedUpdatedAt time.Time) (*model.Template, error) {
+	current, err := tx.Template.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(tx.Template.ID.Eq(templateID)).
+		First()
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+	if !sameEditTimestamp(current.UpdatedAt, expectedUpdatedAt) {
+		return nil, ErrConflict
+	}
+	return current, nil
+}
+
+func applyTemplateInput(t *model.Template, input TemplateInput, now time.Time) {
+	t.CategoryID = input.CategoryID
+	t.Title = strings.TrimSpace(input.Title)
+	t.Description = strings.TrimSpace(input.Description)
+	t.TagsText = joinTags(input.Tags)
+	t.IsPro = boolToInt(input.IsPro)
+	t.SortOrder = input.SortOrder
+	t.IsActive = boolToInt(input.IsActive)
+	t.PublishAt = utcOrNil(input.PublishAt)
+	t.UnpublishAt = utcOrNil(input.UnpublishAt)
+	t.UpdatedAt = now
+}
+
+func applyTemplateDetailInput(d *model.TemplateDetail, input TemplateDetailInput, now time.Time) {
+	d.Headline = strings.TrimSpace(input.Headline)
+	d.Summary = strings.TrimSpace(input.Summary)
+	d.ReplySoft = strings.TrimSpace(input.ReplySoft)
+	d.ReplyNeutral = strings.TrimSpace(input.ReplyNeutral)
+	d.ReplyFirm = strings.TrimSpace(input.ReplyFirm)
+	d.WhenNotToUse = strings.TrimSpace(input.WhenNotToUse)
+	d.BestPractices = strings.Join(splitLines(strings.Join(input.BestPractices, "\n")), "\n")
+	d.Variables = encodeVariables(normalizeVariables(input.Variables))
+	d.Prompt = strings.TrimSpace(input.Prompt)
+	d.UpdatedAt = now
+}
+
+func validateCategory(input CategoryInput) error {
+	if err := requireText("name", input.Name, 64); err != nil {
+		return err
+	}
+	if err := limitText("description", input.Description, 255); err != nil {
+		return err
+	}
+	if err := limitText("icon", input.Icon, 255); err != nil {
+		return err
+	}
+	if err := validateSlug(input.Slug); err != nil {
+		return err
+	}
+	return validateSchedule(input.PublishAt, input.UnpublishAt)
+}
+
+func validateTemplate(input TemplateInput) error {
+	if input.CategoryID <= 0 {
+		return ValidationError{Field: "category_id", Message: "is required"}
+	}
+	checks := []error{
+		requireText("title", input.Title, 128),
+		requireText("description", input.Description, 512),
+		limitText("tags", joinTags(input.Tags), 512),
+		requireText("detail.headline", input.Detail.Headline, 128),
+		requireText("detail.summary", input.Detail.Summary, 512),
+		requireText("detail.reply_soft", input.Detail.ReplySoft, 0),
+		requireText("detail.reply_neutral", input.Detail.ReplyNeutral, 0),
+		requireText("detail.reply_firm", input.Detail.ReplyFirm, 0),
+		validateTemplateVariables(input.Detail),
+		validatePrompt(input.Detail.Prompt),
+		validateSlug(input.Slug),
+		validateSchedule(input.PublishAt, input.UnpublishAt),
+	}
+	for _, err := range checks {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requireText checks value is not blank and at most max characters (0 means unlimited).
+func requireText(field, value string, max int) error {
+	if strings.TrimSpace(value) == "" {
+		return ValidationError{Field: field, Message: "is required"}
+	}
+	return limitText(field, value, max)
+}
+
+func limitText(field, value string, max int) error {
+	if max > 0 && utf8.RuneCountInString(strings.TrimSpace(value)) > max {
+		return ValidationError{Field: field, Message: fmt.Sprintf("must be at most %d characters", max)}
+	}
+	return nil
+}
+
+func validateSchedule(publishAt, unpublishAt *time.Time) error {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return ValidationError{Field: "unpublish_at", Message: "must be after publish_at"}
+	}
+	return nil
+}
+
+func utcOrNil(value *time.Time) *time.Time {
+	if value == nil || value.IsZero() {
+		return nil
+	}
+	v := value.UTC().Truncate(time.Second)
+	return &v
+}
+
+func joinTags(tags []string) string {
+	return strings.Join(splitTags(strings.Join(tags, ",")), ",")
+}
+
+// createTemplateRow inserts t as given. Create, like Save, lets the
+// is_active column default replace a zero value, so an inactive template is
+// switched off again right after the insert.
+func createTemplateRow(ctx context.Context, tx *query.Query, t *model.Template) error {
+	isActive := t.IsActive
+	if err := tx.Template.WithContext(ctx).Create(t); err != nil {
+		return err
+	}
+	if isActive == 0 {
+		t.IsActive = 0
+		return updateTemplateRow(ctx, tx, t)
+	}
+	return nil
+}
+
+// updateTemplateRow writes every column of an existing template. Save is an
+// upsert that lets the is_active column default replace a zero value, so a
+// template could never be switched off through it.
+func updateTemplateRow(ctx context.Context, tx *query.Query, t *model.Template) error {
+	_, err := tx.Template.WithContext(ctx).Where(tx.Template.ID.Eq(t.ID)).Select(field.Star).Updates(t)
+	return err
+}
+
+// createCategoryRow is createTemplateRow for categories.
+func createCategoryRow(ctx context.Context, tx *query.Query, c *model.Category) error {
+	isActive := c.IsActive
+	if err := tx.Category.WithContext(ctx).Create(c); err != nil {
+		return err
+	}
+	if isActive == 0 {
+		c.IsActive = 0
+		return updateCategoryRow(ctx, tx, c)
+	}
+	return nil
+}
+
+// updateCategoryRow is updateTemplateRow for categories.
+func updateCategoryRow(ctx context.Context, tx *query.Query, c *model.Category) error {
+	_, err := tx.Category.WithContext(ctx).Where(tx.Category.ID.Eq(c.ID)).Select(field.Star).Updates(c)
+	return err
+}
+
+func boolToInt(v bool) int32 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// editTimestamp is truncated to the precision of the TIMESTAMP columns so the
+// value handed back to editors compares equal to what the database stores.
+func editTimestamp() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func sameEditTimestamp(stored, expected time.Time) bool {
+	return stored.UTC().Truncate(time.Second).Equal(expected.UTC().Truncate(time.Second))
+}
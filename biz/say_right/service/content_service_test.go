@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"api/biz/say_right/dal/query"
+)
+
+func TestContentCanBeSwitchedOff(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	svc := NewContentService(NewAuditService())
+
+	category, err := svc.CreateCategory(ctx, CategoryInput{Name: "Work", IsActive: true})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	created, err := svc.CreateTemplate(ctx, TemplateInput{
+		CategoryID:  category.ID,
+		Title:       "Decline a meeting",
+		Description: "Say no politely",
+		IsActive:    true,
+		Detail: TemplateDetailInput{
+			Headline:     "Decline",
+			Summary:      "A polite no",
+			ReplySoft:    "soft",
+			ReplyNeutral: "neutral",
+			ReplyFirm:    "firm",
+		},
+	})
+	if err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+
+	off := false
+	flagged, err := svc.SetTemplateFlags(ctx, created.Template.ID, TemplateFlagsInput{IsActive: &off, UpdatedAt: created.Template.UpdatedAt})
+	if err != nil {
+		t.Fatalf("set flags: %v", err)
+	}
+	stored, err := svc.GetTemplate(ctx, created.Template.ID)
+	if err != nil {
+		t.Fatalf("get template: %v", err)
+	}
+	if flagged.IsActive != 0 || stored.Template.IsActive != 0 {
+		t.Errorf("is_active = %d, stored %d; want the template switched off", flagged.IsActive, stored.Template.IsActive)
+	}
+
+	updated, err := svc.UpdateCategory(ctx, category.ID, CategoryInput{Name: "Work", IsActive: false, UpdatedAt: category.UpdatedAt})
+	if err != nil {
+		t.Fatalf("update category: %v", err)
+	}
+	if updated.IsActive != 0 {
+		t.Errorf("category is_active = %d, want 0", updated.IsActive)
+	}
+
+	hidden, err := svc.CreateCategory(ctx, CategoryInput{Name: "Drafts", IsActive: false})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	c := query.Q.Category
+	if row, err := c.WithContext(ctx).Where(c.ID.Eq(hidden.ID)).First(); err != nil || row.IsActive != 0 {
+		t.Errorf("new inactive category stored as active: %+v, %v", row, err)
+	}
+	draft, err := svc.CreateTemplate(ctx, TemplateInput{
+		CategoryID:  category.ID,
+		Title:       "Ask for a raise",
+		Description: "Make the case",
+		Detail: TemplateDetailInput{
+			Headline:     "Ask",
+			Summary:      "A clear ask",
+			ReplySoft:    "soft",
+			ReplyNeutral: "neutral",
+			ReplyFirm:    "firm",
+		},
+	})
+	if err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+	if stored, err = svc.GetTemplate(ctx, draft.Template.ID); err != nil || stored.Template.IsActive != 0 {
+		t.Errorf("new inactive template stored as active: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"strings"
+
+	"api/infra/llm"
+)
+
+const (
+	ToneSoft    = "soft"
+	ToneNeutral = "neutral"
+	ToneFirm    = "firm"
+)
+
+// Tones lists the reply tones in the order templates show them.
+var Tones = []string{ToneSoft, ToneNeutral, ToneFirm}
+
+const (
+	promptMaxLength    = 4000
+	situationMaxLength = 2000
+	generationMaxToken = 600
+	generationTemp     = 0.7
+)
+
+const generationSystemPrompt = `You help people reply to difficult workplace messages.
+Write only the reply itself: no greeting to the assistant, no explanation, no alternatives.
+Keep the reply about as long as the example. If the example contains {{placeholders}} that the situation does not fill in, keep them as written.`
+
+// defaultGenerationPrompt is used for templates without a prompt of their own.
+const defaultGenerationPrompt = `Situation: {{situation}}
+
+Write a {{tone}} reply to this situation in {{language}}, following the "{{title}}" template.
+{{summary}}
+
+Example reply:
+{{example}}
+
+Do not use this approach when: {{when_not_to_use}}
+
+Best practices:
+{{best_practices}}`
+
+// promptPlaceholders are the values a prompt template can refer to.
+var promptPlaceholders = map[string]bool{
+	"situation":       true,
+	"tone":            true,
+	"language":        true,
+	"title":           true,
+	"summary":         true,
+	"example":         true,
+	"when_not_to_use": true,
+	"best_practices":  true,
+}
+
+var toneDescriptions = map[string]string{
+	ToneSoft:    "soft, warm and accommodating",
+	ToneNeutral: "neutral, clear and matter-of-fact",
+	ToneFirm:    "firm and direct while staying professional",
+}
+
+var localeLanguages = map[string]string{
+	"en":    "English",
+	"zh-CN": "Simplified Chinese",
+	"zh-TW": "Traditional Chinese",
+}
+
+// validatePrompt checks an editor's prompt template. It must include the
+// user's situation, or every user would get the same answer.
+func validatePrompt(prompt string) error {
+	prompt = strings.TrimSpace(prompt)
+	if prompt == "" {
+		return nil
+	}
+	if err := limitText("detail.prompt", prompt, promptMaxLength); err != nil {
+		return err
+	}
+	if err := checkPlaceholders("detail.prompt", prompt, promptPlaceholders); err != nil {
+		return err
+	}
+	for _, m := range placeholderPattern.FindAllStringSubmatch(prompt, -1) {
+		if m[1] == "situation" {
+			return nil
+		}
+	}
+	return ValidationError{Field: "detail.prompt", Message: "must include {{situation}}"}
+}
+
+func normalizeTone(tone string) (string, error) {
+	tone = strings.ToLower(strings.TrimSpace(tone))
+	if _, ok := toneDescriptions[tone]; !ok {
+		return "", ValidationError{Field: "tone", Message: "must be soft, neutral or firm"}
+	}
+	return tone, nil
+}
+
+func exampleReply(detail *TemplateDetailResult, tone string) string {
+	switch tone {
+	case ToneSoft:
+		return detail.ReplySoft
+	case ToneFirm:
+		return detail.ReplyFirm
+	}
+	return detail.ReplyNeutral
+}
+
+// generationRequest fills the template's prompt for one tone.
+func generationRequest(prompt string, detail *TemplateDetailResult, situation, tone string) llm.Request {
+	if prompt == "" {
+		prompt = defaultGenerationPrompt
+	}
+	values := map[string]string{
+		"situation":       situation,
+		"tone":            toneDescriptions[tone],
+		"language":        localeLanguages[detail.Locale],
+		"title":           detail.Title,
+		"summary":         detail.Description,
+		"example":         exampleReply(detail, tone),
+		"when_not_to_use": detail.WhenNotToUse,
+		"best_practices":  strings.Join(detail.BestPractices, "\n"),
+	}
+	return llm.Request{
+		System:      generationSystemPrompt,
+		Messages:    []llm.Message{{Role: llm.RoleUser, Content: renderReply(prompt, values, RenderFormatText)}},
+		MaxTokens:   generationMaxToken,
+		Temperature: generationTemp,
+	}
+}
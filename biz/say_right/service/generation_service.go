@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+	"api/infra/llm"
+)
+
+const KeyPrefixGenerate = "biz:say_right:generate:" // user id + UTC day -> generations used
+
+const (
+	defaultFreeGenerationLimit = 5
+	defaultProGenerationLimit  = 100
+)
+
+const (
+	GenerationOK      = "ok"
+	GenerationError   = "error"
+	GenerationTimeout = "timeout"
+	// GenerationCancelled means the client went away mid-generation
+	GenerationCancelled = "cancelled"
+)
+
+// Usage kinds tell template generation and free-form rewrites apart in the
+// usage log.
+const (
+	UsageGenerate = "generate"
+	UsageRewrite  = "rewrite"
+)
+
+var (
+	ErrGenerationDisabled = errors.New("ai generation is not available")
+	ErrGenerationTimeout  = errors.New("ai generation timed out")
+	ErrGenerationFailed   = errors.New("ai generation failed")
+)
+
+type GenerateInput struct {
+	Situation string `json:"situation"`
+	// Tone is soft, neutral or firm; empty generates one reply per tone.
+	Tone string `json:"tone"`
+}
+
+type GeneratedReply struct {
+	Tone string `json:"tone"`
+	Text string `json:"text"`
+}
+
+type TokenUsage struct {
+	InputTokens  int   `json:"input_tokens"`
+	OutputTokens int   `json:"output_tokens"`
+	CostMicros   int64 `json:"cost_micros"`
+	LatencyMs    int64 `json:"latency_ms"`
+}
+
+type GenerationResult struct {
+	TemplateID int32            `json:"template_id"`
+	Locale     string           `json:"locale"`
+	Provider   string           `json:"provider"`
+	Model      string           `json:"model"`
+	Replies    []GeneratedReply `json:"replies"`
+	Usage      TokenUsage       `json:"usage"`
+	Quota      DailyQuota       `json:"quota"`
+}
+
+// GenerationCostRow totals the calls of one kind and provider model on one
+// UTC day.
+type GenerationCostRow struct {
+	Day          string `json:"day"`
+	Kind         string `json:"kind"`
+	Provider     string `json:"provider"`
+	Model        string `json:"model"`
+	Calls        int64  `json:"calls"`
+	Failures     int64  `json:"failures"`
+	InputTokens  int64  `json:"input_tokens"`
+	OutputTokens int64  `json:"output_tokens"`
+	CostMicros   int64  `json:"cost_micros"`
+}
+
+type GenerationCostReport struct {
+	Since      time.Time           `json:"since"`
+	Until      time.Time           `json:"until"`
+	Rows       []GenerationCostRow `json:"rows"`
+	Calls      int64               `json:"calls"`
+	CostMicros int64               `json:"cost_micros"`
+}
+
+type GenerationService interface {
+	// Generate writes replies tailored to the user's situation from a template.
+	// Each request counts once against the daily quota, whatever the number of tones.
+	Generate(ctx context.Context, userID int32, templateID int32, input GenerateInput, locale LocaleRequest) (*GenerationResult, error)
+	// StreamGenerate runs the same checks as Generate and reserves the quota;
+	// the returned stream produces the replies when Run is called.
+	StreamGenerate(ctx context.Context, userID int32, templateID int32, input GenerateInput, locale LocaleRequest) (*GenerationStream, error)
+	GetQuota(ctx context.Context, userID int32) (*DailyQuota, error)
+	// CostReport totals model calls per day, kind and model in [since, until).
+	CostReport(ctx context.Context, since, until time.Time) (*GenerationCostReport, error)
+}
+
+type generationService struct {
+	q         *query.Query
+	templates TemplateService
+	quota     quotaPolicy
+}
+
+// NewGenerationService reads the daily plan limits from
+// GENERATION_DAILY_LIMIT_FREE and GENERATION_DAILY_LIMIT_PRO.
+func NewGenerationService(templates TemplateService) GenerationService {
+	return &generationService{
+		q:         query.Q,
+		templates: templates,
+		quota: newQuotaPolicy(KeyPrefixGenerate, "GENERATION_DAILY_LIMIT",
+			defaultFreeGenerationLimit, defaultProGenerationLimit),
+	}
+}
+
+func (s *generationService) Generate(ctx context.Context, userID int32, templateID int32, input GenerateInput, locale LocaleRequest) (*GenerationResult, error) {
+	job, err := s.prepare(ctx, userID, templateID, input, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]generationCall, len(job.tones))
+	var wg sync.WaitGroup
+	for i, tone := range job.tones {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			calls[i] = s.call(ctx, job, tone, job.provider.Generate)
+		}()
+	}
+	wg.Wait()
+
+	result := &GenerationResult{
+		TemplateID: job.detail.ID,
+		Locale:     job.detail.Locale,
+		Provider:   job.provider.Name(),
+		Model:      job.provider.Model(),
+		Replies:    make([]GeneratedReply, 0, len(calls)),
+		Quota:      job.quota,
+	}
+	for _, call := range calls {
+		if call.err != nil {
+			s.settle(ctx, job, call.err)
+			return nil, call.err
+		}
+		result.Model = call.usage.Model
+		result.Replies = append(result.Replies, call.reply)
+		result.Usage.add(call.usage)
+	}
+	return result, nil
+}
+
+// generationJob is a request that passed validation, access and quota checks.
+type generationJob struct {
+	provider  llm.Provider
+	user      *model.User
+	detail    *TemplateDetailResult
+	prompt    string
+	situation string
+	tones     []string
+	quota     DailyQuota
+}
+
+// prepare checks the input and access, then reserves one generation from
+// the user's quota. Callers must settle the job if generation fails.
+func (s *generationService) prepare(ctx context.Context, userID int32, templateID int32, input GenerateInput, locale LocaleRequest) (*generationJob, error) {
+	job := &generationJob{
+		provider:  llm.Client,
+		situation: strings.TrimSpace(input.Situation),
+		tones:     Tones,
+	}
+	if err := requireText("situation", job.situation, situationMaxLength); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(input.Tone) != "" {
+		tone, err := normalizeTone(input.Tone)
+		if err != nil {
+			return nil, err
+		}
+		job.tones = []string{tone}
+	}
+	if job.provider == nil {
+		return nil, ErrGenerationDisabled
+	}
+
+	// Access rules (Pro templates) and localization are the same as for reading
+	var err error
+	if job.detail, err = s.templates.GetTemplateDetail(ctx, userID, templateID, locale); err != nil {
+		return nil, err
+	}
+	if job.prompt, err = s.templatePrompt(ctx, templateID); err != nil {
+		return nil, err
+	}
+	if job.user, err = s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First(); err != nil {
+		return nil, err
+	}
+	if job.quota, err = s.quota.reserve(ctx, job.user); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// settle gives the reserved generation back when the provider let the user
+// down. Cancelled requests keep counting since the tokens were paid for.
+func (s *generationService) settle(ctx context.Context, job *generationJob, err error) {
+	if err == ErrGenerationFailed || err == ErrGenerationTimeout {
+		s.quota.release(ctx, job.user)
+	}
+}
+
+func (u *TokenUsage) add(usage *model.GenerationUsage) {
+	u.InputTokens += int(usage.InputTokens)
+	u.OutputTokens += int(usage.OutputTokens)
+	u.CostMicros += usage.CostMicros
+	u.LatencyMs = max(u.LatencyMs, int64(usage.LatencyMs))
+}
+
+type generationCall struct {
+	reply GeneratedReply
+	usage *model.GenerationUsage
+	err   error
+}
+
+// call runs one provider request for tone and records its usage.
+func (s *generationService) call(ctx context.Context, job *generationJob, tone string, generate func(context.Context, llm.Request) (*llm.Response, error)) generationCall {
+	usage := &model.GenerationUsage{
+		UserID:     job.user.ID,
+		TemplateID: job.detail.ID,
+		Kind:       UsageGenerate,
+		Tone:       tone,
+	}
+	req := generationRequest(job.prompt, job.detail, job.situation, tone)
+	resp, err := callProvider(ctx, s.q, job.provider, usage, func(ctx context.Context) (*llm.Response, error) {
+		return generate(ctx, req)
+	})
+	call := generationCall{reply: GeneratedReply{Tone: tone}, usage: usage, err: err}
+	if resp != nil {
+		call.reply.Text = strings.TrimSpace(resp.Text)
+	}
+	return call
+}
+
+// callProvider runs generate under the configured timeout and records the
+// call in usage, which the caller fills with who asked for what. Errors are
+// ErrGenerationTimeout, ErrGenerationFailed or the cancelled ctx's error.
+func callProvider(ctx context.Context, q *query.Query, provider llm.Provider, usage *model.GenerationUsage, generate func(context.Context) (*llm.Response, error)) (*llm.Response, error) {
+	callCtx, cancel := context.WithTimeout(ctx, llm.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	resp, err := generate(callCtx)
+	usage.Provider = provider.Name()
+	usage.Model = provider.Model()
+	usage.Status = GenerationOK
+	usage.LatencyMs = int32(time.Since(started).Milliseconds())
+	usage.CreatedAt = started.UTC()
+
+	var result error
+	switch {
+	case err != nil && ctx.Err() != nil:
+		usage.Status = GenerationCancelled
+		usage.Error = err.Error()
+		result = ctx.Err()
+	case err != nil && errors.Is(callCtx.Err(), context.DeadlineExceeded):
+		usage.Status = GenerationTimeout
+		usage.Error = err.Error()
+		result = ErrGenerationTimeout
+	case err != nil:
+		usage.Status = GenerationError
+		usage.Error = err.Error()
+		result = ErrGenerationFailed
+	case strings.TrimSpace(resp.Text) == "":
+		usage.Status = GenerationError
+		usage.Error = "empty response"
+		result = ErrGenerationFailed
+	}
+	if resp != nil {
+		if resp.Model != "" {
+			usage.Model = resp.Model
+		}
+		usage.InputTokens = int32(resp.InputTokens)
+		usage.OutputTokens = int32(resp.OutputTokens)
+		usage.CostMicros = llm.CostMicros(resp.InputTokens, resp.OutputTokens)
+	}
+	usage.Error = truncateRunes(usage.Error, 512)
+
+	// Cost is tracked even when the client has gone away
+	if err := q.GenerationUsage.WithContext(context.WithoutCancel(ctx)).Create(usage); err != nil {
+		log.Printf("failed to record generation usage: %v", err)
+	}
+	if usage.Status == GenerationError || usage.Status == GenerationTimeout {
+		log.Printf("%s via %s failed: %s", usage.Kind, provider.Name(), usage.Error)
+	}
+	return resp, result
+}
+
+func (s *generationService) templatePrompt(ctx context.Context, templateID int32) (string, error) {
+	detail, err := s.q.TemplateDetail.WithContext(ctx).
+		Where(s.q.TemplateDetail.TemplateID.Eq(templateID)).
+		First()
+	if err != nil {
+		if isNotFound(err) {
+			return "", ErrTemplateNotFound
+		}
+		return "", err
+	}
+	return detail.Prompt, nil
+}
+
+func (s *generationService) GetQuota(ctx context.Context, userID int32) (*DailyQuota, error) {
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, err
+	}
+	quota := s.quota.current(ctx, user)
+	return &quota, nil
+}
+
+func (s *generationService) CostReport(ctx context.Context, since, until time.Time) (*GenerationCostReport, error) {
+	u := s.q.GenerationUsage
+	rows := make([]GenerationCostRow, 0)
+	err := u.WithContext(ctx).UnderlyingDB().
+		Model(&model.GenerationUsage{}).
+		Select(`DATE(created_at) AS day, kind, provider, model, COUNT(*) AS calls,
+			SUM(CASE WHEN status = ? THEN 0 ELSE 1 END) AS failures,
+			SUM(input_tokens) AS input_tokens, SUM(output_tokens) AS output_tokens,
+			SUM(cost_micros) AS cost_micros`, GenerationOK).
+		Where("created_at >= ? AND created_at < ?", since, until).
+		Group("day, kind, provider, model").
+		Order("day, kind, provider, model").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	report := &GenerationCostReport{Since: since, Until: until, Rows: rows}
+	for i, row := range rows {
+		// MySQL returns DATE() as a time, SQLite as text
+		rows[i].Day = row.Day[:min(len(row.Day), len(time.DateOnly))]
+		report.Calls += row.Calls
+		report.CostMicros += row.CostMicros
+	}
+	return report, nil
+}
+
+func truncateRunes(value string, max int) string {
+	if utf8.RuneCountInString(value) <= max {
+		return value
+	}
+	return string([]rune(value)[:max])
+}
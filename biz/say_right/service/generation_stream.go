@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+
+	"api/infra/llm"
+)
+
+// Event names of a streamed generation, in the order they are sent: one
+// start, then deltas and a reply for each tone in turn, then done. A failed
+// generation ends with error instead of done.
+const (
+	EventStart = "start"
+	EventDelta = "delta"
+	EventReply = "reply"
+	EventDone  = "done"
+	EventError = "error"
+)
+
+// GenerationEvent is one server-sent event; Data is encoded as JSON.
+type GenerationEvent struct {
+	Name string
+	Data any
+}
+
+type GenerationStart struct {
+	TemplateID int32      `json:"template_id"`
+	Locale     string     `json:"locale"`
+	Provider   string     `json:"provider"`
+	Model      string     `json:"model"`
+	Tones      []string   `json:"tones"`
+	Quota      DailyQuota `json:"quota"`
+}
+
+type GenerationDelta struct {
+	Tone string `json:"tone"`
+	Text string `json:"text"`
+}
+
+type GenerationDone struct {
+	Model   string           `json:"model"`
+	Replies []GeneratedReply `json:"replies"`
+	Usage   TokenUsage       `json:"usage"`
+	Quota   DailyQuota       `json:"quota"`
+}
+
+// GenerationStream is a generation that passed validation, access and quota
+// checks and is ready to run.
+type GenerationStream struct {
+	s   *generationService
+	job *generationJob
+}
+
+// Locale is the content locale the replies are written in.
+func (g *GenerationStream) Locale() string {
+	return g.job.detail.Locale
+}
+
+// Run generates the tones one after another, passing every event to emit.
+// It stops when ctx is cancelled, which is how a client hanging up cancels
+// the model call.
+func (g *GenerationStream) Run(ctx context.Context, emit func(GenerationEvent)) error {
+	job := g.job
+	emit(GenerationEvent{Name: EventStart, Data: GenerationStart{
+		TemplateID: job.detail.ID,
+		Locale:     job.detail.Locale,
+		Provider:   job.provider.Name(),
+		Model:      job.provider.Model(),
+		Tones:      job.tones,
+		Quota:      job.quota,
+	}})
+
+	done := GenerationDone{
+		Model:   job.provider.Model(),
+		Replies: make([]GeneratedReply, 0, len(job.tones)),
+		Quota:   job.quota,
+	}
+	for _, tone := range job.tones {
+		stream := func(ctx context.Context, req llm.Request) (*llm.Response, error) {
+			return job.provider.Stream(ctx, req, func(text string) {
+				emit(GenerationEvent{Name: EventDelta, Data: GenerationDelta{Tone: tone, Text: text}})
+			})
+		}
+		call := g.s.call(ctx, job, tone, stream)
+		if call.err != nil {
+			g.s.settle(ctx, job, call.err)
+			return call.err
+		}
+		emit(GenerationEvent{Name: EventReply, Data: call.reply})
+		done.Model = call.usage.Model
+		done.Replies = append(done.Replies, call.reply)
+		done.Usage.add(call.usage)
+	}
+
+	emit(GenerationEvent{Name: EventDone, Data: done})
+	return nil
+}
+
+func (s *generationService) StreamGenerate(ctx context.Context, userID int32, templateID int32, input GenerateInput, locale LocaleRequest) (*GenerationStream, error) {
+	job, err := s.prepare(ctx, userID, templateID, input, locale)
+	if err != nil {
+		return nil, err
+	}
+	return &GenerationStream{s: s, job: job}, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// SourceLocale is the language the base catalog columns are written in.
+// Translations exist for every other supported locale.
+const SourceLocale = "en"
+
+// SupportedLocales lists the content languages, source first.
+var SupportedLocales = []string{SourceLocale, "zh-CN", "zh-TW"}
+
+// localeFallbacks lists the translations tried, in order, when a field has
+// no translation in the requested locale. The source text always comes last.
+var localeFallbacks = map[string][]string{
+	"zh-TW": {"zh-CN"},
+}
+
+var localeMatcher = func() language.Matcher {
+	tags := make([]language.Tag, len(SupportedLocales))
+	for i, locale := range SupportedLocales {
+		tags[i] = language.MustParse(locale)
+	}
+	return language.NewMatcher(tags)
+}()
+
+// LocaleRequest is what a client asked for; services combine it with the
+// user's saved preference. An explicit locale beats the preference, which
+// beats Accept-Language.
+type LocaleRequest struct {
+	Locale         string
+	AcceptLanguage string
+}
+
+// Resolve picks the content locale given the user's saved preference, which
+// may be empty.
+func (r LocaleRequest) Resolve(preferred string) string {
+	return NegotiateLocale(r.Locale, preferred, r.AcceptLanguage)
+}
+
+// NegotiateLocale returns the supported locale that best matches the first
+// usable preference. Each preference is a language tag or an Accept-Language
+// header value; empty and unmatched ones are skipped.
+func NegotiateLocale(preferences ...string) string {
+	for _, preference := range preferences {
+		if strings.TrimSpace(preference) == "" {
+			continue
+		}
+		tags, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+		if _, i, confidence := localeMatcher.Match(tags...); confidence != language.No {
+			return SupportedLocales[i]
+		}
+	}
+	return SourceLocale
+}
+
+// NormalizeLocale returns the supported spelling of locale, accepting any
+// letter case and "_" separators.
+func NormalizeLocale(locale string) (string, bool) {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	for _, supported := range SupportedLocales {
+		if strings.EqualFold(supported, locale) {
+			return supported, true
+		}
+	}
+	return "", false
+}
+
+// translationChain lists the translation locales consulted for locale, most
+// preferred first. It is empty for the source locale.
+func translationChain(locale string) []string {
+	if locale == SourceLocale {
+		return nil
+	}
+	return append([]string{locale}, localeFallbacks[locale]...)
+}
+
+// translationLocale validates a locale that editors want to translate into.
+func translationLocale(locale string) (string, error) {
+	normalized, ok := NormalizeLocale(locale)
+	if !ok {
+		return "", ValidationError{Field: "locale", Message: "is not a supported locale"}
+	}
+	if normalized == SourceLocale {
+		return "", ValidationError{Field: "locale", Message: "is the source locale; edit the content itself"}
+	}
+	return normalized, nil
+}
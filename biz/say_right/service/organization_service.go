@@ -0,0 +1,865 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+	mailer "api/infra/mail"
+
+	"gorm.io/gorm/clause"
+)
+
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+)
+
+// OrgInvitationTTL is how long an invitation link can be accepted.
+const OrgInvitationTTL = 7 * 24 * time.Hour
+
+// unpaidOrgSeats is the seat count of an organization without an active
+// subscription: just the owner, so members are only invited once paid for.
+const unpaidOrgSeats = 1
+
+// activeSubscriptionStatuses are the Paddle subscription states that keep
+// members on Pro. Past due subscriptions keep access while Paddle retries.
+var activeSubscriptionStatuses = []string{"active", "trialing", "past_due"}
+
+// ErrOrganizationNotFound is also returned to non-members, so organizations
+// cannot be probed by id.
+var ErrOrganizationNotFound = errors.New("organization not found")
+
+var (
+	ErrOrgRoleForbidden     = errors.New("your role in this organization does not allow this")
+	ErrOrgLastOwner         = errors.New("an organization needs at least one owner")
+	ErrOrgMemberNotFound    = errors.New("member not found")
+	ErrAlreadyOrgMember     = errors.New("already a member of this organization")
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrInvitationExpired    = errors.New("invitation has expired")
+	ErrInvitationEmail      = errors.New("invitation was sent to a different email address")
+	ErrOrgSubscriptionOnly  = errors.New("organization has no active subscription")
+	ErrOrgAlreadySubscribed = errors.New("organization already has an active subscription")
+)
+
+var (
+	// ErrCheckoutNotIssued rejects a new subscription that did not come from
+	// a checkout StartCheckout issued for the organization.
+	ErrCheckoutNotIssued = errors.New("subscription did not come from a checkout issued for the organization")
+	// ErrSubscriptionNotBound rejects updates to a subscription no
+	// organization is bound to.
+	ErrSubscriptionNotBound = errors.New("subscription is not bound to an organization")
+)
+
+// OrgSeatLimitError means every seat is taken by a member or a pending
+// invitation.
+type OrgSeatLimitError struct {
+	Seats int
+}
+
+func (e OrgSeatLimitError) Error() string {
+	return fmt.Sprintf("All %d seats are taken", e.Seats)
+}
+
+type OrganizationInput struct {
+	Name string `json:"name"`
+}
+
+type InvitationInput struct {
+	Email string `json:"email"`
+	// Role is admin or member; empty means member.
+	Role string `json:"role"`
+}
+
+type RoleInput struct {
+	Role string `json:"role"`
+}
+
+// OrganizationSubscription is the state of an organization's seat-based
+// Paddle subscription as reported by webhooks. OrganizationID and
+// CheckoutToken come from the checkout's custom data and are only used to
+// bind a new subscription; updates find the organization by SubscriptionID.
+type OrganizationSubscription struct {
+	OrganizationID   int32
+	CheckoutToken    string
+	SubscriptionID   string
+	Status           string
+	Seats            int
+	CurrentPeriodEnd *time.Time
+	// OccurredAt is when Paddle generated the event.
+	OccurredAt time.Time
+}
+
+// OrgCheckoutResult is what the client passes to Paddle checkout when buying
+// seats for an organization.
+type OrgCheckoutResult struct {
+	// CustomData goes into the checkout's customData as is.
+	CustomData map[string]string `json:"custom_data"`
+}
+
+type OrganizationSummary struct {
+	ID                 int32  `json:"id"`
+	Name               string `json:"name"`
+	Role               string `json:"role"`
+	Seats              int    `json:"seats"`
+	SubscriptionStatus string `json:"subscription_status"`
+	// Active means members currently get Pro from this organization.
+	Active bool `json:"active"`
+}
+
+type OrganizationResult struct {
+	OrganizationSummary
+	SeatsUsed        int                `json:"seats_used"`
+	CurrentPeriodEnd *time.Time         `json:"current_period_end"`
+	Members          []OrgMemberResult  `json:"members"`
+	Invitations      []InvitationResult `json:"invitations"`
+	CreatedAt        time.Time          `json:"created_at"`
+}
+
+type OrgMemberResult struct {
+	UserID   int32     `json:"user_id"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+	// Seated means the member holds one of the paid seats and gets Pro.
+	Seated bool `json:"seated"`
+}
+
+type InvitationResult struct {
+	ID    int32  `json:"id"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+	// URL is only returned when the invitation is created, so an admin can
+	// pass it on if the email does not arrive.
+	URL       string    `json:"url,omitempty"`
+	EmailSent bool      `json:"email_sent"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OrganizationService manages teams. Members of an organization with an
+// active subscription get Pro, and share a template library only they see.
+// Owners and admins manage members; only owners can appoint other owners.
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, userID int32, input OrganizationInput) (*OrganizationResult, error)
+	ListOrganizations(ctx context.Context, userID int32) ([]OrganizationSummary, error)
+	// GetOrganization includes pending invitations for owners and admins.
+	GetOrganization(ctx context.Context, userID int32, orgID int32) (*OrganizationResult, error)
+	UpdateOrganization(ctx context.Context, userID int32, orgID int32, input OrganizationInput) (*OrganizationResult, error)
+
+	// InviteMember emails a link to join. Inviting an address again replaces
+	// the earlier invitation. Pending invitations hold a seat.
+	InviteMember(ctx context.Context, userID int32, orgID int32, input InvitationInput) (*InvitationResult, error)
+	RevokeInvitation(ctx context.Context, userID int32, orgID int32, invitationID int32) error
+	// AcceptInvitation adds the user, who must have the invited email address.
+	AcceptInvitation(ctx context.Context, userID int32, token string) (*OrganizationResult, error)
+	SetMemberRole(ctx context.Context, userID int32, orgID int32, memberID int32, input RoleInput) error
+	// RemoveMember removes memberID; members may remove themselves to leave.
+	RemoveMember(ctx context.Context, userID int32, orgID int32, memberID int32) error
+
+	// StartCheckout issues the custom data for an owner or admin to buy seats
+	// with. Only a subscription created from it is bound to the organization,
+	// so nobody can attach a subscription to an organization they do not
+	// manage.
+	StartCheckout(ctx context.Context, userID int32, orgID int32) (*OrgCheckoutResult, error)
+	// BindSubscription attaches the subscription from a subscription.created
+	// webhook to the organization whose checkout it came from.
+	BindSubscription(ctx context.Context, sub OrganizationSubscription) error
+	// ApplySubscription stores an update to a bound subscription and
+	// recomputes Pro for every member. Events older than the last one
+	// applied are ignored, since Paddle may deliver them out of order.
+	ApplySubscription(ctx context.Context, sub OrganizationSubscription) error
+
+	ListTeamTemplates(ctx context.Context, userID int32, orgID int32) (*UserTemplateList, error)
+	GetTeamTemplate(ctx context.Context, userID int32, orgID int32, templateID int32) (*UserTemplateResult, error)
+	// CreateTeamTemplate needs an active subscription.
+	CreateTeamTemplate(ctx context.Context, userID int32, orgID int32, input UserTemplateInput) (*UserTemplateResult, error)
+	// UpdateTeamTemplate and DeleteTeamTemplate are open to the author and
+	// to owners and admins.
+	UpdateTeamTemplate(ctx context.Context, userID int32, orgID int32, templateID int32, input UserTemplateInput) (*UserTemplateResult, error)
+	DeleteTeamTemplate(ctx context.Context, userID int32, orgID int32, templateID int32) error
+}
+
+type organizationService struct {
+	q             *query.Query
+	audit         AuditService
+	siteURL       string
+	templateLimit int
+}
+
+// NewOrganizationService builds invitation links from SAYRIGHT_SITE_URL and
+// reads the team library size from TEAM_TEMPLATE_LIMIT.
+func NewOrganizationService(audit AuditService) OrganizationService {
+	return &organizationService{
+		q:             query.Q,
+		audit:         audit,
+		siteURL:       siteURLFromEnv(),
+		templateLimit: limitFromEnv("TEAM_TEMPLATE_LIMIT", defaultTeamTemplateLimit),
+	}
+}
+
+func (s *organizationService) CreateOrganization(ctx context.Context, userID int32, input OrganizationInput) (*OrganizationResult, error) {
+	name := strings.TrimSpace(input.Name)
+	if err := requireText("name", name, 128); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	org := &model.Organization{Name: name, CreatedAt: now, UpdatedAt: now}
+	err := s.q.Transaction(func(tx *query.Query) error {
+		if err := tx.Organization.WithContext(ctx).Create(org); err != nil {
+			return err
+		}
+		return tx.OrganizationMember.WithContext(ctx).Create(&model.OrganizationMember{
+			OrganizationID: org.ID,
+			UserID:         userID,
+			Role:           OrgRoleOwner,
+			CreatedAt:      now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetOrganization(ctx, userID, org.ID)
+}
+
+func (s *organizationService) ListOrganizations(ctx context.Context, userID int32) ([]OrganizationSummary, error) {
+	m := s.q.OrganizationMember
+	memberships, err := m.WithContext(ctx).Where(m.UserID.Eq(userID)).Order(m.CreatedAt, m.OrganizationID).Find()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, 0, len(memberships))
+	for _, membership := range memberships {
+		ids = append(ids, membership.OrganizationID)
+	}
+	orgs, err := findOrganizations(ctx, s.q, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]OrganizationSummary, 0, len(memberships))
+	for _, membership := range memberships {
+		if org, ok := orgs[membership.OrganizationID]; ok {
+			result = append(result, toOrganizationSummary(org, membership.Role))
+		}
+	}
+	return result, nil
+}
+
+func (s *organizationService) GetOrganization(ctx context.Context, userID int32, orgID int32) (*OrganizationResult, error) {
+	member, err := findMembership(ctx, s.q, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.q.Organization.WithContext(ctx).Where(s.q.Organization.ID.Eq(orgID)).First()
+	if err != nil {
+		return nil, err
+	}
+
+	m := s.q.OrganizationMember
+	members, err := m.WithContext(ctx).Where(m.OrganizationID.Eq(orgID)).Order(m.CreatedAt, m.UserID).Find()
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]int32, 0, len(members))
+	for _, m := range members {
+		userIDs = append(userIDs, m.UserID)
+	}
+	users, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.In(userIDs...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	emails := make(map[int32]string, len(users))
+	for _, u := range users {
+		emails[u.ID] = u.Email
+	}
+
+	invitations, err := pendingInvitations(ctx, s.q, orgID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	seated := seatedMembers(org, members)
+	result := &OrganizationResult{
+		OrganizationSummary: toOrganizationSummary(org, member.Role),
+		SeatsUsed:           len(members) + len(invitations),
+		CurrentPeriodEnd:    org.CurrentPeriodEnd,
+		Members:             make([]OrgMemberResult, 0, len(members)),
+		Invitations:         []InvitationResult{},
+		CreatedAt:           org.CreatedAt,
+	}
+	for _, m := range members {
+		result.Members = append(result.Members, OrgMemberResult{
+			UserID:   m.UserID,
+			Email:    emails[m.UserID],
+			Role:     m.Role,
+			JoinedAt: m.CreatedAt,
+			Seated:   seated[m.UserID],
+		})
+	}
+	if canManageOrg(member) {
+		for _, invitation := range invitations {
+			result.Invitations = append(result.Invitations, toInvitationResult(invitation))
+		}
+	}
+	return result, nil
+}
+
+func (s *organizationService) UpdateOrganization(ctx context.Context, userID int32, orgID int32, input OrganizationInput) (*OrganizationResult, error) {
+	name := strings.TrimSpace(input.Name)
+	if err := requireText("name", name, 128); err != nil {
+		return nil, err
+	}
+	if _, err := s.manager(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	o := s.q.Organization
+	if _, err := o.WithContext(ctx).Where(o.ID.Eq(orgID)).UpdateSimple(o.Name.Value(name), o.UpdatedAt.Value(time.Now().UTC())); err != nil {
+		return nil, err
+	}
+	return s.GetOrganization(ctx, userID, orgID)
+}
+
+func (s *organizationService) InviteMember(ctx context.Context, userID int32, orgID int32, input InvitationInput) (*InvitationResult, error) {
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email || len(email) > 320 {
+		return nil, ValidationError{Field: "email", Message: "must be a valid email address"}
+	}
+	role := input.Role
+	if role == "" {
+		role = OrgRoleMember
+	}
+	if role != OrgRoleAdmin && role != OrgRoleMember {
+		return nil, ValidationError{Field: "role", Message: "must be admin or member"}
+	}
+
+	if _, err := s.manager(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	var invitation *model.OrganizationInvitation
+	var org *model.Organization
+	err := s.q.Transaction(func(tx *query.Query) error {
+		var err error
+		// Locking the organization serializes invitations so the seat count holds
+		org, err = lockOrganization(ctx, tx, orgID)
+		if err != nil {
+			return err
+		}
+
+		invited, err := tx.User.WithContext(ctx).Where(tx.User.EmailNorm.Eq(email)).First()
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		if invited != nil {
+			if _, err := findMembership(ctx, tx, orgID, invited.ID); err == nil {
+				return ErrAlreadyOrgMember
+			}
+		}
+
+		i := tx.OrganizationInvitation
+		if _, err := i.WithContext(ctx).Where(i.OrganizationID.Eq(orgID), i.Email.Eq(email)).Delete(); err != nil {
+			return err
+		}
+		if err := checkFreeSeat(ctx, tx, org); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		invitation = &model.OrganizationInvitation{
+			OrganizationID: orgID,
+			Email:          email,
+			Role:           role,
+			Token:          randomToken(),
+			InvitedBy:      userID,
+			ExpiresAt:      now.Add(OrgInvitationTTL),
+			CreatedAt:      now,
+		}
+		return i.WithContext(ctx).Create(invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toInvitationResult(invitation)
+	result.URL = s.siteURL + "/invitations/" + invitation.Token
+	// The invitation stands without the email; the admin can send the link
+	body := fmt.Sprintf("You have been invited to join %s on Say Right.\n\nAccept the invitation: %s\n\nThe link expires on %s.",
+		org.Name, result.URL, invitation.ExpiresAt.Format("2006-01-02"))
+	if err := mailer.SendEmail(email, "Join "+org.Name+" on Say Right", body); err != nil {
+		log.Printf("Failed to email invitation %d: %v", invitation.ID, err)
+	} else {
+		result.EmailSent = true
+	}
+	return &result, nil
+}
+
+func (s *organizationService) RevokeInvitation(ctx context.Context, userID int32, orgID int32, invitationID int32) error {
+	if _, err := s.manager(ctx, orgID, userID); err != nil {
+		return err
+	}
+	i := s.q.OrganizationInvitation
+	info, err := i.WithContext(ctx).Where(i.ID.Eq(invitationID), i.OrganizationID.Eq(orgID)).Delete()
+	if err != nil {
+		return err
+	}
+	if info.RowsAffected == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (s *organizationService) AcceptInvitation(ctx context.Context, userID int32, token string) (*OrganizationResult, error) {
+	var orgID int32
+	err := s.q.Transaction(func(tx *query.Query) error {
+		i := tx.OrganizationInvitation
+		invitation, err := i.WithContext(ctx).Where(i.Token.Eq(token)).First()
+		if err != nil {
+			if isNotFound(err) {
+				return ErrInvitationNotFound
+			}
+			return err
+		}
+		if !time.Now().UTC().Before(invitation.ExpiresAt) {
+			return ErrInvitationExpired
+		}
+		user, err := tx.User.WithContext(ctx).Where(tx.User.ID.Eq(userID)).First()
+		if err != nil {
+			return err
+		}
+		if user.EmailNorm != invitation.Email {
+			return ErrInvitationEmail
+		}
+
+		orgID = invitation.OrganizationID
+		if _, err := lockOrganization(ctx, tx, orgID); err != nil {
+			return err
+		}
+		if _, err := findMembership(ctx, tx, orgID, userID); err == nil {
+			return ErrAlreadyOrgMember
+		}
+
+		if _, err := i.WithContext(ctx).Where(i.ID.Eq(invitation.ID)).Delete(); err != nil {
+			return err
+		}
+		err = tx.OrganizationMember.WithContext(ctx).Create(&model.OrganizationMember{
+			OrganizationID: orgID,
+			UserID:         userID,
+			Role:           invitation.Role,
+			CreatedAt:      time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		return syncOrgTeamPro(ctx, tx, orgID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetOrganization(ctx, userID, orgID)
+}
+
+func (s *organizationService) SetMemberRole(ctx context.Context, userID int32, orgID int32, memberID int32, input RoleInput) error {
+	if !slices.Contains([]string{OrgRoleOwner, OrgRoleAdmin, OrgRoleMember}, input.Role) {
+		return ValidationError{Field: "role", Message: "must be owner, admin or member"}
+	}
+
+	return s.q.Transaction(func(tx *query.Query) error {
+		if _, err := lockOrganization(ctx, tx, orgID); err != nil {
+			return err
+		}
+		actor, err := findMembership(ctx, tx, orgID, userID)
+		if err != nil {
+			return err
+		}
+		target, err := findMembership(ctx, tx, orgID, memberID)
+		if err == ErrOrganizationNotFound {
+			return ErrOrgMemberNotFound
+		}
+		if err != nil {
+			return err
+		}
+		// Admins manage admins and members; owners are changed by owners only
+		if actor.Role != OrgRoleOwner && (!canManageOrg(actor) || target.Role == OrgRoleOwner || input.Role == OrgRoleOwner) {
+			return ErrOrgRoleForbidden
+		}
+		if target.Role == OrgRoleOwner && input.Role != OrgRoleOwner {
+			if err := keepAnOwner(ctx, tx, orgID); err != nil {
+				return err
+			}
+		}
+		m := tx.OrganizationMember
+		_, err = m.WithContext(ctx).Where(m.OrganizationID.Eq(orgID), m.UserID.Eq(memberID)).UpdateSimple(m.Role.Value(input.Role))
+		if err != nil {
+			return err
+		}
+		// Owners are seated first, so a role change can move seats
+		return syncOrgTeamPro(ctx, tx, orgID)
+	})
+}
+
+func (s *organizationService) RemoveMember(ctx context.Context, userID int32, orgID int32, memberID int32) error {
+	return s.q.Transaction(func(tx *query.Query) error {
+		if _, err := lockOrganization(ctx, tx, orgID); err != nil {
+			return err
+		}
+		actor, err := findMembership(ctx, tx, orgID, userID)
+		if err != nil {
+			return err
+		}
+		target, err := findMembership(ctx, tx, orgID, memberID)
+		if err == ErrOrganizationNotFound {
+			return ErrOrgMemberNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if userID != memberID && actor.Role != OrgRoleOwner && (!canManageOrg(actor) || target.Role == OrgRoleOwner) {
+			return ErrOrgRoleForbidden
+		}
+		if target.Role == OrgRoleOwner {
+			if err := keepAnOwner(ctx, tx, orgID); err != nil {
+				return err
+			}
+		}
+
+		m := tx.OrganizationMember
+		if _, err := m.WithContext(ctx).Where(m.OrganizationID.Eq(orgID), m.UserID.Eq(memberID)).Delete(); err != nil {
+			return err
+		}
+		// The freed seat may go to a member who was over the limit
+		return syncOrgTeamPro(ctx, tx, orgID, memberID)
+	})
+}
+
+func (s *organizationService) StartCheckout(ctx context.Context, userID int32, orgID int32) (*OrgCheckoutResult, error) {
+	if _, err := s.manager(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	token := randomToken()
+	err := s.q.Transaction(func(tx *query.Query) error {
+		org, err := lockOrganization(ctx, tx, orgID)
+		if err != nil {
+			return err
+		}
+		// Seats on a running subscription change through Paddle, not a new checkout
+		if subscriptionActive(org) {
+			return ErrOrgAlreadySubscribed
+		}
+		o := tx.Organization
+		_, err = o.WithContext(ctx).Where(o.ID.Eq(orgID)).UpdateSimple(o.CheckoutToken.Value(token))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &OrgCheckoutResult{CustomData: map[string]string{
+		"organization_id": strconv.Itoa(int(orgID)),
+		"checkout_token":  token,
+	}}, nil
+}
+
+func (s *organizationService) BindSubscription(ctx context.Context, sub OrganizationSubscription) error {
+	if sub.SubscriptionID == "" {
+		return ErrSubscriptionNotBound
+	}
+	return s.q.Transaction(func(tx *query.Query) error {
+		org, err := lockOrganization(ctx, tx, sub.OrganizationID)
+		if err != nil {
+			return err
+		}
+		// A redelivered subscription.created is an update like any other
+		if org.SubscriptionID == sub.SubscriptionID {
+			return s.storeSubscription(ctx, tx, org, sub)
+		}
+		if org.CheckoutToken == "" || subtle.ConstantTimeCompare([]byte(org.CheckoutToken), []byte(sub.CheckoutToken)) != 1 {
+			return ErrCheckoutNotIssued
+		}
+		org.CheckoutToken = ""
+		org.SubscriptionEventAt = nil
+		return s.storeSubscription(ctx, tx, org, sub)
+	})
+}
+
+func (s *organizationService) ApplySubscription(ctx context.Context, sub OrganizationSubscription) error {
+	if sub.SubscriptionID == "" {
+		return ErrSubscriptionNotBound
+	}
+	return s.q.Transaction(func(tx *query.Query) error {
+		o := tx.Organization
+		org, err := o.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(o.SubscriptionID.Eq(sub.SubscriptionID)).
+			First()
+		if isNotFound(err) {
+			return ErrSubscriptionNotBound
+		}
+		if err != nil {
+			return err
+		}
+		return s.storeSubscription(ctx, tx, org, sub)
+	})
+}
+
+// storeSubscription saves sub on the locked org unless a newer event was
+// already applied, and recomputes Pro for every member.
+func (s *organizationService) storeSubscription(ctx context.Context, tx *query.Query, org *model.Organization, sub OrganizationSubscription) error {
+	if org.SubscriptionEventAt != nil && sub.OccurredAt.Before(*org.SubscriptionEventAt) {
+		log.Printf("Ignoring stale event for subscription %s from %s", sub.SubscriptionID, sub.OccurredAt.Format(time.RFC3339))
+		return nil
+	}
+
+	before := *org
+	occurredAt := sub.OccurredAt
+	org.SubscriptionID = sub.SubscriptionID
+	org.SubscriptionStatus = sub.Status
+	org.Seats = int32(sub.Seats)
+	org.CurrentPeriodEnd = sub.CurrentPeriodEnd
+	org.SubscriptionEventAt = &occurredAt
+	org.UpdatedAt = time.Now().UTC()
+	if err := tx.Organization.WithContext(ctx).Save(org); err != nil {
+		return err
+	}
+
+	m := tx.OrganizationMember
+	members, err := m.WithContext(ctx).Where(m.OrganizationID.Eq(org.ID)).Count()
+	if err != nil {
+		return err
+	}
+	if err := syncOrgTeamPro(ctx, tx, org.ID); err != nil {
+		return err
+	}
+
+	return s.audit.Record(ctx, tx, AuditEntry{
+		Action:     "billing.organization_subscription",
+		TargetType: "organization",
+		TargetID:   strconv.Itoa(int(org.ID)),
+		Before:     before,
+		After:      org,
+		Detail:     map[string]any{"members": members},
+	})
+}
+
+// manager returns the user's membership if they may manage the organization.
+func (s *organizationService) manager(ctx context.Context, orgID, userID int32) (*model.OrganizationMember, error) {
+	member, err := findMembership(ctx, s.q, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageOrg(member) {
+		return nil, ErrOrgRoleForbidden
+	}
+	return member, nil
+}
+
+func findMembership(ctx context.Context, q *query.Query, orgID, userID int32) (*model.OrganizationMember, error) {
+	m := q.OrganizationMember
+	member, err := m.WithContext(ctx).Where(m.OrganizationID.Eq(orgID), m.UserID.Eq(userID)).First()
+	if isNotFound(err) {
+		return nil, ErrOrganizationNotFound
+	}
+	return member, err
+}
+
+func findOrganizations(ctx context.Context, q *query.Query, ids []int32) (map[int32]*model.Organization, error) {
+	result := make(map[int32]*model.Organization, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	orgs, err := q.Organization.WithContext(ctx).Where(q.Organization.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	for _, org := range orgs {
+		result[org.ID] = org
+	}
+	return result, nil
+}
+
+func lockOrganization(ctx context.Context, tx *query.Query, orgID int32) (*model.Organization, error) {
+	org, err := tx.Organization.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(tx.Organization.ID.Eq(orgID)).
+		First()
+	if isNotFound(err) {
+		return nil, ErrOrganizationNotFound
+	}
+	return org, err
+}
+
+func pendingInvitations(ctx context.Context, q *query.Query, orgID int32, now time.Time) ([]*model.OrganizationInvitation, error) {
+	i := q.OrganizationInvitation
+	return i.WithContext(ctx).Where(i.OrganizationID.Eq(orgID), i.ExpiresAt.Gt(now)).Order(i.CreatedAt, i.ID).Find()
+}
+
+// checkFreeSeat fails when members and pending invitations fill the seats.
+// Seats can drop below the member count when a subscription is reduced;
+// existing members stay, but only seated ones keep Pro (see seatedMembers)
+// and nobody new is invited until enough leave.
+func checkFreeSeat(ctx context.Context, tx *query.Query, org *model.Organization) error {
+	seats := orgSeats(org)
+	members, err := tx.OrganizationMember.WithContext(ctx).Where(tx.OrganizationMember.OrganizationID.Eq(org.ID)).Count()
+	if err != nil {
+		return err
+	}
+	invitations, err := pendingInvitations(ctx, tx, org.ID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if int(members)+len(invitations) >= seats {
+		return OrgSeatLimitError{Seats: seats}
+	}
+	return nil
+}
+
+func keepAnOwner(ctx context.Context, tx *query.Query, orgID int32) error {
+	m := tx.OrganizationMember
+	owners, err := m.WithContext(ctx).Where(m.OrganizationID.Eq(orgID), m.Role.Eq(OrgRoleOwner)).Count()
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrOrgLastOwner
+	}
+	return nil
+}
+
+// syncOrgTeamPro runs syncTeamPro for every member of the organization, plus
+// userIDs who may have just left it.
+func syncOrgTeamPro(ctx context.Context, tx *query.Query, orgID int32, userIDs ...int32) error {
+	m := tx.OrganizationMember
+	var memberIDs []int32
+	if err := m.WithContext(ctx).Where(m.OrganizationID.Eq(orgID)).Pluck(m.UserID, &memberIDs); err != nil {
+		return err
+	}
+	return syncTeamPro(ctx, tx, append(memberIDs, userIDs...)...)
+}
+
+// syncTeamPro recomputes users.team_pro for the given users from their
+// seats, so entitlement checks only need the user row.
+func syncTeamPro(ctx context.Context, tx *query.Query, userIDs ...int32) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	m := tx.OrganizationMember
+	var orgIDs []int32
+	if err := m.WithContext(ctx).Where(m.UserID.In(userIDs...)).Distinct(m.OrganizationID).Pluck(m.OrganizationID, &orgIDs); err != nil {
+		return err
+	}
+	orgs, err := findOrganizations(ctx, tx, orgIDs)
+	if err != nil {
+		return err
+	}
+
+	entitled := make([]int32, 0, len(userIDs))
+	for _, org := range orgs {
+		if !subscriptionActive(org) {
+			continue
+		}
+		// Seats depend on every member of the organization, not just userIDs
+		members, err := m.WithContext(ctx).Where(m.OrganizationID.Eq(org.ID)).Order(m.CreatedAt, m.UserID).Find()
+		if err != nil {
+			return err
+		}
+		for id := range seatedMembers(org, members) {
+			if slices.Contains(userIDs, id) && !slices.Contains(entitled, id) {
+				entitled = append(entitled, id)
+			}
+		}
+	}
+	others := make([]int32, 0, len(userIDs))
+	for _, id := range userIDs {
+		if !slices.Contains(entitled, id) {
+			others = append(others, id)
+		}
+	}
+
+	u := tx.User
+	if len(entitled) > 0 {
+		if _, err := u.WithContext(ctx).Where(u.ID.In(entitled...)).UpdateSimple(u.TeamPro.Value(1)); err != nil {
+			return err
+		}
+	}
+	if len(others) > 0 {
+		if _, err := u.WithContext(ctx).Where(u.ID.In(others...)).UpdateSimple(u.TeamPro.Value(0)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seatedMembers picks the members who get Pro from org: owners first, then
+// everyone else in the order they joined, up to the seat count. members must
+// be ordered by join date.
+func seatedMembers(org *model.Organization, members []*model.OrganizationMember) map[int32]bool {
+	seated := make(map[int32]bool)
+	if !subscriptionActive(org) {
+		return seated
+	}
+	ordered := slices.Clone(members)
+	slices.SortStableFunc(ordered, func(a, b *model.OrganizationMember) int {
+		return cmp.Compare(orgSeatRank(a), orgSeatRank(b))
+	})
+	for _, member := range ordered {
+		if len(seated) == int(org.Seats) {
+			break
+		}
+		seated[member.UserID] = true
+	}
+	return seated
+}
+
+func orgSeatRank(member *model.OrganizationMember) int {
+	if member.Role == OrgRoleOwner {
+		return 0
+	}
+	return 1
+}
+
+func subscriptionActive(org *model.Organization) bool {
+	return slices.Contains(activeSubscriptionStatuses, org.SubscriptionStatus)
+}
+
+func orgSeats(org *model.Organization) int {
+	if subscriptionActive(org) {
+		return int(org.Seats)
+	}
+	return unpaidOrgSeats
+}
+
+func canManageOrg(member *model.OrganizationMember) bool {
+	return member.Role == OrgRoleOwner || member.Role == OrgRoleAdmin
+}
+
+func toOrganizationSummary(org *model.Organization, role string) OrganizationSummary {
+	return OrganizationSummary{
+		ID:                 org.ID,
+		Name:               org.Name,
+		Role:               role,
+		Seats:              orgSeats(org),
+		SubscriptionStatus: org.SubscriptionStatus,
+		Active:             subscriptionActive(org),
+	}
+}
+
+func toInvitationResult(invitation *model.OrganizationInvitation) InvitationResult {
+	return InvitationResult{
+		ID:        invitation.ID,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+// createOrgUsers creates n users and returns their ids in order.
+func createOrgUsers(t *testing.T, n int) []int32 {
+	t.Helper()
+	now := time.Now().UTC()
+	ids := make([]int32, 0, n)
+	for i := 0; i < n; i++ {
+		email := "member" + strconv.Itoa(i) + "@example.com"
+		u := &model.User{Email: email, EmailNorm: email, Role: RoleUser, Status: UserStatusActive, CreatedAt: now, UpdatedAt: now}
+		if err := query.Q.User.WithContext(context.Background()).Create(u); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
+
+func teamProUsers(t *testing.T, ids []int32) []int32 {
+	t.Helper()
+	var result []int32
+	u := query.Q.User
+	if err := u.WithContext(context.Background()).Where(u.ID.In(ids...), u.TeamPro.Eq(1)).Order(u.ID).Pluck(u.ID, &result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// bindSubscription binds subscriptionID to the organization through a
+// checkout started by userID, with a single seat.
+func bindSubscription(t *testing.T, svc OrganizationService, userID, orgID int32, subscriptionID string) {
+	t.Helper()
+	ctx := context.Background()
+	checkout, err := svc.StartCheckout(ctx, userID, orgID)
+	if err != nil {
+		t.Fatalf("start checkout: %v", err)
+	}
+	err = svc.BindSubscription(ctx, OrganizationSubscription{
+		OrganizationID: orgID, CheckoutToken: checkout.CustomData["checkout_token"],
+		SubscriptionID: subscriptionID, Status: "active", Seats: 1, OccurredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("bind subscription: %v", err)
+	}
+}
+
+func TestTeamProIsCappedAtSeats(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	svc := NewOrganizationService(NewAuditService())
+
+	users := createOrgUsers(t, 3)
+	owner, first, second := users[0], users[1], users[2]
+	org, err := svc.CreateOrganization(ctx, owner, OrganizationInput{Name: "Acme"})
+	if err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	// Members joined before the owner's row to check owners still come first
+	joined := time.Now().UTC().Add(-time.Hour)
+	for i, id := range []int32{first, second} {
+		err := query.Q.OrganizationMember.WithContext(ctx).Create(&model.OrganizationMember{
+			OrganizationID: org.ID, UserID: id, Role: OrgRoleMember, CreatedAt: joined.Add(time.Duration(i) * time.Minute),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bindSubscription(t, svc, owner, org.ID, "sub_1")
+	occurredAt := time.Now().UTC()
+	apply := func(seats int) {
+		t.Helper()
+		occurredAt = occurredAt.Add(time.Second)
+		err := svc.ApplySubscription(ctx, OrganizationSubscription{
+			SubscriptionID: "sub_1", Status: "active", Seats: seats, OccurredAt: occurredAt,
+		})
+		if err != nil {
+			t.Fatalf("apply subscription: %v", err)
+		}
+	}
+	assertPro := func(want ...int32) {
+		t.Helper()
+		got := teamProUsers(t, users)
+		if len(got) != len(want) {
+			t.Fatalf("team_pro users = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("team_pro users = %v, want %v", got, want)
+			}
+		}
+	}
+
+	apply(3)
+	assertPro(owner, first, second)
+
+	apply(2)
+	assertPro(owner, first)
+
+	if err := svc.RemoveMember(ctx, owner, org.ID, first); err != nil {
+		t.Fatalf("remove member: %v", err)
+	}
+	assertPro(owner, second)
+
+	result, err := svc.GetOrganization(ctx, owner, org.ID)
+	if err != nil {
+		t.Fatalf("get organization: %v", err)
+	}
+	for _, m := range result.Members {
+		if !m.Seated {
+			t.Errorf("member %d is not seated with a free seat", m.UserID)
+		}
+	}
+}
+
+func TestSubscriptionBindsOnlyIssuedCheckouts(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	svc := NewOrganizationService(NewAuditService())
+
+	users := createOrgUsers(t, 2)
+	owner, outsider := users[0], users[1]
+	org, err := svc.CreateOrganization(ctx, owner, OrganizationInput{Name: "Acme"})
+	if err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	seats := func() int32 {
+		t.Helper()
+		o := query.Q.Organization
+		got, err := o.WithContext(ctx).Where(o.ID.Eq(org.ID)).First()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got.Seats
+	}
+
+	if _, err := svc.StartCheckout(ctx, outsider, org.ID); err == nil {
+		t.Fatal("outsider started a checkout for the organization")
+	}
+	forged := OrganizationSubscription{OrganizationID: org.ID, SubscriptionID: "sub_forged", Status: "active", Seats: 50}
+	if err := svc.BindSubscription(ctx, forged); err != ErrCheckoutNotIssued {
+		t.Fatalf("bind without a checkout = %v, want ErrCheckoutNotIssued", err)
+	}
+	if _, err := svc.StartCheckout(ctx, owner, org.ID); err != nil {
+		t.Fatalf("start checkout: %v", err)
+	}
+	forged.CheckoutToken = "guess"
+	if err := svc.BindSubscription(ctx, forged); err != ErrCheckoutNotIssued {
+		t.Fatalf("bind with a wrong token = %v, want ErrCheckoutNotIssued", err)
+	}
+
+	bindSubscription(t, svc, owner, org.ID, "sub_1")
+	if _, err := svc.StartCheckout(ctx, owner, org.ID); err != ErrOrgAlreadySubscribed {
+		t.Fatalf("second checkout = %v, want ErrOrgAlreadySubscribed", err)
+	}
+
+	// Updates to another subscription do not touch the organization
+	forged.CheckoutToken = ""
+	if err := svc.ApplySubscription(ctx, forged); err != ErrSubscriptionNotBound {
+		t.Fatalf("apply unbound subscription = %v, want ErrSubscriptionNotBound", err)
+	}
+
+	now := time.Now().UTC()
+	update := OrganizationSubscription{SubscriptionID: "sub_1", Status: "active", Seats: 5, OccurredAt: now.Add(time.Minute)}
+	if err := svc.ApplySubscription(ctx, update); err != nil {
+		t.Fatalf("apply subscription: %v", err)
+	}
+	stale := OrganizationSubscription{SubscriptionID: "sub_1", Status: "active", Seats: 2, OccurredAt: now}
+	if err := svc.ApplySubscription(ctx, stale); err != nil {
+		t.Fatalf("apply stale subscription: %v", err)
+	}
+	if got := seats(); got != 5 {
+		t.Fatalf("seats = %d after a stale event, want 5", got)
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"api/biz/say_right/dal/query"
+)
+
+// schedulerMaxSleep bounds how long the scheduler sleeps, so boundaries added
+// by editors after it went to sleep are still picked up in time.
+const schedulerMaxSleep = 5 * time.Minute
+
+// PublishScheduler drops the catalog cache whenever a publish_at or
+// unpublish_at boundary passes, so scheduled content appears and disappears on time.
+type PublishScheduler struct {
+	q *query.Query
+}
+
+func NewPublishScheduler() *PublishScheduler {
+	return &PublishScheduler{
+		q: query.Q,
+	}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled.
+func (s *PublishScheduler) Start(ctx context.Context) {
+	go s.run(ctx)
+}
+
+func (s *PublishScheduler) run(ctx context.Context) {
+	for {
+		now := time.Now().UTC()
+		wait := schedulerMaxSleep
+		next, err := s.nextBoundary(ctx, now)
+		if err != nil {
+			log.Printf("Publish scheduler failed to load boundaries: %v", err)
+		} else if next != nil && next.Sub(now) < wait {
+			wait = next.Sub(now)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		if next != nil && !time.Now().UTC().Before(*next) {
+			InvalidateCatalogCache(ctx)
+		}
+	}
+}
+
+// nextBoundary returns the earliest publish or unpublish time after now, or nil if none is scheduled.
+func (s *PublishScheduler) nextBoundary(ctx context.Context, now time.Time) (*time.Time, error) {
+	t := s.q.Template
+	c := s.q.Category
+
+	var next *time.Time
+	consider := func(value *time.Time) {
+		if value != nil && (next == nil || value.Before(*next)) {
+			next = value
+		}
+	}
+
+	if row, err := t.WithContext(ctx).Where(t.PublishAt.Gt(now)).Order(t.PublishAt).First(); err == nil {
+		consider(row.PublishAt)
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+	if row, err := t.WithContext(ctx).Where(t.UnpublishAt.Gt(now)).Order(t.UnpublishAt).First(); err == nil {
+		consider(row.UnpublishAt)
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+	if row, err := c.WithContext(ctx).Where(c.PublishAt.Gt(now)).Order(c.PublishAt).First(); err == nil {
+		consider(row.PublishAt)
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+	if row, err := c.WithContext(ctx).Where(c.UnpublishAt.Gt(now)).Order(c.UnpublishAt).First(); err == nil {
+		consider(row.UnpublishAt)
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+
+	return next, nil
+}
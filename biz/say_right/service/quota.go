@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/infra/redis"
+)
+
+const (
+	PlanFree = "free"
+	PlanPro  = "pro"
+)
+
+// QuotaExceededError means the user's plan has no requests left today.
+type QuotaExceededError struct {
+	Limit      int
+	RetryAfter int
+}
+
+func (e QuotaExceededError) Error() string {
+	return fmt.Sprintf("Daily limit of %d requests reached", e.Limit)
+}
+
+// DailyQuota is the user's allowance for the current UTC day.
+type DailyQuota struct {
+	Plan      string    `json:"plan"`
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// quotaPolicy counts a metered feature per user and UTC day in Redis, with a
+// limit for each plan.
+type quotaPolicy struct {
+	prefix string
+	limits map[string]int
+}
+
+// newQuotaPolicy reads the limits from <env>_FREE and <env>_PRO, falling back
+// to the given defaults.
+func newQuotaPolicy(prefix, env string, freeLimit, proLimit int) quotaPolicy {
+	return quotaPolicy{
+		prefix: prefix,
+		limits: map[string]int{
+			PlanFree: limitFromEnv(env+"_FREE", freeLimit),
+			PlanPro:  limitFromEnv(env+"_PRO", proLimit),
+		},
+	}
+}
+
+func limitFromEnv(name string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(name)); err == nil && v >= 0 {
+		return v
+	}
+	return fallback
+}
+
+func userPlan(user *model.User) string {
+	if hasPro(user) {
+		return PlanPro
+	}
+	return PlanFree
+}
+
+// hasPro reports whether the user has Pro, bought on their own, through a
+// seat in a team with an active subscription, or as time-limited Pro earned
+// from referrals.
+func hasPro(user *model.User) bool {
+	if user.IsPro != 0 || user.TeamPro != 0 {
+		return true
+	}
+	return user.ProUntil != nil && time.Now().Before(*user.ProUntil)
+}
+
+func (p quotaPolicy) current(ctx context.Context, user *model.User) DailyQuota {
+	// A missing key means nothing has been used today
+	used, _ := redis.Client.Get(ctx, p.key(user.ID, time.Now())).Int()
+	return p.quota(user, used)
+}
+
+// reserve takes one request from today's quota before the work is done, so
+// concurrent requests cannot overshoot the limit.
+func (p quotaPolicy) reserve(ctx context.Context, user *model.User) (DailyQuota, error) {
+	key := p.key(user.ID, time.Now())
+	used, err := redis.Client.Incr(ctx, key).Result()
+	if err != nil {
+		return DailyQuota{}, err
+	}
+	if used == 1 {
+		// Outlive the day so a clock skew between servers does not reset it early
+		redis.Client.Expire(ctx, key, 25*time.Hour)
+	}
+
+	quota := p.quota(user, int(used))
+	if int(used) > quota.Limit {
+		redis.Client.Decr(ctx, key)
+		return DailyQuota{}, QuotaExceededError{
+			Limit:      quota.Limit,
+			RetryAfter: int(time.Until(quota.ResetsAt).Seconds()) + 1,
+		}
+	}
+	return quota, nil
+}
+
+// release gives back a reserved request whose work failed.
+func (p quotaPolicy) release(ctx context.Context, user *model.User) {
+	redis.Client.Decr(context.WithoutCancel(ctx), p.key(user.ID, time.Now()))
+}
+
+func (p quotaPolicy) quota(user *model.User, used int) DailyQuota {
+	plan := userPlan(user)
+	limit := p.limits[plan]
+	return DailyQuota{
+		Plan:      plan,
+		Limit:     limit,
+		Used:      min(used, limit),
+		Remaining: max(limit-used, 0),
+		ResetsAt:  time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour),
+	}
+}
+
+func (p quotaPolicy) key(userID int32, now time.Time) string {
+	return p.prefix + strconv.Itoa(int(userID)) + ":" + now.UTC().Format("20060102")
+}
@@ -0,0 +1,495 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+var ErrRatingNotFound = errors.New("rating not found")
+
+const (
+	VoteUp   = "up"
+	VoteDown = "down"
+)
+
+// Comment moderation states. A rating without a comment has an empty status.
+const (
+	CommentPending  = "pending"
+	CommentApproved = "approved"
+	CommentRejected = "rejected"
+)
+
+const ratingCommentMaxLength = 1000
+
+// wilsonZ is the normal quantile for the 95% confidence bound used to rank
+// scores, so a template with 3 of 3 up votes does not beat one with 95 of 100.
+const wilsonZ = 1.96
+
+type RatingInput struct {
+	Tone string `json:"tone"`
+	// Vote is up or down.
+	Vote string `json:"vote"`
+	// Comment is optional and only shown to editors once approved.
+	Comment string `json:"comment"`
+}
+
+type RatingResult struct {
+	TemplateID    int32     `json:"template_id"`
+	Tone          string    `json:"tone"`
+	Vote          string    `json:"vote"`
+	Comment       string    `json:"comment"`
+	CommentStatus string    `json:"comment_status"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ToneScore struct {
+	Tone  string `json:"tone"`
+	Up    int    `json:"up"`
+	Down  int    `json:"down"`
+	Total int    `json:"total"`
+	// Approval is the share of up votes; Score is the lower bound of its 95%
+	// Wilson interval and is what lists are ranked by.
+	Approval float64 `json:"approval"`
+	Score    float64 `json:"score"`
+}
+
+type TemplateScore struct {
+	TemplateID int32       `json:"template_id"`
+	Slug       string      `json:"slug"`
+	Title      string      `json:"title"`
+	Overall    ToneScore   `json:"overall"`
+	Tones      []ToneScore `json:"tones"`
+}
+
+type ScoreFilter struct {
+	Since time.Time
+	// MinVotes drops templates with fewer votes in total.
+	MinVotes int
+	// Best ranks the best scores first; by default the worst come first,
+	// since those are the ones to improve.
+	Best bool
+}
+
+type RatingComment struct {
+	ID            int32      `json:"id"`
+	TemplateID    int32      `json:"template_id"`
+	TemplateTitle string     `json:"template_title"`
+	UserID        int32      `json:"user_id"`
+	Tone          string     `json:"tone"`
+	Vote          string     `json:"vote"`
+	Comment       string     `json:"comment"`
+	Status        string     `json:"status"`
+	ModeratedBy   int32      `json:"moderated_by"`
+	ModeratedAt   *time.Time `json:"moderated_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type RatingCommentPage struct {
+	Comments []RatingComment `json:"comments"`
+	Total    int64           `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+}
+
+// TemplateRatings is the editor view of one template's feedback.
+type TemplateRatings struct {
+	TemplateScore
+	Comments []RatingComment `json:"comments"`
+}
+
+// RatingService collects user feedback on template replies and reports it
+// to editors. Comments stay hidden until moderated.
+type RatingService interface {
+	// RateTemplate records the user's vote on one tone, replacing any earlier one.
+	RateTemplate(ctx context.Context, userID int32, templateID int32, input RatingInput) (*RatingResult, error)
+	ListMyRatings(ctx context.Context, userID int32, templateID int32) ([]RatingResult, error)
+
+	ListScores(ctx context.Context, filter ScoreFilter) ([]TemplateScore, error)
+	// GetTemplateRatings returns one template's scores with its approved comments.
+	GetTemplateRatings(ctx context.Context, templateID int32) (*TemplateRatings, error)
+	// ListComments lists comments in a moderation status; pending ones come
+	// oldest first so the queue is worked in order.
+	ListComments(ctx context.Context, status string, page, pageSize int) (*RatingCommentPage, error)
+	ModerateComment(ctx context.Context, ratingID int32, status string) (*RatingComment, error)
+}
+
+type ratingService struct {
+	q     *query.Query
+	audit AuditService
+}
+
+func NewRatingService(audit AuditService) RatingService {
+	return &ratingService{
+		q:     query.Q,
+		audit: audit,
+	}
+}
+
+func (s *ratingService) RateTemplate(ctx context.Context, userID int32, templateID int32, input RatingInput) (*RatingResult, error) {
+	tone, vote, comment, err := normalizeRatingInput(input)
+	if err != nil {
+		return nil, err
+	}
+	template, err := (&activityService{q: s.q}).visibleTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.IsPro != 0 {
+		user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+		if err != nil {
+			return nil, err
+		}
+		if !hasPro(user) {
+			return nil, ErrProRequired
+		}
+	}
+
+	r := s.q.TemplateRating
+	rating, err := r.WithContext(ctx).Where(r.UserID.Eq(userID), r.TemplateID.Eq(templateID), r.Tone.Eq(tone)).First()
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if rating == nil {
+		rating = &model.TemplateRating{UserID: userID, TemplateID: templateID, Tone: tone, CreatedAt: now}
+	}
+	rating.Vote = vote
+	// An edited comment goes back through moderation
+	if comment != rating.Comment || rating.ID == 0 {
+		rating.Comment = comment
+		rating.CommentStatus = ""
+		if comment != "" {
+			rating.CommentStatus = CommentPending
+		}
+		rating.ModeratedBy = 0
+		rating.ModeratedAt = nil
+	}
+	rating.UpdatedAt = now
+	if err := r.WithContext(ctx).Save(rating); err != nil {
+		return nil, err
+	}
+	result := toRatingResult(rating)
+	return &result, nil
+}
+
+func (s *ratingService) ListMyRatings(ctx context.Context, userID int32, templateID int32) ([]RatingResult, error) {
+	r := s.q.TemplateRating
+	ratings, err := r.WithContext(ctx).Where(r.UserID.Eq(userID), r.TemplateID.Eq(templateID)).Find()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]RatingResult, 0, len(ratings))
+	for _, rating := range ratings {
+		result = append(result, toRatingResult(rating))
+	}
+	return result, nil
+}
+
+func (s *ratingService) ListScores(ctx context.Context, filter ScoreFilter) ([]TemplateScore, error) {
+	scores, err := s.scores(ctx, filter.Since, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]TemplateScore, 0, len(scores))
+	for _, score := range scores {
+		if score.Overall.Total >= filter.MinVotes {
+			result = append(result, score)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].Overall, result[j].Overall
+		if a.Score != b.Score {
+			return (a.Score > b.Score) == filter.Best
+		}
+		return a.Total > b.Total
+	})
+	return result, nil
+}
+
+func (s *ratingService) GetTemplateRatings(ctx context.Context, templateID int32) (*TemplateRatings, error) {
+	scores, err := s.scores(ctx, time.Time{}, templateID)
+	if err != nil {
+		return nil, err
+	}
+	result := &TemplateRatings{}
+	if len(scores) > 0 {
+		result.TemplateScore = scores[0]
+	} else {
+		template, err := s.q.Template.WithContext(ctx).Where(s.q.Template.ID.Eq(templateID)).First()
+		if err != nil {
+			return nil, ErrTemplateNotFound
+		}
+		result.TemplateScore = TemplateScore{TemplateID: template.ID, Slug: template.Slug, Title: template.Title, Tones: []ToneScore{}}
+	}
+
+	r := s.q.TemplateRating
+	ratings, err := r.WithContext(ctx).
+		Where(r.TemplateID.Eq(templateID), r.CommentStatus.Eq(CommentApproved)).
+		Order(r.UpdatedAt.Desc()).
+		Limit(AdminMaxPageSize).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	result.Comments, err = s.toRatingComments(ctx, ratings)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *ratingService) ListComments(ctx context.Context, status string, page, pageSize int) (*RatingCommentPage, error) {
+	if status == "" {
+		status = CommentPending
+	}
+	if err := validateCommentStatus(status, true); err != nil {
+		return nil, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = AdminDefaultPageSize
+	}
+	if pageSize > AdminMaxPageSize {
+		pageSize = AdminMaxPageSize
+	}
+
+	r := s.q.TemplateRating
+	do := r.WithContext(ctx).Where(r.CommentStatus.Eq(status))
+	if status == CommentPending {
+		do = do.Order(r.UpdatedAt, r.ID)
+	} else {
+		do = do.Order(r.UpdatedAt.Desc(), r.ID.Desc())
+	}
+	ratings, total, err := do.FindByPage((page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.toRatingComments(ctx, ratings)
+	if err != nil {
+		return nil, err
+	}
+	return &RatingCommentPage{Comments: comments, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+func (s *ratingService) ModerateComment(ctx context.Context, ratingID int32, status string) (*RatingComment, error) {
+	if err := validateCommentStatus(status, false); err != nil {
+		return nil, err
+	}
+
+	var moderated *model.TemplateRating
+	err := s.q.Transaction(func(tx *query.Query) error {
+		current, err := tx.TemplateRating.WithContext(ctx).Where(tx.TemplateRating.ID.Eq(ratingID)).First()
+		if err != nil || current.Comment == "" {
+			return ErrRatingNotFound
+		}
+
+		next := *current
+		now := time.Now().UTC()
+		next.CommentStatus = status
+		next.ModeratedBy = ActorFromContext(ctx).ID
+		next.ModeratedAt = &now
+		if err := tx.TemplateRating.WithContext(ctx).Save(&next); err != nil {
+			return err
+		}
+		moderated = &next
+
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.rating_comment.moderate",
+			TargetType: "template_rating",
+			TargetID:   strconv.Itoa(int(ratingID)),
+			Before:     map[string]string{"status": current.CommentStatus},
+			After:      map[string]string{"status": next.CommentStatus},
+			Detail:     map[string]any{"template_id": current.TemplateID, "comment": current.Comment},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.toRatingComments(ctx, []*model.TemplateRating{moderated})
+	if err != nil {
+		return nil, err
+	}
+	return &comments[0], nil
+}
+
+// scores aggregates votes per template and tone, for one template when
+// templateID is set. Tones come in the order of Tones.
+func (s *ratingService) scores(ctx context.Context, since time.Time, templateID int32) ([]TemplateScore, error) {
+	var rows []struct {
+		TemplateID int32
+		Tone       string
+		Up         int
+		Down       int
+	}
+	db := s.q.TemplateRating.WithContext(ctx).UnderlyingDB().
+		Model(&model.TemplateRating{}).
+		Select(`template_id, tone,
+			SUM(CASE WHEN vote > 0 THEN 1 ELSE 0 END) AS up,
+			SUM(CASE WHEN vote < 0 THEN 1 ELSE 0 END) AS down`)
+	if !since.IsZero() {
+		db = db.Where("updated_at >= ?", since)
+	}
+	if templateID > 0 {
+		db = db.Where("template_id = ?", templateID)
+	}
+	if err := db.Group("template_id, tone").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	byTemplate := make(map[int32]*TemplateScore)
+	ids := make([]int32, 0)
+	for _, row := range rows {
+		score, ok := byTemplate[row.TemplateID]
+		if !ok {
+			score = &TemplateScore{TemplateID: row.TemplateID}
+			byTemplate[row.TemplateID] = score
+			ids = append(ids, row.TemplateID)
+		}
+		score.Tones = append(score.Tones, toneScore(row.Tone, row.Up, row.Down))
+		score.Overall = toneScore("", score.Overall.Up+row.Up, score.Overall.Down+row.Down)
+	}
+	if len(ids) == 0 {
+		return []TemplateScore{}, nil
+	}
+
+	templates, err := s.q.Template.WithContext(ctx).Where(s.q.Template.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		byTemplate[t.ID].Slug = t.Slug
+		byTemplate[t.ID].Title = t.Title
+	}
+
+	toneOrder := make(map[string]int, len(Tones))
+	for i, tone := range Tones {
+		toneOrder[tone] = i
+	}
+	result := make([]TemplateScore, 0, len(ids))
+	for _, id := range ids {
+		score := byTemplate[id]
+		sort.Slice(score.Tones, func(i, j int) bool {
+			return toneOrder[score.Tones[i].Tone] < toneOrder[score.Tones[j].Tone]
+		})
+		result = append(result, *score)
+	}
+	return result, nil
+}
+
+func (s *ratingService) toRatingComments(ctx context.Context, ratings []*model.TemplateRating) ([]RatingComment, error) {
+	ids := make([]int32, 0, len(ratings))
+	for _, r := range ratings {
+		ids = append(ids, r.TemplateID)
+	}
+	titles := make(map[int32]string, len(ids))
+	if len(ids) > 0 {
+		templates, err := s.q.Template.WithContext(ctx).Where(s.q.Template.ID.In(ids...)).Find()
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range templates {
+			titles[t.ID] = t.Title
+		}
+	}
+
+	result := make([]RatingComment, 0, len(ratings))
+	for _, r := range ratings {
+		result = append(result, RatingComment{
+			ID:            r.ID,
+			TemplateID:    r.TemplateID,
+			TemplateTitle: titles[r.TemplateID],
+			UserID:        r.UserID,
+			Tone:          r.Tone,
+			Vote:          voteName(r.Vote),
+			Comment:       r.Comment,
+			Status:        r.CommentStatus,
+			ModeratedBy:   r.ModeratedBy,
+			ModeratedAt:   r.ModeratedAt,
+			UpdatedAt:     r.UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+func normalizeRatingInput(input RatingInput) (string, int32, string, error) {
+	if strings.TrimSpace(input.Tone) == "" {
+		return "", 0, "", ValidationError{Field: "tone", Message: "is required"}
+	}
+	tone, err := normalizeTone(input.Tone)
+	if err != nil {
+		return "", 0, "", err
+	}
+
+	var vote int32
+	switch strings.ToLower(strings.TrimSpace(input.Vote)) {
+	case VoteUp:
+		vote = 1
+	case VoteDown:
+		vote = -1
+	default:
+		return "", 0, "", ValidationError{Field: "vote", Message: "must be up or down"}
+	}
+
+	comment := strings.TrimSpace(input.Comment)
+	if err := limitText("comment", comment, ratingCommentMaxLength); err != nil {
+		return "", 0, "", err
+	}
+	return tone, vote, comment, nil
+}
+
+func validateCommentStatus(status string, allowPending bool) error {
+	switch status {
+	case CommentApproved, CommentRejected:
+		return nil
+	case CommentPending:
+		if allowPending {
+			return nil
+		}
+	}
+	if allowPending {
+		return ValidationError{Field: "status", Message: "must be pending, approved or rejected"}
+	}
+	return ValidationError{Field: "status", Message: "must be approved or rejected"}
+}
+
+func toRatingResult(r *model.TemplateRating) RatingResult {
+	return RatingResult{
+		TemplateID:    r.TemplateID,
+		Tone:          r.Tone,
+		Vote:          voteName(r.Vote),
+		Comment:       r.Comment,
+		CommentStatus: r.CommentStatus,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+func voteName(vote int32) string {
+	if vote > 0 {
+		return VoteUp
+	}
+	return VoteDown
+}
+
+func toneScore(tone string, up, down int) ToneScore {
+	score := ToneScore{Tone: tone, Up: up, Down: down, Total: up + down}
+	if score.Total == 0 {
+		return score
+	}
+	n := float64(score.Total)
+	p := float64(up) / n
+	z2 := wilsonZ * wilsonZ
+	score.Approval = p
+	score.Score = math.Max(0, (p+z2/(2*n)-wilsonZ*math.Sqrt((p*(1-p)+z2/(4*n))/n))/(1+z2/n))
+	return score
+}
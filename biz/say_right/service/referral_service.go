@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ReferralPending  = "pending"
+	ReferralRewarded = "rewarded"
+	ReferralRejected = "rejected"
+)
+
+// Reasons a referral was rejected.
+const (
+	ReferralReasonSameIP          = "same_ip"
+	ReferralReasonDisposableEmail = "disposable_email"
+)
+
+const defaultReferralRewardDays = 30
+
+// referralListLimit caps the referrals listed on the dashboard; the counts
+// cover all of them.
+const referralListLimit = 50
+
+// referralCodeAlphabet leaves out 0/O and 1/I, which are easy to mix up when
+// a code is typed in.
+const referralCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+const referralCodeLength = 8
+
+// disposableEmailDomains are throwaway mail services. DISPOSABLE_EMAIL_DOMAINS
+// adds more as a comma-separated list.
+var disposableEmailDomains = []string{
+	"10minutemail.com",
+	"dispostable.com",
+	"fakeinbox.com",
+	"getnada.com",
+	"guerrillamail.com",
+	"mailinator.com",
+	"maildrop.cc",
+	"sharklasers.com",
+	"temp-mail.org",
+	"throwawaymail.com",
+	"trashmail.com",
+	"yopmail.com",
+}
+
+type ReferralItem struct {
+	// Email is masked; referrers only need to recognize who signed up.
+	Email      string     `json:"email"`
+	Status     string     `json:"status"`
+	Reason     string     `json:"reason,omitempty"`
+	RewardDays int        `json:"reward_days"`
+	RewardedAt *time.Time `json:"rewarded_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ReferralDashboard struct {
+	Code string `json:"code"`
+	URL  string `json:"url"`
+	// RewardDays is the Pro time each paying referral earns.
+	RewardDays int        `json:"reward_days"`
+	ProUntil   *time.Time `json:"pro_until"`
+	SignedUp   int        `json:"signed_up"`
+	Pending    int        `json:"pending"`
+	Rewarded   int        `json:"rewarded"`
+	Rejected   int        `json:"rejected"`
+	DaysEarned int        `json:"days_earned"`
+	// Referrals are the most recent, newest first.
+	Referrals []ReferralItem `json:"referrals"`
+}
+
+// ReferralService runs the referral program. A new user who signs up with
+// someone's code is attributed to them, and once the new user pays the
+// referrer gets Pro time. Sign-ups that look like self-referrals are kept
+// but rejected, so they never earn anything.
+type ReferralService interface {
+	// AttributeSignup links a user who was just created to the owner of code.
+	// Unknown codes are ignored.
+	AttributeSignup(ctx context.Context, referee *model.User, code string) error
+	// RewardReferral grants the referrer Pro time for the referee's first paid
+	// transaction. Later transactions and retried webhooks grant nothing.
+	RewardReferral(ctx context.Context, refereeEmail string, transactionID string) error
+	// GetDashboard creates the user's code the first time it is asked for.
+	GetDashboard(ctx context.Context, userID int32) (*ReferralDashboard, error)
+}
+
+type referralService struct {
+	q          *query.Query
+	audit      AuditService
+	siteURL    string
+	rewardDays int
+	disposable []string
+}
+
+// NewReferralService reads the reward from REFERRAL_REWARD_DAYS and builds
+// referral links from SAYRIGHT_SITE_URL.
+func NewReferralService(audit AuditService) ReferralService {
+	disposable := slices.Clone(disposableEmailDomains)
+	for _, domain := range strings.Split(os.Getenv("DISPOSABLE_EMAIL_DOMAINS"), ",") {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			disposable = append(disposable, domain)
+		}
+	}
+	return &referralService{
+		q:          query.Q,
+		audit:      audit,
+		siteURL:    siteURLFromEnv(),
+		rewardDays: limitFromEnv("REFERRAL_REWARD_DAYS", defaultReferralRewardDays),
+		disposable: disposable,
+	}
+}
+
+func (s *referralService) AttributeSignup(ctx context.Context, referee *model.User, code string) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil
+	}
+	referrer, err := s.q.User.WithContext(ctx).Where(s.q.User.ReferralCode.Eq(code)).First()
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if referrer.ID == referee.ID {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	referral := &model.Referral{
+		ReferrerID: referrer.ID,
+		RefereeID:  referee.ID,
+		Status:     ReferralPending,
+		SignupIP:   ActorFromContext(ctx).IP,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if s.isDisposable(referee.EmailNorm) {
+		referral.Status = ReferralRejected
+		referral.Reason = ReferralReasonDisposableEmail
+	} else {
+		shared, err := s.sharesIP(ctx, s.q, referrer.ID, []string{referral.SignupIP})
+		if err != nil {
+			return err
+		}
+		if shared {
+			referral.Status = ReferralRejected
+			referral.Reason = ReferralReasonSameIP
+		}
+	}
+	return s.q.Referral.WithContext(ctx).Create(referral)
+}
+
+func (s *referralService) RewardReferral(ctx context.Context, refereeEmail string, transactionID string) error {
+	email := strings.ToLower(strings.TrimSpace(refereeEmail))
+	referee, err := s.q.User.WithContext(ctx).Where(s.q.User.EmailNorm.Eq(email)).First()
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return s.q.Transaction(func(tx *query.Query) error {
+		r := tx.Referral
+		referral, err := r.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(r.RefereeID.Eq(referee.ID)).First()
+		if err != nil {
+			if isNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if referral.Status != ReferralPending {
+			return nil
+		}
+
+		// The two may have shared a network since sign-up, e.g. the referee
+		// only ever logging in from the referrer's machine
+		ips, err := s.userIPs(ctx, tx, referee.ID)
+		if err != nil {
+			return err
+		}
+		shared, err := s.sharesIP(ctx, tx, referral.ReferrerID, append(ips, referral.SignupIP))
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		referral.UpdatedAt = now
+		if shared {
+			referral.Status = ReferralRejected
+			referral.Reason = ReferralReasonSameIP
+			return r.WithContext(ctx).Save(referral)
+		}
+
+		referrer, err := tx.User.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(tx.User.ID.Eq(referral.ReferrerID)).First()
+		if err != nil {
+			return err
+		}
+		// Time earned while Pro time is still left is added on top of it
+		before := referrer.ProUntil
+		start := now
+		if before != nil && before.After(now) {
+			start = *before
+		}
+		until := start.AddDate(0, 0, s.rewardDays)
+		if _, err := tx.User.WithContext(ctx).Where(tx.User.ID.Eq(referrer.ID)).UpdateSimple(tx.User.ProUntil.Value(until)); err != nil {
+			return err
+		}
+
+		referral.Status = ReferralRewarded
+		referral.RewardDays = int32(s.rewardDays)
+		referral.TransactionID = transactionID
+		referral.RewardedAt = &now
+		if err := r.WithContext(ctx).Save(referral); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "billing.referral_reward",
+			TargetType: "user",
+			TargetID:   strconv.Itoa(int(referrer.ID)),
+			Before:     map[string]any{"pro_until": before},
+			After:      map[string]any{"pro_until": until},
+			Detail: map[string]any{
+				"referee_id":     referee.ID,
+				"transaction_id": transactionID,
+				"days":           s.rewardDays,
+			},
+		})
+	})
+}
+
+func (s *referralService) GetDashboard(ctx context.Context, userID int32) (*ReferralDashboard, error) {
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, err
+	}
+	code, err := s.ensureCode(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReferralDashboard{
+		Code:       code,
+		URL:        s.siteURL + "/r/" + code,
+		RewardDays: s.rewardDays,
+		ProUntil:   user.ProUntil,
+		Referrals:  []ReferralItem{},
+	}
+
+	r := s.q.Referral
+	var counts []struct {
+		Status string
+		Count  int
+		Days   int
+	}
+	err = r.WithContext(ctx).UnderlyingDB().
+		Model(&model.Referral{}).
+		Select("status, COUNT(*) AS count, SUM(reward_days) AS days").
+		Where("referrer_id = ?", userID).
+		Group("status").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range counts {
+		result.SignedUp += c.Count
+		result.DaysEarned += c.Days
+		switch c.Status {
+		case ReferralPending:
+			result.Pending = c.Count
+		case ReferralRewarded:
+			result.Rewarded = c.Count
+		case ReferralRejected:
+			result.Rejected = c.Count
+		}
+	}
+
+	referrals, err := r.WithContext(ctx).Where(r.ReferrerID.Eq(userID)).Order(r.CreatedAt.Desc(), r.ID.Desc()).Limit(referralListLimit).Find()
+	if err != nil || len(referrals) == 0 {
+		return result, err
+	}
+	ids := make([]int32, 0, len(referrals))
+	for _, referral := range referrals {
+		ids = append(ids, referral.RefereeID)
+	}
+	referees, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	emails := make(map[int32]string, len(referees))
+	for _, referee := range referees {
+		emails[referee.ID] = maskEmail(referee.Email)
+	}
+	for _, referral := range referrals {
+		result.Referrals = append(result.Referrals, ReferralItem{
+			Email:      emails[referral.RefereeID],
+			Status:     referral.Status,
+			Reason:     referral.Reason,
+			RewardDays: int(referral.RewardDays),
+			RewardedAt: referral.RewardedAt,
+			CreatedAt:  referral.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// ensureCode returns the user's referral code, creating one if they have none.
+// Users get a code when they are created; this covers rows the migration
+// backfill missed.
+func (s *referralService) ensureCode(ctx context.Context, user *model.User) (string, error) {
+	if user.ReferralCode != nil {
+		return *user.ReferralCode, nil
+	}
+
+	u := s.q.User
+	code, err := unusedReferralCode(ctx, s.q)
+	if err != nil {
+		return "", err
+	}
+	// Only fill an empty code, so two requests racing agree on one
+	info, err := u.WithContext(ctx).Where(u.ID.Eq(user.ID), u.ReferralCode.IsNull()).UpdateSimple(u.ReferralCode.Value(code))
+	if err != nil {
+		return "", err
+	}
+	if info.RowsAffected == 0 {
+		current, err := u.WithContext(ctx).Where(u.ID.Eq(user.ID)).First()
+		if err != nil {
+			return "", err
+		}
+		if current.ReferralCode == nil {
+			return "", errors.New("failed to save referral code")
+		}
+		code = *current.ReferralCode
+	}
+	user.ReferralCode = &code
+	return code, nil
+}
+
+// unusedReferralCode generates a referral code no user has yet.
+func unusedReferralCode(ctx context.Context, q *query.Query) (string, error) {
+	u := q.User
+	for i := 0; i < 5; i++ {
+		code, err := generateReferralCode()
+		if err != nil {
+			return "", err
+		}
+		taken, err := u.WithContext(ctx).Where(u.ReferralCode.Eq(code)).Count()
+		if err != nil {
+			return "", err
+		}
+		if taken == 0 {
+			return code, nil
+		}
+	}
+	return "", errors.New("failed to generate unique referral code")
+}
+
+// sharesIP reports whether the user was ever seen at one of ips, going by the
+// audit log of their own actions.
+func (s *referralService) sharesIP(ctx context.Context, q *query.Query, userID int32, ips []string) (bool, error) {
+	ips = slices.DeleteFunc(ips, func(ip string) bool { return ip == "" })
+	if len(ips) == 0 {
+		return false, nil
+	}
+	a := q.AuditLog
+	count, err := a.WithContext(ctx).Where(a.ActorID.Eq(userID), a.ActorType.Eq(ActorTypeUser), a.IP.In(ips...)).Count()
+	return count > 0, err
+}
+
+// userIPs lists the addresses the user acted from.
+func (s *referralService) userIPs(ctx context.Context, q *query.Query, userID int32) ([]string, error) {
+	a := q.AuditLog
+	var ips []string
+	err := a.WithContext(ctx).Where(a.ActorID.Eq(userID), a.ActorType.Eq(ActorTypeUser), a.IP.Neq("")).Distinct(a.IP).Pluck(a.IP, &ips)
+	return ips, err
+}
+
+// isDisposable matches the email's domain and its parent domains, so
+// subdomains of a throwaway service are caught too.
+func (s *referralService) isDisposable(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for {
+		if slices.Contains(s.disposable, domain) {
+			return true
+		}
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+// maskEmail keeps the first character of the local part and the domain.
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 1 {
+		return "***"
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+func generateReferralCode() (string, error) {
+	b := make([]byte, referralCodeLength)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(referralCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = referralCodeAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
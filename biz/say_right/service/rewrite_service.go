@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+	"api/infra/llm"
+)
+
+const KeyPrefixRewrite = "biz:say_right:rewrite:" // user id + UTC day -> rewrites used
+
+const (
+	defaultFreeRewriteLimit = 10
+	defaultProRewriteLimit  = 200
+)
+
+const (
+	rewriteMaxLength       = 4000
+	rewriteMaxVariants     = 3
+	rewriteDefaultVariants = 2
+	rewriteMaxTokens       = 1500
+	// Sliders run from 1 to 5; 3 keeps the draft as it is
+	sliderMin    = 1
+	sliderMiddle = 3
+	sliderMax    = 5
+)
+
+const rewriteSystemPrompt = `You rewrite workplace messages so they land well.
+Keep the writer's meaning, facts and language; change only how it is said.
+Answer with JSON only, no code fence: {"variants": ["..."], "explanation": "..."}
+where explanation is one or two sentences on what you changed and why.`
+
+var formalityDescriptions = map[int]string{
+	1: "much more casual",
+	2: "a little more casual",
+	3: "as formal as the draft",
+	4: "a little more formal",
+	5: "much more formal",
+}
+
+var lengthDescriptions = map[int]string{
+	1: "much shorter",
+	2: "a little shorter",
+	3: "about as long as the draft",
+	4: "a little longer",
+	5: "much longer",
+}
+
+type RewriteInput struct {
+	Text string `json:"text"`
+	// Tone uses the same vocabulary as template replies: soft, neutral or
+	// firm. Empty means neutral.
+	Tone string `json:"tone"`
+	// Formality runs from 1 (casual) to 5 (formal) and Length from 1
+	// (shorter) to 5 (longer); 0 means 3, keeping the draft's.
+	Formality int `json:"formality"`
+	Length    int `json:"length"`
+	// Variants is how many alternatives to return, 1 to 3; 0 means 2.
+	Variants int `json:"variants"`
+}
+
+type RewriteResult struct {
+	Tone        string     `json:"tone"`
+	Formality   int        `json:"formality"`
+	Length      int        `json:"length"`
+	Variants    []string   `json:"variants"`
+	Explanation string     `json:"explanation"`
+	Provider    string     `json:"provider"`
+	Model       string     `json:"model"`
+	Usage       TokenUsage `json:"usage"`
+	Quota       DailyQuota `json:"quota"`
+}
+
+type RewriteService interface {
+	// Rewrite returns variants of the user's own draft in the requested tone.
+	Rewrite(ctx context.Context, userID int32, input RewriteInput) (*RewriteResult, error)
+	GetQuota(ctx context.Context, userID int32) (*DailyQuota, error)
+}
+
+type rewriteService struct {
+	q     *query.Query
+	quota quotaPolicy
+}
+
+// NewRewriteService reads the daily plan limits from REWRITE_DAILY_LIMIT_FREE
+// and REWRITE_DAILY_LIMIT_PRO.
+func NewRewriteService() RewriteService {
+	return &rewriteService{
+		q:     query.Q,
+		quota: newQuotaPolicy(KeyPrefixRewrite, "REWRITE_DAILY_LIMIT", defaultFreeRewriteLimit, defaultProRewriteLimit),
+	}
+}
+
+func (s *rewriteService) Rewrite(ctx context.Context, userID int32, input RewriteInput) (*RewriteResult, error) {
+	input, err := normalizeRewriteInput(input)
+	if err != nil {
+		return nil, err
+	}
+	provider := llm.Client
+	if provider == nil {
+		return nil, ErrGenerationDisabled
+	}
+
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, err
+	}
+	quota, err := s.quota.reserve(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &model.GenerationUsage{UserID: userID, Kind: UsageRewrite, Tone: input.Tone}
+	req := rewriteRequest(input)
+	resp, err := callProvider(ctx, s.q, provider, usage, func(ctx context.Context) (*llm.Response, error) {
+		return provider.Generate(ctx, req)
+	})
+	if err != nil {
+		if err == ErrGenerationFailed || err == ErrGenerationTimeout {
+			s.quota.release(ctx, user)
+		}
+		return nil, err
+	}
+
+	variants, explanation := parseRewriteOutput(resp.Text, input.Variants)
+	result := &RewriteResult{
+		Tone:        input.Tone,
+		Formality:   input.Formality,
+		Length:      input.Length,
+		Variants:    variants,
+		Explanation: explanation,
+		Provider:    provider.Name(),
+		Model:       usage.Model,
+		Quota:       quota,
+	}
+	result.Usage.add(usage)
+	return result, nil
+}
+
+func (s *rewriteService) GetQuota(ctx context.Context, userID int32) (*DailyQuota, error) {
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, err
+	}
+	quota := s.quota.current(ctx, user)
+	return &quota, nil
+}
+
+func normalizeRewriteInput(input RewriteInput) (RewriteInput, error) {
+	input.Text = strings.TrimSpace(input.Text)
+	if err := requireText("text", input.Text, rewriteMaxLength); err != nil {
+		return input, err
+	}
+
+	if strings.TrimSpace(input.Tone) == "" {
+		input.Tone = ToneNeutral
+	}
+	tone, err := normalizeTone(input.Tone)
+	if err != nil {
+		return input, err
+	}
+	input.Tone = tone
+
+	sliders := []struct {
+		field string
+		value *int
+	}{
+		{"formality", &input.Formality},
+		{"length", &input.Length},
+	}
+	for _, slider := range sliders {
+		if *slider.value == 0 {
+			*slider.value = sliderMiddle
+		}
+		if *slider.value < sliderMin || *slider.value > sliderMax {
+			return input, ValidationError{Field: slider.field, Message: fmt.Sprintf("must be between %d and %d", sliderMin, sliderMax)}
+		}
+	}
+
+	if input.Variants == 0 {
+		input.Variants = rewriteDefaultVariants
+	}
+	if input.Variants < 1 || input.Variants > rewriteMaxVariants {
+		return input, ValidationError{Field: "variants", Message: fmt.Sprintf("must be between 1 and %d", rewriteMaxVariants)}
+	}
+	return input, nil
+}
+
+func rewriteRequest(input RewriteInput) llm.Request {
+	instructions := fmt.Sprintf("Rewrite the draft below %d different ways. Make each one %s, %s and %s.",
+		input.Variants,
+		toneDescriptions[input.Tone],
+		formalityDescriptions[input.Formality],
+		lengthDescriptions[input.Length],
+	)
+	return llm.Request{
+		System: rewriteSystemPrompt,
+		Messages: []llm.Message{{
+			Role:    llm.RoleUser,
+			Content: instructions + "\n\nDraft:\n" + input.Text,
+		}},
+		MaxTokens:   rewriteMaxTokens,
+		Temperature: generationTemp,
+	}
+}
+
+// parseRewriteOutput reads the JSON answer asked for in the system prompt.
+// Models sometimes wrap it in prose or a code fence, and some ignore the
+// format altogether; then the whole answer is the single variant.
+func parseRewriteOutput(text string, limit int) ([]string, string) {
+	var out struct {
+		Variants    []string `json:"variants"`
+		Explanation string   `json:"explanation"`
+	}
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start >= 0 && end > start && json.Unmarshal([]byte(text[start:end+1]), &out) == nil {
+		variants := make([]string, 0, len(out.Variants))
+		for _, v := range out.Variants {
+			if v = strings.TrimSpace(v); v != "" && len(variants) < limit {
+				variants = append(variants, v)
+			}
+		}
+		if len(variants) > 0 {
+			return variants, strings.TrimSpace(out.Explanation)
+		}
+	}
+	return []string{strings.TrimSpace(text)}, ""
+}
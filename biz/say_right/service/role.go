@@ -0,0 +1,74 @@
+package service
+
+import "sort"
+
+const (
+	RoleUser          = "user"
+	RoleAdmin         = "admin"
+	RoleContentEditor = "content_editor"
+	RoleSupport       = "support"
+	RoleAnalyst       = "analyst"
+)
+
+const (
+	PermUsersRead        = "users.read"
+	PermUsersWrite       = "users.write"
+	PermUsersImpersonate = "users.impersonate"
+	PermBillingRead      = "billing.read"
+	PermRolesManage      = "roles.manage"
+	PermContentRead      = "content.read"
+	PermContentWrite     = "content.write"
+	PermAuditRead        = "audit.read"
+)
+
+// rolePermissions is the single source of truth for what each staff role may do.
+// Regular users have no entry and therefore no permissions.
+var rolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermUsersRead, PermUsersWrite, PermUsersImpersonate, PermBillingRead,
+		PermRolesManage, PermContentRead, PermContentWrite, PermAuditRead,
+	},
+	RoleContentEditor: {
+		PermContentRead, PermContentWrite,
+	},
+	RoleSupport: {
+		PermUsersRead, PermUsersWrite, PermUsersImpersonate, PermBillingRead, PermContentRead,
+	},
+	RoleAnalyst: {
+		PermUsersRead, PermBillingRead, PermContentRead, PermAuditRead,
+	},
+}
+
+type RoleInfo struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+func IsValidRole(role string) bool {
+	if role == RoleUser {
+		return true
+	}
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+func RoleHasPermission(role, permission string) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// ListRoles returns the staff roles with their permissions, sorted by role name.
+func ListRoles() []RoleInfo {
+	result := make([]RoleInfo, 0, len(rolePermissions))
+	for role, perms := range rolePermissions {
+		result = append(result, RoleInfo{Role: role, Permissions: perms})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Role < result[j].Role
+	})
+	return result
+}
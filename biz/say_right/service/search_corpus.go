@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+// Field weights used when ranking matches; titles and tags say more about a
+// template than a sentence buried in one of its replies.
+var searchFieldWeights = map[string]float64{
+	"title":         3,
+	"tags":          2.5,
+	"headline":      2,
+	"description":   1.5,
+	"summary":       1.5,
+	"reply_soft":    1,
+	"reply_neutral": 1,
+	"reply_firm":    1,
+}
+
+var searchStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "at": true, "for": true, "i": true, "in": true,
+	"is": true, "it": true, "me": true, "my": true, "of": true, "on": true, "or": true,
+	"the": true, "to": true, "with": true,
+}
+
+// catalogGeneration is bumped on every InvalidateCatalogCache so in-process
+// caches built from the catalog know to rebuild.
+var catalogGeneration atomic.Uint64
+
+// searchDocument is one visible template with everything search needs.
+type searchDocument struct {
+	Template *model.Template
+	Detail   *model.TemplateDetail
+	Category *model.Category
+	// Tags are the template's linked tags, in its tag order.
+	Tags []*model.Tag
+}
+
+func (d *searchDocument) fields() map[string]string {
+	return map[string]string{
+		"title":         d.Template.Title,
+		"description":   d.Template.Description,
+		"tags":          tagSearchText(d.Tags),
+		"headline":      d.Detail.Headline,
+		"summary":       d.Detail.Summary,
+		"reply_soft":    d.Detail.ReplySoft,
+		"reply_neutral": d.Detail.ReplyNeutral,
+		"reply_firm":    d.Detail.ReplyFirm,
+	}
+}
+
+type searchPosting struct {
+	TemplateID int32
+	Weight     float64 // sum of field weights over every occurrence
+}
+
+// searchCorpus is the visible catalog with an inverted index over it. The
+// index doubles as the vocabulary used for typo correction.
+type searchCorpus struct {
+	Documents map[int32]*searchDocument
+	Postings  map[string][]searchPosting
+}
+
+var searchCorpusCache struct {
+	sync.Mutex
+	corpus     *searchCorpus
+	generation uint64
+	loadedAt   time.Time
+}
+
+// loadSearchCorpus returns the in-process corpus, rebuilding it after catalog
+// edits or once it is older than CatalogCacheTTL.
+func loadSearchCorpus(ctx context.Context, q *query.Query) (*searchCorpus, error) {
+	searchCorpusCache.Lock()
+	defer searchCorpusCache.Unlock()
+
+	generation := catalogGeneration.Load()
+	if c := searchCorpusCache.corpus; c != nil && searchCorpusCache.generation == generation &&
+		time.Since(searchCorpusCache.loadedAt) < CatalogCacheTTL {
+		return c, nil
+	}
+
+	corpus, err := buildSearchCorpus(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	searchCorpusCache.corpus = corpus
+	searchCorpusCache.generation = generation
+	searchCorpusCache.loadedAt = time.Now()
+	return corpus, nil
+}
+
+func buildSearchCorpus(ctx context.Context, q *query.Query) (*searchCorpus, error) {
+	catalog, err := loadCatalog(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[int32]*model.Category, len(catalog.Categories))
+	for _, c := range catalog.Categories {
+		categories[c.ID] = c
+	}
+	templateIDs := make([]int32, 0, len(catalog.Templates))
+	for _, t := range catalog.Templates {
+		templateIDs = append(templateIDs, t.ID)
+	}
+	details := make(map[int32]*model.TemplateDetail, len(templateIDs))
+	if len(templateIDs) > 0 {
+		rows, err := q.TemplateDetail.WithContext(ctx).
+			Where(q.TemplateDetail.TemplateID.In(templateIDs...)).
+			Find()
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range rows {
+			details[d.TemplateID] = d
+		}
+	}
+
+	tags := catalog.tagsByTemplate()
+	corpus := &searchCorpus{
+		Documents: make(map[int32]*searchDocument, len(catalog.Templates)),
+		Postings:  make(map[string][]searchPosting),
+	}
+	for _, t := range catalog.Templates {
+		category, ok := categories[t.CategoryID]
+		detail, hasDetail := details[t.ID]
+		if !ok || !hasDetail {
+			continue
+		}
+		doc := &searchDocument{Template: t, Detail: detail, Category: category, Tags: tags[t.ID]}
+		corpus.Documents[t.ID] = doc
+
+		weights := make(map[string]float64)
+		for field, text := range doc.fields() {
+			for _, term := range searchTerms(text) {
+				weights[term] += searchFieldWeights[field]
+			}
+		}
+		for term, weight := range weights {
+			corpus.Postings[term] = append(corpus.Postings[term], searchPosting{TemplateID: t.ID, Weight: weight})
+		}
+	}
+	return corpus, nil
+}
+
+// idf is the inverse document frequency of term, higher for rarer terms.
+func (c *searchCorpus) idf(term string) float64 {
+	n := float64(len(c.Documents))
+	df := float64(len(c.Postings[term]))
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+// correct replaces terms missing from the corpus with the closest known term
+// within the typo budget for their length. changed reports whether any term moved.
+func (c *searchCorpus) correct(terms []string) (corrected []string, changed bool) {
+	corrected = make([]string, len(terms))
+	for i, term := range terms {
+		corrected[i] = term
+		if _, ok := c.Postings[term]; ok || c.hasPrefix(term) {
+			continue
+		}
+		budget := typoBudget(term)
+		if budget == 0 {
+			continue
+		}
+
+		best, bestDistance, bestDF := "", budget+1, 0
+		for candidate, postings := range c.Postings {
+			if abs(utf8.RuneCountInString(candidate)-utf8.RuneCountInString(term)) > budget {
+				continue
+			}
+			d := editDistance(term, candidate)
+			if d < bestDistance || (d == bestDistance && len(postings) > bestDF) {
+				best, bestDistance, bestDF = candidate, d, len(postings)
+			}
+		}
+		if best != "" {
+			corrected[i] = best
+			changed = true
+		}
+	}
+	return corrected, changed
+}
+
+// hasPrefix reports whether term starts some indexed word, so half-typed
+// queries are not "corrected" away.
+func (c *searchCorpus) hasPrefix(term string) bool {
+	if utf8.RuneCountInString(term) < 3 {
+		return false
+	}
+	for candidate := range c.Postings {
+		if len(candidate) > len(term) && strings.HasPrefix(candidate, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchTerms lowercases text and splits it into words, dropping stop words.
+// Chinese, Japanese and Korean runs become overlapping bigrams, the same
+// tokens MySQL's ngram parser indexes, since those scripts do not separate
+// words with spaces.
+func searchTerms(text string) []string {
+	terms := make([]string, 0)
+	eachSearchToken([]rune(text), func(token string, cjk bool, _, _ int) {
+		if cjk || (utf8.RuneCountInString(token) >= 2 && !searchStopWords[token]) {
+			terms = append(terms, token)
+		}
+	})
+	return terms
+}
+
+// eachSearchToken calls fn with every lowercased token of text and its rune
+// offsets: whole words outside CJK scripts, and bigrams (or a lone character)
+// within CJK runs. Indexing and highlighting both tokenize through it so a
+// term that matched can always be marked.
+func eachSearchToken(text []rune, fn func(token string, cjk bool, start, end int)) {
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+	start := -1
+	cjk := false
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		switch {
+		case cjk && end-start == 1:
+			fn(string(lower[start:end]), true, start, end)
+		case cjk:
+			for i := start; i+1 < end; i++ {
+				fn(string(lower[i:i+2]), true, i, i+2)
+			}
+		default:
+			fn(string(lower[start:end]), false, start, end)
+		}
+		start = -1
+	}
+	for i, r := range lower {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush(i)
+			continue
+		}
+		if start >= 0 && isCJK(r) != cjk {
+			flush(i)
+		}
+		if start < 0 {
+			start, cjk = i, isCJK(r)
+		}
+	}
+	flush(len(lower))
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+func typoBudget(term string) int {
+	switch n := utf8.RuneCountInString(term); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// editDistance is the Damerau-Levenshtein distance (with adjacent swaps) between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
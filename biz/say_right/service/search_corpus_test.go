@@ -0,0 +1,45 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Decline the meeting, politely!", []string{"decline", "meeting", "politely"}},
+		{"拒绝会议", []string{"拒绝", "绝会", "会议"}},
+		{"谢", []string{"谢"}},
+		{"Say no 礼貌地拒绝", []string{"say", "no", "礼貌", "貌地", "地拒", "拒绝"}},
+		{"email邮件", []string{"email", "邮件"}},
+		{"お願いします", []string{"お願", "願い", "いし", "しま", "ます"}},
+	}
+	for _, tt := range tests {
+		got := searchTerms(tt.text)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("searchTerms(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestHighlightText(t *testing.T) {
+	tests := []struct {
+		text  string
+		query string
+		want  string
+	}{
+		{"Decline the meeting politely", "meet", "Decline the <mark>meeting</mark> politely"},
+		{"我想礼貌地拒绝这次会议", "拒绝会议", "我想礼貌地<mark>拒绝</mark>这次<mark>会议</mark>"},
+		{"拒绝会议", "拒绝会议", "<mark>拒绝会议</mark>"},
+		{"<b>拒绝</b>", "拒绝", "&lt;b&gt;<mark>拒绝</mark>&lt;/b&gt;"},
+	}
+	for _, tt := range tests {
+		got, ok := highlightText(tt.text, searchTerms(tt.query), 200)
+		if !ok || got != tt.want {
+			t.Errorf("highlightText(%q, %q) = %q, %v; want %q", tt.text, tt.query, got, ok, tt.want)
+		}
+	}
+}
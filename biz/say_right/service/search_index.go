@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"api/biz/say_right/dal/query"
+)
+
+type SearchHit struct {
+	TemplateID int32
+	Score      float64
+}
+
+// SearchIndex ranks templates for already tokenized, typo-corrected terms.
+// Hits may include templates that are no longer visible; callers filter them.
+type SearchIndex interface {
+	Search(ctx context.Context, terms []string, limit int) ([]SearchHit, error)
+}
+
+// NewSearchIndex uses MySQL FULLTEXT when running on MySQL and the in-process
+// index otherwise (SQLite, local runs).
+func NewSearchIndex(q *query.Query) SearchIndex {
+	if q.Template.WithContext(context.Background()).UnderlyingDB().Dialector.Name() == "mysql" {
+		return &mysqlSearchIndex{q: q}
+	}
+	return &memorySearchIndex{q: q}
+}
+
+// mysqlSearchIndex relies on the ft_templates_search and
+// ft_template_details_search FULLTEXT indexes. Template fields weigh double.
+type mysqlSearchIndex struct {
+	q *query.Query
+}
+
+const mysqlSearchSQL = `
+SELECT t.id AS template_id,
+       MATCH (t.title, t.description, t.tags_text) AGAINST (? IN BOOLEAN MODE) * 2 +
+       MATCH (d.headline, d.summary, d.reply_soft, d.reply_neutral, d.reply_firm) AGAINST (? IN BOOLEAN MODE) AS score
+FROM templates t
+JOIN template_details d ON d.template_id = t.id
+WHERE MATCH (t.title, t.description, t.tags_text) AGAINST (? IN BOOLEAN MODE)
+   OR MATCH (d.headline, d.summary, d.reply_soft, d.reply_neutral, d.reply_firm) AGAINST (? IN BOOLEAN MODE)
+ORDER BY score DESC, t.id
+LIMIT ?`
+
+func (i *mysqlSearchIndex) Search(ctx context.Context, terms []string, limit int) ([]SearchHit, error) {
+	// Terms only contain letters and digits, so they cannot inject boolean
+	// operators; the trailing * lets "negot" match "negotiate".
+	words := make([]string, len(terms))
+	for n, term := range terms {
+		words[n] = term + "*"
+	}
+	against := strings.Join(words, " ")
+
+	var rows []struct {
+		TemplateID int32
+		Score      float64
+	}
+	err := i.q.Template.WithContext(ctx).UnderlyingDB().
+		Raw(mysqlSearchSQL, against, against, against, against, limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]SearchHit, 0, len(rows))
+	for _, r := range rows {
+		hits = append(hits, SearchHit{TemplateID: r.TemplateID, Score: r.Score})
+	}
+	return hits, nil
+}
+
+// memorySearchIndex scores documents from the in-process corpus with
+// field-weighted TF-IDF. Prefix matches count half.
+type memorySearchIndex struct {
+	q *query.Query
+}
+
+func (i *memorySearchIndex) Search(ctx context.Context, terms []string, limit int) ([]SearchHit, error) {
+	corpus, err := loadSearchCorpus(ctx, i.q)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[int32]float64)
+	matched := make(map[int32]int)
+	for _, term := range terms {
+		termScores := make(map[int32]float64)
+		for word, postings := range corpus.Postings {
+			factor := 0.0
+			switch {
+			case word == term:
+				factor = 1
+			case len(term) >= 3 && strings.HasPrefix(word, term):
+				factor = 0.5
+			default:
+				continue
+			}
+			idf := corpus.idf(word)
+			for _, p := range postings {
+				termScores[p.TemplateID] = max(termScores[p.TemplateID], factor*idf*p.Weight)
+			}
+		}
+		for id, score := range termScores {
+			scores[id] += score
+			matched[id]++
+		}
+	}
+
+	hits := make([]SearchHit, 0, len(scores))
+	for id, score := range scores {
+		// Favour documents matching every term over ones repeating a single term
+		coverage := float64(matched[id]) / float64(len(terms))
+		hits = append(hits, SearchHit{TemplateID: id, Score: score * coverage})
+	}
+	sortHits(hits)
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"html"
+	"strings"
+	"sync"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+const (
+	SearchDefaultLimit = 20
+	SearchMaxLimit     = 50
+	// searchCandidatePool is how many hits are fetched before filters apply.
+	searchCandidatePool = 200
+	searchSnippetLength = 160
+)
+
+var ErrEmptySearchQuery = errors.New("search query is empty")
+
+type SearchParams struct {
+	Query      string
+	Tag        string
+	CategoryID int32
+	IsPro      *bool
+	Limit      int
+	// Locale picks the language results are shown in; matching always runs
+	// against the source text.
+	Locale LocaleRequest
+}
+
+// SearchResultItem is a TemplateItem plus ranking data. Highlights hold
+// HTML-escaped text with matches wrapped in <mark>, keyed by field name.
+type SearchResultItem struct {
+	TemplateItem
+	CategoryID   int32             `json:"category_id"`
+	CategoryName string            `json:"category_name"`
+	Score        float64           `json:"score"`
+	Highlights   map[string]string `json:"highlights"`
+}
+
+type SearchResult struct {
+	Query string `json:"query"`
+	// CorrectedQuery is set when typos in Query were corrected before searching.
+	CorrectedQuery string             `json:"corrected_query,omitempty"`
+	Results        []SearchResultItem `json:"results"`
+}
+
+type SearchService interface {
+	Search(ctx context.Context, userID int32, params SearchParams) (*SearchResult, error)
+}
+
+type searchService struct {
+	q *query.Query
+
+	// The index is picked on first use, once the database connection is up
+	indexOnce sync.Once
+	index     SearchIndex
+}
+
+func NewSearchService() SearchService {
+	return &searchService{
+		q: query.Q,
+	}
+}
+
+func (s *searchService) Search(ctx context.Context, userID int32, params SearchParams) (*SearchResult, error) {
+	terms := searchTerms(params.Query)
+	if len(terms) == 0 {
+		return nil, ErrEmptySearchQuery
+	}
+	limit := params.Limit
+	if limit < 1 {
+		limit = SearchDefaultLimit
+	}
+	if limit > SearchMaxLimit {
+		limit = SearchMaxLimit
+	}
+
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, err
+	}
+
+	// The corpus also backs typo correction when the MySQL index does the ranking
+	corpus, err := loadSearchCorpus(ctx, s.q)
+	if err != nil {
+		return nil, err
+	}
+	locale := params.Locale.Resolve(user.Locale)
+	display, err := displayDocuments(ctx, s.q, corpus, locale)
+	if err != nil {
+		return nil, err
+	}
+	activity, err := loadTemplateActivity(ctx, s.q, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := &SearchResult{Query: params.Query, Results: make([]SearchResultItem, 0)}
+	terms, changed := corpus.correct(terms)
+	if changed {
+		result.CorrectedQuery = strings.Join(terms, " ")
+	}
+
+	s.indexOnce.Do(func() {
+		s.index = NewSearchIndex(s.q)
+	})
+	hits, err := s.index.Search(ctx, terms, searchCandidatePool)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hit := range hits {
+		doc, ok := corpus.Documents[hit.TemplateID]
+		if !ok || !params.matches(doc) {
+			continue
+		}
+
+		if translated, ok := display[doc.Template.ID]; ok {
+			doc = translated
+		}
+		item := toTemplateItem(doc.Template, toTemplateTagItems(doc.Tags, locale), user, activity)
+		result.Results = append(result.Results, SearchResultItem{
+			TemplateItem: item,
+			CategoryID:   doc.Category.ID,
+			CategoryName: doc.Category.Name,
+			Score:        hit.Score,
+			Highlights:   searchHighlights(doc, terms, item.IsLocked),
+		})
+		if len(result.Results) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// displayDocuments returns corpus documents whose template and category text
+// is translated into locale, keyed by template id. Details stay in the source
+// language. It returns nil for the source locale.
+func displayDocuments(ctx context.Context, q *query.Query, corpus *searchCorpus, locale string) (map[int32]*searchDocument, error) {
+	if len(translationChain(locale)) == 0 {
+		return nil, nil
+	}
+	catalog, err := loadCatalog(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	catalog = catalog.localized(locale)
+
+	categories := make(map[int32]*model.Category, len(catalog.Categories))
+	for _, c := range catalog.Categories {
+		categories[c.ID] = c
+	}
+	result := make(map[int32]*searchDocument, len(catalog.Templates))
+	for _, t := range catalog.Templates {
+		doc, ok := corpus.Documents[t.ID]
+		category, found := categories[t.CategoryID]
+		if !ok || !found {
+			continue
+		}
+		result[t.ID] = &searchDocument{Template: t, Detail: doc.Detail, Category: category, Tags: doc.Tags}
+	}
+	return result, nil
+}
+
+func (p SearchParams) matches(doc *searchDocument) bool {
+	if p.CategoryID > 0 && doc.Template.CategoryID != p.CategoryID {
+		return false
+	}
+	if p.IsPro != nil && (doc.Template.IsPro != 0) != *p.IsPro {
+		return false
+	}
+	if p.Tag != "" {
+		for _, tag := range doc.Tags {
+			if tag.Slug == p.Tag || strings.EqualFold(tag.Name, p.Tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// searchHighlights marks matches in every field that contains one. Replies of
+// locked Pro templates are left out so search cannot leak them.
+func searchHighlights(doc *searchDocument, terms []string, locked bool) map[string]string {
+	highlights := make(map[string]string)
+	for field, text := range doc.fields() {
+		if locked && strings.HasPrefix(field, "reply_") {
+			continue
+		}
+		if marked, ok := highlightText(text, terms, searchSnippetLength); ok {
+			highlights[field] = marked
+		}
+	}
+	return highlights
+}
+
+// highlightText escapes text and wraps words matching a term (exactly or by
+// prefix) in <mark>. CJK text is matched by the bigrams searchTerms produces,
+// with overlapping matches marked as one. Long text is cut to a window around
+// the first match.
+func highlightText(text string, terms []string, window int) (string, bool) {
+	runes := []rune(text)
+	type span struct{ start, end int }
+	var spans []span
+
+	eachSearchToken(runes, func(token string, cjk bool, start, end int) {
+		for _, term := range terms {
+			if token == term || (!cjk && len(term) >= 3 && strings.HasPrefix(token, term)) {
+				if n := len(spans); n > 0 && start <= spans[n-1].end {
+					spans[n-1].end = max(spans[n-1].end, end)
+				} else {
+					spans = append(spans, span{start, end})
+				}
+				return
+			}
+		}
+	})
+	if len(spans) == 0 {
+		return "", false
+	}
+
+	from, to := 0, len(runes)
+	if len(runes) > window {
+		from = max(0, spans[0].start-window/4)
+		to = min(len(runes), from+window)
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, sp := range spans {
+		if sp.start < from || sp.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:sp.start])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[sp.start:sp.end])))
+		b.WriteString("</mark>")
+		pos = sp.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:to])))
+	if to < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+var ErrShareNotFound = errors.New("share link not found")
+
+// ErrShareGone is returned for links that were revoked, expired or point at
+// a template that is no longer published.
+var ErrShareGone = errors.New("share link is no longer available")
+
+// ShareMaxExpiryDays caps how far ahead a link may expire.
+const ShareMaxExpiryDays = 365
+
+type ShareInput struct {
+	// Tone limits the link to one reply; empty shares all three.
+	Tone string `json:"tone"`
+	// Values fill in the template's variables, as for rendering.
+	Values map[string]string `json:"values"`
+	// ExpiresInDays is 0 for a link that never expires.
+	ExpiresInDays int `json:"expires_in_days"`
+}
+
+type ShareResult struct {
+	ID            int32             `json:"id"`
+	Token         string            `json:"token"`
+	URL           string            `json:"url"`
+	TemplateID    int32             `json:"template_id"`
+	TemplateTitle string            `json:"template_title"`
+	Locale        string            `json:"locale"`
+	Tone          string            `json:"tone"`
+	Values        map[string]string `json:"values"`
+	ViewCount     int32             `json:"view_count"`
+	LastViewedAt  *time.Time        `json:"last_viewed_at"`
+	ExpiresAt     *time.Time        `json:"expires_at"`
+	RevokedAt     *time.Time        `json:"revoked_at"`
+	Active        bool              `json:"active"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// SharedReply is what a share link shows. Locked means the template is Pro
+// and the viewer is not, so only a teaser is included.
+type SharedReply struct {
+	Slug         string            `json:"slug"`
+	Locale       string            `json:"locale"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	CategoryName string            `json:"category_name"`
+	Tags         []string          `json:"tags"`
+	IsPro        bool              `json:"is_pro"`
+	Locked       bool              `json:"locked"`
+	Tone         string            `json:"tone"`
+	Teaser       string            `json:"teaser,omitempty"`
+	ReplySoft    string            `json:"reply_soft,omitempty"`
+	ReplyNeutral string            `json:"reply_neutral,omitempty"`
+	ReplyFirm    string            `json:"reply_firm,omitempty"`
+	Values       map[string]string `json:"values,omitempty"`
+	SharedAt     time.Time         `json:"shared_at"`
+	ExpiresAt    *time.Time        `json:"expires_at"`
+}
+
+// ShareService manages public links to a template reply. Links show the
+// template as it is when opened, filled in with the sharer's values.
+type ShareService interface {
+	CreateShare(ctx context.Context, userID int32, templateID int32, input ShareInput, locale LocaleRequest) (*ShareResult, error)
+	// ListShares returns the user's links, newest first, revoked ones included.
+	ListShares(ctx context.Context, userID int32) ([]ShareResult, error)
+	RevokeShare(ctx context.Context, userID int32, shareID int32) error
+	// ViewShare opens a link and counts the view. viewerID is 0 for visitors
+	// who are not signed in.
+	ViewShare(ctx context.Context, token string, viewerID int32) (*SharedReply, error)
+}
+
+type shareService struct {
+	q         *query.Query
+	templates TemplateService
+	siteURL   string
+}
+
+// NewShareService builds link URLs from SAYRIGHT_SITE_URL.
+func NewShareService(templates TemplateService) ShareService {
+	return &shareService{
+		q:         query.Q,
+		templates: templates,
+		siteURL:   siteURLFromEnv(),
+	}
+}
+
+func (s *shareService) CreateShare(ctx context.Context, userID int32, templateID int32, input ShareInput, locale LocaleRequest) (*ShareResult, error) {
+	tone := ""
+	if input.Tone != "" {
+		var err error
+		if tone, err = normalizeTone(input.Tone); err != nil {
+			return nil, err
+		}
+	}
+	if input.ExpiresInDays < 0 || input.ExpiresInDays > ShareMaxExpiryDays {
+		return nil, ValidationError{Field: "expires_in_days", Message: "must be between 0 and 365"}
+	}
+
+	// Sharers need access themselves, so free users cannot share Pro replies
+	detail, err := s.templates.GetTemplateDetail(ctx, userID, templateID, locale)
+	if err != nil {
+		return nil, err
+	}
+	values, err := resolveVariableValues(detail.Variables, input.Values)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	share := &model.TemplateShare{
+		Token:          randomToken(),
+		UserID:         userID,
+		TemplateID:     templateID,
+		Locale:         detail.Locale,
+		Tone:           tone,
+		VariableValues: string(encoded),
+		CreatedAt:      now,
+	}
+	if input.ExpiresInDays > 0 {
+		expiresAt := now.AddDate(0, 0, input.ExpiresInDays)
+		share.ExpiresAt = &expiresAt
+	}
+	if err := s.q.TemplateShare.WithContext(ctx).Create(share); err != nil {
+		return nil, err
+	}
+	result := s.toShareResult(share, detail.Title, now)
+	return &result, nil
+}
+
+func (s *shareService) ListShares(ctx context.Context, userID int32) ([]ShareResult, error) {
+	sh := s.q.TemplateShare
+	shares, err := sh.WithContext(ctx).Where(sh.UserID.Eq(userID)).Order(sh.CreatedAt.Desc(), sh.ID.Desc()).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int32, 0, len(shares))
+	for _, share := range shares {
+		ids = append(ids, share.TemplateID)
+	}
+	titles := make(map[int32]string, len(ids))
+	if len(ids) > 0 {
+		templates, err := s.q.Template.WithContext(ctx).Where(s.q.Template.ID.In(ids...)).Find()
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range templates {
+			titles[t.ID] = t.Title
+		}
+	}
+
+	now := time.Now().UTC()
+	result := make([]ShareResult, 0, len(shares))
+	for _, share := range shares {
+		result = append(result, s.toShareResult(share, titles[share.TemplateID], now))
+	}
+	return result, nil
+}
+
+func (s *shareService) RevokeShare(ctx context.Context, userID int32, shareID int32) error {
+	sh := s.q.TemplateShare
+	share, err := sh.WithContext(ctx).Where(sh.ID.Eq(shareID), sh.UserID.Eq(userID)).First()
+	if err != nil {
+		if isNotFound(err) {
+			return ErrShareNotFound
+		}
+		return err
+	}
+	if share.RevokedAt != nil {
+		return nil
+	}
+	now := time.Now().UTC()
+	share.RevokedAt = &now
+	return sh.WithContext(ctx).Save(share)
+}
+
+func (s *shareService) ViewShare(ctx context.Context, token string, viewerID int32) (*SharedReply, error) {
+	sh := s.q.TemplateShare
+	share, err := sh.WithContext(ctx).Where(sh.Token.Eq(token)).First()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrShareNotFound
+		}
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if !shareActive(share, now) {
+		return nil, ErrShareGone
+	}
+
+	templates := &templateService{q: s.q}
+	template, detail, category, err := templates.findVisibleTemplate(ctx, s.q.Template.ID.Eq(share.TemplateID))
+	if err == ErrTemplateNotFound {
+		return nil, ErrShareGone
+	}
+	if err != nil {
+		return nil, err
+	}
+	template, detail, category, err = localizeTemplateContent(ctx, s.q, share.Locale, template, detail, category)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := templateTagItems(ctx, s.q, template.ID, share.Locale)
+	if err != nil {
+		return nil, err
+	}
+	result := buildTemplateDetailResult(template, detail, category, tags)
+
+	locked := false
+	if result.IsPro {
+		locked = true
+		if viewerID > 0 {
+			viewer, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(viewerID)).First()
+			if err != nil && !isNotFound(err) {
+				return nil, err
+			}
+			locked = viewer == nil || !hasPro(viewer)
+		}
+	}
+
+	reply := &SharedReply{
+		Slug:         result.Slug,
+		Locale:       share.Locale,
+		Title:        result.Title,
+		Description:  result.Description,
+		CategoryName: result.CategoryName,
+		Tags:         result.Tags,
+		IsPro:        result.IsPro,
+		Locked:       locked,
+		Tone:         share.Tone,
+		SharedAt:     share.CreatedAt,
+		ExpiresAt:    share.ExpiresAt,
+	}
+	values := sharedValues(result.Variables, share.VariableValues)
+	if locked {
+		// Cut from the summary like the preview; any part of the reply is paid content
+		reply.Teaser = teaserText(previewTeaserSource(template, detail), teaserMaxLength)
+	} else {
+		reply.Values = values
+		if share.Tone == "" || share.Tone == ToneSoft {
+			reply.ReplySoft = renderReply(result.ReplySoft, values, RenderFormatText)
+		}
+		if share.Tone == "" || share.Tone == ToneNeutral {
+			reply.ReplyNeutral = renderReply(result.ReplyNeutral, values, RenderFormatText)
+		}
+		if share.Tone == "" || share.Tone == ToneFirm {
+			reply.ReplyFirm = renderReply(result.ReplyFirm, values, RenderFormatText)
+		}
+	}
+
+	// The sharer checking their own link is not a view. A failed count must
+	// not break the page.
+	if viewerID != share.UserID {
+		if _, err := sh.WithContext(ctx).Where(sh.ID.Eq(share.ID)).UpdateSimple(sh.ViewCount.Add(1), sh.LastViewedAt.Value(now)); err != nil {
+			log.Printf("Failed to count view of share %d: %v", share.ID, err)
+		}
+	}
+	return reply, nil
+}
+
+func (s *shareService) toShareResult(share *model.TemplateShare, title string, now time.Time) ShareResult {
+	values := map[string]string{}
+	// Values were validated on write; a broken row shows without them
+	_ = json.Unmarshal([]byte(share.VariableValues), &values)
+	return ShareResult{
+		ID:            share.ID,
+		Token:         share.Token,
+		URL:           s.siteURL + "/s/" + share.Token,
+		TemplateID:    share.TemplateID,
+		TemplateTitle: title,
+		Locale:        share.Locale,
+		Tone:          share.Tone,
+		Values:        values,
+		ViewCount:     share.ViewCount,
+		LastViewedAt:  share.LastViewedAt,
+		ExpiresAt:     share.ExpiresAt,
+		RevokedAt:     share.RevokedAt,
+		Active:        shareActive(share, now),
+		CreatedAt:     share.CreatedAt,
+	}
+}
+
+func shareActive(share *model.TemplateShare, now time.Time) bool {
+	return share.RevokedAt == nil && (share.ExpiresAt == nil || now.Before(*share.ExpiresAt))
+}
+
+// sharedValues are the stored values for variables the template still has.
+// Variables added since stay as placeholders unless they have a default.
+func sharedValues(variables []TemplateVariable, stored string) map[string]string {
+	saved := map[string]string{}
+	_ = json.Unmarshal([]byte(stored), &saved)
+
+	values := make(map[string]string, len(variables))
+	for _, v := range variables {
+		if value, ok := saved[v.Name]; ok {
+			values[v.Name] = value
+		} else if v.Default != "" {
+			values[v.Name] = v.Default
+		}
+	}
+	return values
+}
+
+// randomToken returns 128 random bits, hex encoded, for links that must not
+// be guessable.
+func randomToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+func TestLockedShareHidesProReply(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	content := NewContentService(NewAuditService())
+
+	category, err := content.CreateCategory(ctx, CategoryInput{Name: "Work", IsActive: true})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	reply := "No thanks, I'm busy."
+	created, err := content.CreateTemplate(ctx, TemplateInput{
+		CategoryID:  category.ID,
+		Title:       "Decline a meeting",
+		Description: "Say no politely",
+		IsActive:    true,
+		IsPro:       true,
+		Detail: TemplateDetailInput{
+			Headline:     "Decline",
+			Summary:      "A polite no",
+			ReplySoft:    reply,
+			ReplyNeutral: reply,
+			ReplyFirm:    reply,
+		},
+	})
+	if err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+
+	share := &model.TemplateShare{
+		Token:          randomToken(),
+		UserID:         1,
+		TemplateID:     created.Template.ID,
+		Locale:         SourceLocale,
+		Tone:           ToneNeutral,
+		VariableValues: "{}",
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := query.Q.TemplateShare.WithContext(ctx).Create(share); err != nil {
+		t.Fatal(err)
+	}
+
+	shared, err := NewShareService(NewTemplateService()).ViewShare(ctx, share.Token, 0)
+	if err != nil {
+		t.Fatalf("view share: %v", err)
+	}
+	if !shared.Locked {
+		t.Fatal("share of a Pro reply is not locked for a visitor")
+	}
+	if strings.Contains(shared.Teaser, "busy") || shared.ReplyNeutral != "" {
+		t.Errorf("locked share leaks the reply: teaser %q", shared.Teaser)
+	}
+	if shared.Teaser != "A polite no" {
+		t.Errorf("teaser = %q, want the summary", shared.Teaser)
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+const slugMaxLength = 96
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Slugify lowercases value and joins its ASCII letters and digits with dashes.
+// It returns "" when nothing usable is left, e.g. for non-Latin titles.
+func Slugify(value string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(value) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	return strings.Trim(truncate(b.String(), slugMaxLength-9), "-")
+}
+
+// validateSlug checks an explicitly supplied slug; empty means "generate one".
+func validateSlug(slug string) error {
+	if slug == "" {
+		return nil
+	}
+	if len(slug) > slugMaxLength {
+		return ValidationError{Field: "slug", Message: "must be at most 96 characters"}
+	}
+	if !slugPattern.MatchString(slug) {
+		return ValidationError{Field: "slug", Message: "may only contain lowercase letters, digits and single dashes"}
+	}
+	return nil
+}
+
+// categorySlug returns slug if it is free, or derives a free one from name when slug is empty.
+func categorySlug(ctx context.Context, tx *query.Query, slug, name string, exceptID int32) (string, error) {
+	return resolveSlug(slug, name, "category", func(candidate string) (int64, error) {
+		return tx.Category.WithContext(ctx).
+			Where(tx.Category.Slug.Eq(candidate), tx.Category.ID.Neq(exceptID)).
+			Count()
+	})
+}
+
+// templateSlug returns slug if it is free, or derives a free one from title when slug is empty.
+func templateSlug(ctx context.Context, tx *query.Query, slug, title string, exceptID int32) (string, error) {
+	return resolveSlug(slug, title, "template", func(candidate string) (int64, error) {
+		return tx.Template.WithContext(ctx).
+			Where(tx.Template.Slug.Eq(candidate), tx.Template.ID.Neq(exceptID)).
+			Count()
+	})
+}
+
+// moveTemplateSlug keeps template_slug_redirects in step with a template's slug:
+// the old slug keeps pointing at the template, and a slug that is live again
+// stops redirecting anywhere.
+func moveTemplateSlug(ctx context.Context, tx *query.Query, templateID int32, oldSlug, newSlug string) error {
+	r := tx.TemplateSlugRedirect
+	if _, err := r.WithContext(ctx).Where(r.OldSlug.Eq(newSlug)).Delete(); err != nil {
+		return err
+	}
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+	if _, err := r.WithContext(ctx).Where(r.OldSlug.Eq(oldSlug)).Delete(); err != nil {
+		return err
+	}
+	return r.WithContext(ctx).Create(&model.TemplateSlugRedirect{
+		OldSlug:    oldSlug,
+		TemplateID: templateID,
+		CreatedAt:  time.Now().UTC(),
+	})
+}
+
+func resolveSlug(slug, source, fallback string, taken func(string) (int64, error)) (string, error) {
+	if slug != "" {
+		count, err := taken(slug)
+		if err != nil {
+			return "", err
+		}
+		if count > 0 {
+			return "", ValidationError{Field: "slug", Message: "is already in use"}
+		}
+		return slug, nil
+	}
+
+	base := Slugify(source)
+	if base == "" {
+		base = fallback
+	}
+	candidate := base
+	for {
+		count, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+		candidate = base + "-" + randomSlugSuffix()
+	}
+}
+
+func randomSlugSuffix() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
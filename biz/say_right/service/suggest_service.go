@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+
+	"api/biz/say_right/dal/query"
+	"api/infra/llm"
+)
+
+const (
+	SuggestDefaultLimit = 5
+	SuggestMaxLimit     = 20
+)
+
+// Ranking methods reported with suggestions.
+const (
+	SuggestMethodEmbedding = "embedding"
+	SuggestMethodLexical   = "lexical"
+)
+
+// bm25K1 controls how quickly repeated matches of a term stop adding score.
+const bm25K1 = 1.2
+
+type SuggestInput struct {
+	Situation string `json:"situation"`
+	Limit     int    `json:"limit"`
+}
+
+// TemplateSuggestion is a TemplateItem ranked for a situation. Locked Pro
+// templates are suggested too, so users learn what upgrading unlocks.
+type TemplateSuggestion struct {
+	TemplateItem
+	CategoryID   int32   `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Score        float64 `json:"score"`
+}
+
+type SuggestResult struct {
+	Locale      string               `json:"locale"`
+	Method      string               `json:"method"`
+	Suggestions []TemplateSuggestion `json:"suggestions"`
+}
+
+type SuggestService interface {
+	// Suggest ranks the visible templates by how well they fit a described situation.
+	Suggest(ctx context.Context, userID int32, input SuggestInput, locale LocaleRequest) (*SuggestResult, error)
+}
+
+type suggestService struct {
+	q *query.Query
+}
+
+func NewSuggestService() SuggestService {
+	return &suggestService{
+		q: query.Q,
+	}
+}
+
+func (s *suggestService) Suggest(ctx context.Context, userID int32, input SuggestInput, locale LocaleRequest) (*SuggestResult, error) {
+	situation := strings.TrimSpace(input.Situation)
+	if err := requireText("situation", situation, situationMaxLength); err != nil {
+		return nil, err
+	}
+	limit := input.Limit
+	if limit < 1 {
+		limit = SuggestDefaultLimit
+	}
+	if limit > SuggestMaxLimit {
+		limit = SuggestMaxLimit
+	}
+
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, err
+	}
+	corpus, err := loadSearchCorpus(ctx, s.q)
+	if err != nil {
+		return nil, err
+	}
+	resolved := locale.Resolve(user.Locale)
+	display, err := displayDocuments(ctx, s.q, corpus, resolved)
+	if err != nil {
+		return nil, err
+	}
+	activity, err := loadTemplateActivity(ctx, s.q, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SuggestResult{Locale: resolved, Method: SuggestMethodLexical, Suggestions: make([]TemplateSuggestion, 0)}
+	var hits []SearchHit
+	if embedder := llm.Embeddings; embedder != nil {
+		hits, err = s.embeddingHits(ctx, embedder, situation)
+		if err != nil {
+			// The lexical ranking is worse but always available
+			log.Printf("Embedding suggestions failed, falling back to lexical: %v", err)
+		} else {
+			result.Method = SuggestMethodEmbedding
+		}
+	}
+	if result.Method == SuggestMethodLexical {
+		hits = corpus.bm25(searchTerms(situation))
+	}
+
+	for _, hit := range hits {
+		doc, ok := corpus.Documents[hit.TemplateID]
+		if !ok {
+			continue
+		}
+		if translated, ok := display[doc.Template.ID]; ok {
+			doc = translated
+		}
+		result.Suggestions = append(result.Suggestions, TemplateSuggestion{
+			TemplateItem: toTemplateItem(doc.Template, toTemplateTagItems(doc.Tags, resolved), user, activity),
+			CategoryID:   doc.Category.ID,
+			CategoryName: doc.Category.Name,
+			Score:        hit.Score,
+		})
+		if len(result.Suggestions) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (s *suggestService) embeddingHits(ctx context.Context, embedder llm.Embedder, situation string) ([]SearchHit, error) {
+	vectors, err := loadTemplateVectors(ctx, s.q, embedder)
+	if err != nil {
+		return nil, err
+	}
+	embedded, err := embedder.Embed(ctx, []string{situation})
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]SearchHit, 0, len(vectors))
+	for id, vector := range vectors {
+		hits = append(hits, SearchHit{TemplateID: id, Score: cosineSimilarity(embedded[0], vector)})
+	}
+	sortHits(hits)
+	return hits, nil
+}
+
+// bm25 ranks documents matching any of terms. Unlike search, a document need
+// not match every term: a described situation is mostly filler words. The
+// field-weighted count stands in for term frequency, without length
+// normalisation since short fields already weigh more.
+func (c *searchCorpus) bm25(terms []string) []SearchHit {
+	scores := make(map[int32]float64)
+	seen := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		idf := c.idf(term)
+		for _, p := range c.Postings[term] {
+			scores[p.TemplateID] += idf * p.Weight * (bm25K1 + 1) / (p.Weight + bm25K1)
+		}
+	}
+
+	hits := make([]SearchHit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, SearchHit{TemplateID: id, Score: score})
+	}
+	sortHits(hits)
+	return hits
+}
+
+// sortHits orders hits best first, breaking ties by id for stable output.
+func sortHits(hits []SearchHit) {
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return hits[a].TemplateID < hits[b].TemplateID
+	})
+}
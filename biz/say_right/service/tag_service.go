@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+var ErrTagNotFound = errors.New("tag not found")
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// TagItem is a tag as shown to users, with the number of visible templates using it.
+type TagItem struct {
+	Slug  string `json:"slug"`
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Color string `json:"color"`
+	Count int    `json:"count"`
+}
+
+// TemplateTagItem is a tag on a template, read through the template's tag
+// links so renames, colors and labels set with UpdateTag show everywhere.
+type TemplateTagItem struct {
+	Slug  string `json:"slug"`
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Color string `json:"color"`
+}
+
+// TagInput edits a tag's metadata. The slug never changes so filters and
+// links keep working after a rename.
+type TagInput struct {
+	Name      string            `json:"name"`
+	Color     string            `json:"color"`
+	Labels    map[string]string `json:"labels"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type TagService interface {
+	// ListTags returns tags used by visible templates, labelled for locale.
+	ListTags(ctx context.Context, locale string) ([]TagItem, error)
+	ListAllTags(ctx context.Context) ([]*model.Tag, error)
+	UpdateTag(ctx context.Context, tagID int32, input TagInput) (*model.Tag, error)
+	// Backfill links every template to the tags in its tags_text and returns
+	// how many templates it processed.
+	Backfill(ctx context.Context) (int, error)
+	// BackfillMissing is Backfill for templates with tags_text but no tag
+	// links yet. The server runs it on startup.
+	BackfillMissing(ctx context.Context) (int, error)
+}
+
+type tagService struct {
+	*contentService
+}
+
+func NewTagService(audit AuditService) TagService {
+	return &tagService{
+		contentService: &contentService{
+			q:     query.Q,
+			audit: audit,
+		},
+	}
+}
+
+func (s *tagService) ListTags(ctx context.Context, locale string) ([]TagItem, error) {
+	catalog, err := loadCatalog(ctx, s.q)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make(map[int32]bool, len(catalog.Templates))
+	for _, t := range catalog.Templates {
+		visible[t.ID] = true
+	}
+	counts := make(map[int32]int)
+	for _, link := range catalog.TemplateTags {
+		if visible[link.TemplateID] {
+			counts[link.TagID]++
+		}
+	}
+
+	result := make([]TagItem, 0, len(counts))
+	for _, tag := range catalog.Tags {
+		if counts[tag.ID] == 0 {
+			continue
+		}
+		result = append(result, TagItem{
+			Slug:  tag.Slug,
+			Name:  tag.Name,
+			Label: tagLabel(tag, locale),
+			Color: tag.Color,
+			Count: counts[tag.ID],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (s *tagService) ListAllTags(ctx context.Context) ([]*model.Tag, error) {
+	return s.q.Tag.WithContext(ctx).Order(s.q.Tag.Name).Find()
+}
+
+func (s *tagService) UpdateTag(ctx context.Context, tagID int32, input TagInput) (*model.Tag, error) {
+	if err := validateTag(input); err != nil {
+		return nil, err
+	}
+	labels, err := json.Marshal(cleanLabels(input.Labels))
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *model.Tag
+	err = s.write(ctx, func(tx *query.Query) error {
+		current, err := tx.Tag.WithContext(ctx).Where(tx.Tag.ID.Eq(tagID)).First()
+		if err != nil {
+			return ErrTagNotFound
+		}
+		if !sameEditTimestamp(current.UpdatedAt, input.UpdatedAt) {
+			return ErrConflict
+		}
+
+		next := *current
+		next.Name = strings.TrimSpace(input.Name)
+		next.Color = strings.ToUpper(input.Color)
+		next.Labels = string(labels)
+		next.UpdatedAt = editTimestamp()
+		if err := tx.Tag.WithContext(ctx).Save(&next); err != nil {
+			return err
+		}
+		updated = &next
+
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.tag.update",
+			TargetType: "tag",
+			TargetID:   strconv.Itoa(int(tagID)),
+			Before:     current,
+			After:      &next,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *tagService) Backfill(ctx context.Context) (int, error) {
+	templates, err := s.q.Template.WithContext(ctx).Order(s.q.Template.ID).Find()
+	if err != nil {
+		return 0, err
+	}
+	return s.linkTags(ctx, templates)
+}
+
+func (s *tagService) BackfillMissing(ctx context.Context) (int, error) {
+	t, tt := s.q.Template, s.q.TemplateTag
+	templates, err := t.WithContext(ctx).Where(t.TagsText.Neq("")).Order(t.ID).Find()
+	if err != nil {
+		return 0, err
+	}
+	var linkedIDs []int32
+	if err := tt.WithContext(ctx).Distinct(tt.TemplateID).Pluck(tt.TemplateID, &linkedIDs); err != nil {
+		return 0, err
+	}
+	linked := make(map[int32]bool, len(linkedIDs))
+	for _, id := range linkedIDs {
+		linked[id] = true
+	}
+
+	missing := make([]*model.Template, 0)
+	for _, template := range templates {
+		if !linked[template.ID] {
+			missing = append(missing, template)
+		}
+	}
+	if len(missing) == 0 {
+		return 0, nil
+	}
+	return s.linkTags(ctx, missing)
+}
+
+// linkTags replaces the tag links of templates with the tags in their
+// tags_text.
+func (s *tagService) linkTags(ctx context.Context, templates []*model.Template) (int, error) {
+	err := s.write(ctx, func(tx *query.Query) error {
+		for _, t := range templates {
+			if err := syncTemplateTags(ctx, tx, t.ID, splitTags(t.TagsText)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(templates), nil
+}
+
+// syncTemplateTags makes the template's tag links match names, creating tags
+// that do not exist yet. tags_text keeps the names as entered; catalog reads
+// go through the links so tag edits show up.
+func syncTemplateTags(ctx context.Context, tx *query.Query, templateID int32, names []string) error {
+	if _, err := tx.TemplateTag.WithContext(ctx).Where(tx.TemplateTag.TemplateID.Eq(templateID)).Delete(); err != nil {
+		return err
+	}
+
+	linked := make(map[int32]bool, len(names))
+	for i, name := range names {
+		tag, err := findOrCreateTag(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+		if linked[tag.ID] {
+			continue
+		}
+		linked[tag.ID] = true
+		err = tx.TemplateTag.WithContext(ctx).Create(&model.TemplateTag{
+			TemplateID: templateID,
+			TagID:      tag.ID,
+			SortOrder:  int32(i),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findOrCreateTag(ctx context.Context, tx *query.Query, name string) (*model.Tag, error) {
+	slug := TagSlug(name)
+	tag, err := tx.Tag.WithContext(ctx).Where(tx.Tag.Slug.Eq(slug)).First()
+	if err == nil {
+		return tag, nil
+	}
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	now := editTimestamp()
+	tag = &model.Tag{
+		Slug:      slug,
+		Name:      truncate(strings.TrimSpace(name), 64),
+		Labels:    "{}",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := tx.Tag.WithContext(ctx).Create(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// toTemplateTagItems labels a template's linked tags for locale.
+func toTemplateTagItems(tags []*model.Tag, locale string) []TemplateTagItem {
+	result := make([]TemplateTagItem, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, TemplateTagItem{
+			Slug:  tag.Slug,
+			Name:  tag.Name,
+			Label: tagLabel(tag, locale),
+			Color: tag.Color,
+		})
+	}
+	return result
+}
+
+// templateTagItems reads one template's tags through its tag links, labelled
+// for locale.
+func templateTagItems(ctx context.Context, q *query.Query, templateID int32, locale string) ([]TemplateTagItem, error) {
+	tt := q.TemplateTag
+	links, err := tt.WithContext(ctx).Where(tt.TemplateID.Eq(templateID)).Order(tt.SortOrder).Find()
+	if err != nil || len(links) == 0 {
+		return []TemplateTagItem{}, err
+	}
+	ids := make([]int32, 0, len(links))
+	for _, link := range links {
+		ids = append(ids, link.TagID)
+	}
+	rows, err := q.Tag.WithContext(ctx).Where(q.Tag.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int32]*model.Tag, len(rows))
+	for _, tag := range rows {
+		byID[tag.ID] = tag
+	}
+	tags := make([]*model.Tag, 0, len(links))
+	for _, link := range links {
+		if tag, ok := byID[link.TagID]; ok {
+			tags = append(tags, tag)
+		}
+	}
+	return toTemplateTagItems(tags, locale), nil
+}
+
+// tagNames lists the names of tags, which is what the tags field of catalog
+// responses has always carried.
+func tagNames(tags []TemplateTagItem) []string {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, tag.Name)
+	}
+	return result
+}
+
+// plainTagItems shows tags kept only as text, like those on user templates,
+// which are not linked to the tags table.
+func plainTagItems(names []string) []TemplateTagItem {
+	result := make([]TemplateTagItem, 0, len(names))
+	for _, name := range names {
+		result = append(result, TemplateTagItem{Slug: TagSlug(name), Name: name, Label: name})
+	}
+	return result
+}
+
+// tagSearchText is the text search indexes for a template's tags: every
+// tag's name and its localized labels.
+func tagSearchText(tags []*model.Tag) string {
+	parts := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		parts = append(parts, tag.Name)
+		var labels map[string]string
+		if err := json.Unmarshal([]byte(tag.Labels), &labels); err == nil {
+			for _, label := range labels {
+				parts = append(parts, label)
+			}
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// TagSlug derives the stable slug for a tag name. Names without any Latin
+// letters or digits get a hash so that the same name always maps to the same tag.
+func TagSlug(name string) string {
+	if slug := Slugify(name); slug != "" {
+		return slug
+	}
+	sum := sha1.Sum([]byte(strings.ToLower(strings.TrimSpace(name))))
+	return "tag-" + hex.EncodeToString(sum[:4])
+}
+
+// tagLabel returns the label for locale, falling back from "zh-CN" to "zh"
+// and finally to the tag name.
+func tagLabel(tag *model.Tag, locale string) string {
+	if locale == "" {
+		return tag.Name
+	}
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(tag.Labels), &labels); err != nil {
+		return tag.Name
+	}
+	for _, candidate := range []string{locale, strings.SplitN(locale, "-", 2)[0]} {
+		for key, label := range labels {
+			if strings.EqualFold(key, candidate) && label != "" {
+				return label
+			}
+		}
+	}
+	return tag.Name
+}
+
+func validateTag(input TagInput) error {
+	if err := requireText("name", input.Name, 64); err != nil {
+		return err
+	}
+	if input.Color != "" && !tagColorPattern.MatchString(input.Color) {
+		return ValidationError{Field: "color", Message: "must look like #RRGGBB"}
+	}
+	for locale, label := range input.Labels {
+		if locale == "" || len(locale) > 16 {
+			return ValidationError{Field: "labels", Message: "locale keys must be 1 to 16 characters"}
+		}
+		if err := limitText("labels."+locale, label, 64); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cleanLabels(labels map[string]string) map[string]string {
+	result := make(map[string]string, len(labels))
+	for locale, label := range labels {
+		if v := strings.TrimSpace(label); v != "" {
+			result[locale] = v
+		}
+	}
+	return result
+}
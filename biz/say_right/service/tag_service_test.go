@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+func TestTemplateTagsReadThroughLinks(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	content := NewContentService(NewAuditService())
+	tags := NewTagService(NewAuditService())
+
+	category, err := content.CreateCategory(ctx, CategoryInput{Name: "Work", IsActive: true})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	created, err := content.CreateTemplate(ctx, TemplateInput{
+		CategoryID:  category.ID,
+		Title:       "Decline a meeting",
+		Description: "Say no politely",
+		Tags:        []string{"Boundaries"},
+		IsActive:    true,
+		Detail: TemplateDetailInput{
+			Headline:     "Decline",
+			Summary:      "A polite no",
+			ReplySoft:    "soft",
+			ReplyNeutral: "neutral",
+			ReplyFirm:    "firm",
+		},
+	})
+	if err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+
+	// Drop the links to look like a template saved before migration 0008
+	tt := query.Q.TemplateTag
+	if _, err := tt.WithContext(ctx).Where(tt.TemplateID.Eq(created.Template.ID)).Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := tags.BackfillMissing(ctx); err != nil || n != 1 {
+		t.Fatalf("BackfillMissing = %d, %v; want 1 template linked", n, err)
+	}
+	if n, err := tags.BackfillMissing(ctx); err != nil || n != 0 {
+		t.Fatalf("second BackfillMissing = %d, %v; want nothing to do", n, err)
+	}
+
+	tag, err := query.Q.Tag.WithContext(ctx).Where(query.Q.Tag.Slug.Eq("boundaries")).First()
+	if err != nil {
+		t.Fatalf("find tag: %v", err)
+	}
+	_, err = tags.UpdateTag(ctx, tag.ID, TagInput{
+		Name:      "Limits",
+		Color:     "#ff8800",
+		Labels:    map[string]string{"zh-CN": "界限"},
+		UpdatedAt: tag.UpdatedAt,
+	})
+	if err != nil {
+		t.Fatalf("update tag: %v", err)
+	}
+
+	now := time.Now().UTC()
+	user := &model.User{Email: "a@example.com", EmailNorm: "a@example.com", Role: RoleUser, Status: UserStatusActive, CreatedAt: now, UpdatedAt: now}
+	if err := query.Q.User.WithContext(ctx).Create(user); err != nil {
+		t.Fatal(err)
+	}
+
+	categories, err := NewTemplateService().ListTemplatesByCategory(ctx, user.ID, nil, LocaleRequest{Locale: "zh-CN"})
+	if err != nil {
+		t.Fatalf("list templates: %v", err)
+	}
+	want := TemplateTagItem{Slug: "boundaries", Name: "Limits", Label: "界限", Color: "#FF8800"}
+	if len(categories) != 1 || len(categories[0].Templates) != 1 {
+		t.Fatalf("categories = %+v, want one template", categories)
+	}
+	item := categories[0].Templates[0]
+	if got := item.TagDetails; len(got) != 1 || got[0] != want {
+		t.Errorf("tag_details = %+v, want [%+v]", got, want)
+	}
+	if len(item.Tags) != 1 || item.Tags[0] != "Limits" {
+		t.Errorf("tags = %q, want [Limits]", item.Tags)
+	}
+
+	detail, err := NewTemplateService().GetTemplateDetail(ctx, user.ID, created.Template.ID, LocaleRequest{Locale: "zh-CN"})
+	if err != nil {
+		t.Fatalf("get template detail: %v", err)
+	}
+	if len(detail.Tags) != 1 || detail.Tags[0] != "Limits" || len(detail.TagDetails) != 1 || detail.TagDetails[0] != want {
+		t.Errorf("detail tags = %q %+v, want the linked tag", detail.Tags, detail.TagDetails)
+	}
+
+	for _, filter := range []string{"boundaries", "limits"} {
+		result, err := NewSearchService().Search(ctx, user.ID, SearchParams{Query: "meeting", Tag: filter})
+		if err != nil {
+			t.Fatalf("search: %v", err)
+		}
+		if len(result.Results) != 1 {
+			t.Errorf("search with tag %q found %d templates, want 1", filter, len(result.Results))
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+// TeamTemplatesSlugPrefix starts the slug of the pseudo category that holds
+// a team's library in ListTemplatesByCategory; the organization id follows.
+const TeamTemplatesSlugPrefix = "team-"
+
+const defaultTeamTemplateLimit = 500
+
+func (s *organizationService) ListTeamTemplates(ctx context.Context, userID int32, orgID int32) (*UserTemplateList, error) {
+	if _, err := findMembership(ctx, s.q, orgID, userID); err != nil {
+		return nil, err
+	}
+	org, err := s.q.Organization.WithContext(ctx).Where(s.q.Organization.ID.Eq(orgID)).First()
+	if err != nil {
+		return nil, err
+	}
+	templates, err := findTeamTemplates(ctx, s.q, []int32{orgID})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := PlanFree
+	if subscriptionActive(org) {
+		plan = PlanPro
+	}
+	result := &UserTemplateList{
+		Plan:      plan,
+		Limit:     s.templateLimit,
+		Count:     len(templates),
+		Templates: make([]UserTemplateItem, 0, len(templates)),
+	}
+	for _, t := range templates {
+		result.Templates = append(result.Templates, UserTemplateItem{
+			TemplateItem:     toTeamTemplateItem(t, userID),
+			SourceTemplateID: t.SourceTemplateID,
+		})
+	}
+	return result, nil
+}
+
+func (s *organizationService) GetTeamTemplate(ctx context.Context, userID int32, orgID int32, templateID int32) (*UserTemplateResult, error) {
+	if _, err := findMembership(ctx, s.q, orgID, userID); err != nil {
+		return nil, err
+	}
+	template, err := s.findTeamTemplate(ctx, orgID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	return toUserTemplateResult(template), nil
+}
+
+func (s *organizationService) CreateTeamTemplate(ctx context.Context, userID int32, orgID int32, input UserTemplateInput) (*UserTemplateResult, error) {
+	if err := validateUserTemplate(input); err != nil {
+		return nil, err
+	}
+	if _, err := findMembership(ctx, s.q, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	created := &model.UserTemplate{OwnerID: userID, OrganizationID: &orgID, CreatedAt: now}
+	applyUserTemplateInput(created, input, now)
+	err := s.q.Transaction(func(tx *query.Query) error {
+		// Locking the organization serializes concurrent creates so the count check holds
+		org, err := lockOrganization(ctx, tx, orgID)
+		if err != nil {
+			return err
+		}
+		if !subscriptionActive(org) {
+			return ErrOrgSubscriptionOnly
+		}
+		count, err := tx.UserTemplate.WithContext(ctx).Where(tx.UserTemplate.OrganizationID.Eq(orgID)).Count()
+		if err != nil {
+			return err
+		}
+		if count >= int64(s.templateLimit) {
+			return UserTemplateLimitError{Limit: s.templateLimit}
+		}
+		return tx.UserTemplate.WithContext(ctx).Create(created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toUserTemplateResult(created), nil
+}
+
+func (s *organizationService) UpdateTeamTemplate(ctx context.Context, userID int32, orgID int32, templateID int32, input UserTemplateInput) (*UserTemplateResult, error) {
+	if err := validateUserTemplate(input); err != nil {
+		return nil, err
+	}
+	template, err := s.findTeamEditable(ctx, userID, orgID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.q.Organization.WithContext(ctx).Where(s.q.Organization.ID.Eq(orgID)).First()
+	if err != nil {
+		return nil, err
+	}
+	if !subscriptionActive(org) {
+		return nil, ErrOrgSubscriptionOnly
+	}
+
+	applyUserTemplateInput(template, input, time.Now().UTC())
+	if err := s.q.UserTemplate.WithContext(ctx).Save(template); err != nil {
+		return nil, err
+	}
+	return toUserTemplateResult(template), nil
+}
+
+func (s *organizationService) DeleteTeamTemplate(ctx context.Context, userID int32, orgID int32, templateID int32) error {
+	template, err := s.findTeamEditable(ctx, userID, orgID, templateID)
+	if err != nil {
+		return err
+	}
+	_, err = s.q.UserTemplate.WithContext(ctx).Where(s.q.UserTemplate.ID.Eq(template.ID)).Delete()
+	return err
+}
+
+// findTeamEditable is findTeamTemplate for the author and for owners and
+// admins.
+func (s *organizationService) findTeamEditable(ctx context.Context, userID, orgID, templateID int32) (*model.UserTemplate, error) {
+	member, err := findMembership(ctx, s.q, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	template, err := s.findTeamTemplate(ctx, orgID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.OwnerID != userID && !canManageOrg(member) {
+		return nil, ErrOrgRoleForbidden
+	}
+	return template, nil
+}
+
+func (s *organizationService) findTeamTemplate(ctx context.Context, orgID, templateID int32) (*model.UserTemplate, error) {
+	u := s.q.UserTemplate
+	template, err := u.WithContext(ctx).Where(u.ID.Eq(templateID), u.OrganizationID.Eq(orgID)).First()
+	if isNotFound(err) {
+		return nil, ErrTemplateNotFound
+	}
+	return template, err
+}
+
+func findTeamTemplates(ctx context.Context, q *query.Query, orgIDs []int32) ([]*model.UserTemplate, error) {
+	u := q.UserTemplate
+	return u.WithContext(ctx).Where(u.OrganizationID.In(orgIDs...)).Order(u.UpdatedAt.Desc(), u.ID.Desc()).Find()
+}
+
+// teamTemplateCategories lists the libraries of the user's teams as one
+// category each, in the order the user joined them, keeping only templates
+// carrying every one of tagSlugs. Teams with nothing left are skipped.
+func teamTemplateCategories(ctx context.Context, q *query.Query, userID int32, tagSlugs []string) ([]CategoryWithTemplates, error) {
+	m := q.OrganizationMember
+	memberships, err := m.WithContext(ctx).Where(m.UserID.Eq(userID)).Order(m.CreatedAt, m.OrganizationID).Find()
+	if err != nil || len(memberships) == 0 {
+		return nil, err
+	}
+	orgIDs := make([]int32, 0, len(memberships))
+	for _, membership := range memberships {
+		orgIDs = append(orgIDs, membership.OrganizationID)
+	}
+	orgs, err := findOrganizations(ctx, q, orgIDs)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := findTeamTemplates(ctx, q, orgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsByOrg := make(map[int32][]TemplateItem, len(orgIDs))
+	for _, t := range templates {
+		item := toTeamTemplateItem(t, userID)
+		if hasTagSlugs(item.TagDetails, tagSlugs) {
+			itemsByOrg[*t.OrganizationID] = append(itemsByOrg[*t.OrganizationID], item)
+		}
+	}
+
+	result := make([]CategoryWithTemplates, 0, len(orgIDs))
+	for _, id := range orgIDs {
+		org, ok := orgs[id]
+		if !ok || len(itemsByOrg[id]) == 0 {
+			continue
+		}
+		result = append(result, CategoryWithTemplates{
+			Slug:      TeamTemplatesSlugPrefix + strconv.Itoa(int(id)),
+			Name:      org.Name,
+			Templates: itemsByOrg[id],
+		})
+	}
+	return result, nil
+}
+
+func toTeamTemplateItem(t *model.UserTemplate, userID int32) TemplateItem {
+	item := toUserTemplateItem(t)
+	item.IsOwned = t.OwnerID == userID
+	item.OrganizationID = *t.OrganizationID
+	return item
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+	"api/infra/llm"
+)
+
+const (
+	embeddingBatchSize      = 64
+	embeddingRefreshTimeout = 2 * time.Minute
+)
+
+// templateVectorCache holds the vectors of the visible catalog for one
+// embedding model, rebuilt like the search corpus.
+var templateVectorCache struct {
+	sync.Mutex
+	model      string
+	vectors    map[int32][]float32
+	generation uint64
+	loadedAt   time.Time
+}
+
+var embeddingRefreshRunning atomic.Bool
+
+// embeddingText is what a template's vector stands for: the situation it is
+// meant for rather than the wording of its replies.
+func embeddingText(doc *searchDocument) string {
+	return strings.Join([]string{
+		doc.Template.Title,
+		doc.Template.Description,
+		doc.Template.TagsText,
+		doc.Detail.Headline,
+		doc.Detail.Summary,
+		doc.Category.Name,
+	}, "\n")
+}
+
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadTemplateVectors returns a vector for every visible template, embedding
+// templates that are new or were edited since their vector was stored.
+func loadTemplateVectors(ctx context.Context, q *query.Query, embedder llm.Embedder) (map[int32][]float32, error) {
+	templateVectorCache.Lock()
+	defer templateVectorCache.Unlock()
+
+	generation := catalogGeneration.Load()
+	if v := templateVectorCache.vectors; v != nil && templateVectorCache.model == embedder.Model() &&
+		templateVectorCache.generation == generation && time.Since(templateVectorCache.loadedAt) < CatalogCacheTTL {
+		return v, nil
+	}
+
+	vectors, err := refreshTemplateEmbeddings(ctx, q, embedder)
+	if err != nil {
+		return nil, err
+	}
+	templateVectorCache.model = embedder.Model()
+	templateVectorCache.vectors = vectors
+	templateVectorCache.generation = generation
+	templateVectorCache.loadedAt = time.Now()
+	return vectors, nil
+}
+
+func refreshTemplateEmbeddings(ctx context.Context, q *query.Query, embedder llm.Embedder) (map[int32][]float32, error) {
+	corpus, err := loadSearchCorpus(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.TemplateEmbedding.WithContext(ctx).
+		Where(q.TemplateEmbedding.Model.Eq(embedder.Model())).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[int32]*model.TemplateEmbedding, len(rows))
+	for _, row := range rows {
+		stored[row.TemplateID] = row
+	}
+
+	vectors := make(map[int32][]float32, len(corpus.Documents))
+	stale := make([]int32, 0)
+	texts := make(map[int32]string)
+	for id, doc := range corpus.Documents {
+		text := embeddingText(doc)
+		if row := stored[id]; row != nil && row.ContentHash == contentHash(text) {
+			vectors[id] = decodeVector(row.Vector)
+			continue
+		}
+		stale = append(stale, id)
+		texts[id] = text
+	}
+	sort.Slice(stale, func(a, b int) bool { return stale[a] < stale[b] })
+
+	for start := 0; start < len(stale); start += embeddingBatchSize {
+		batch := stale[start:min(start+embeddingBatchSize, len(stale))]
+		inputs := make([]string, len(batch))
+		for i, id := range batch {
+			inputs[i] = texts[id]
+		}
+		embedded, err := embedder.Embed(ctx, inputs)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now().UTC()
+		for i, id := range batch {
+			row := stored[id]
+			if row == nil {
+				row = &model.TemplateEmbedding{TemplateID: id, Model: embedder.Model(), CreatedAt: now}
+			}
+			row.ContentHash = contentHash(inputs[i])
+			row.Dimensions = int32(len(embedded[i]))
+			row.Vector = encodeVector(embedded[i])
+			row.UpdatedAt = now
+			if err := q.TemplateEmbedding.WithContext(ctx).Save(row); err != nil {
+				return nil, err
+			}
+			vectors[id] = embedded[i]
+		}
+	}
+	return vectors, nil
+}
+
+// refreshEmbeddingsInBackground embeds edited templates right after a catalog
+// change so the next suggestion does not wait for it. A refresh already
+// running is not repeated; the next suggestion catches anything it missed.
+func refreshEmbeddingsInBackground() {
+	embedder := llm.Embeddings
+	if embedder == nil || query.Q == nil || !embeddingRefreshRunning.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer embeddingRefreshRunning.Store(false)
+		ctx, cancel := context.WithTimeout(context.Background(), embeddingRefreshTimeout)
+		defer cancel()
+		if _, err := loadTemplateVectors(ctx, query.Q, embedder); err != nil {
+			log.Printf("Failed to refresh template embeddings: %v", err)
+		}
+	}()
+}
+
+// Vectors are stored as little-endian float32s.
+func encodeVector(v []float32) []byte {
+	b := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(x))
+	}
+	return b
+}
+
+func decodeVector(b []byte) []float32 {
+	v := make([]float32, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return v
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+	"api/infra/redis"
+)
+
+const (
+	KeySitemap      = "biz:say_right:sitemap"       // rendered sitemap.xml
+	KeyTemplateFeed = "biz:say_right:template_feed" // rendered JSON feed
+	defaultSiteURL  = "https://simpleaiwork.com/sayright"
+)
+
+// FeedItem carries what a marketing page needs for its Open Graph tags.
+type FeedItem struct {
+	Slug         string    `json:"slug"`
+	Title        string    `json:"title"`
+	Summary      string    `json:"summary"`
+	Category     string    `json:"category"`
+	CategorySlug string    `json:"category_slug"`
+	Tags         []string  `json:"tags"`
+	IsPro        bool      `json:"is_pro"`
+	LastModified time.Time `json:"last_modified"`
+	CanonicalURL string    `json:"canonical_url"`
+}
+
+type TemplateFeed struct {
+	GeneratedAt time.Time  `json:"generated_at"`
+	Templates   []FeedItem `json:"templates"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// FeedService renders the public, crawlable views of the published catalog.
+// Both are cached and dropped together with the catalog cache.
+type FeedService interface {
+	Sitemap(ctx context.Context) ([]byte, error)
+	Feed(ctx context.Context) ([]byte, error)
+}
+
+type feedService struct {
+	q       *query.Query
+	siteURL string
+}
+
+// NewFeedService builds canonical URLs from SAYRIGHT_SITE_URL.
+func NewFeedService() FeedService {
+	return &feedService{
+		q:       query.Q,
+		siteURL: siteURLFromEnv(),
+	}
+}
+
+// siteURLFromEnv is the public site root without a trailing slash.
+func siteURLFromEnv() string {
+	siteURL := strings.TrimRight(os.Getenv("SAYRIGHT_SITE_URL"), "/")
+	if siteURL == "" {
+		return defaultSiteURL
+	}
+	return siteURL
+}
+
+func (s *feedService) Sitemap(ctx context.Context) ([]byte, error) {
+	return s.cached(ctx, KeySitemap, func(items []FeedItem) ([]byte, error) {
+		set := sitemapURLSet{
+			XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9",
+			URLs:  make([]sitemapURL, 0, len(items)),
+		}
+		for _, item := range items {
+			set.URLs = append(set.URLs, sitemapURL{
+				Loc:     item.CanonicalURL,
+				LastMod: item.LastModified.UTC().Format(time.RFC3339),
+			})
+		}
+		b, err := xml.MarshalIndent(set, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(xml.Header), b...), nil
+	})
+}
+
+func (s *feedService) Feed(ctx context.Context) ([]byte, error) {
+	return s.cached(ctx, KeyTemplateFeed, func(items []FeedItem) ([]byte, error) {
+		return json.Marshal(TemplateFeed{
+			GeneratedAt: time.Now().UTC(),
+			Templates:   items,
+		})
+	})
+}
+
+// cached serves key from Redis, rendering and storing it on a miss.
+// Cache errors are logged and fall through to rendering.
+func (s *feedService) cached(ctx context.Context, key string, render func([]FeedItem) ([]byte, error)) ([]byte, error) {
+	if b, err := redis.Client.Get(ctx, key).Bytes(); err == nil {
+		return b, nil
+	}
+
+	items, err := s.items(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b, err := render(items)
+	if err != nil {
+		return nil, err
+	}
+	if err := redis.Client.Set(ctx, key, b, CatalogCacheTTL).Err(); err != nil {
+		log.Printf("Failed to cache %s: %v", key, err)
+	}
+	return b, nil
+}
+
+func (s *feedService) items(ctx context.Context) ([]FeedItem, error) {
+	catalog, err := loadCatalog(ctx, s.q)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[int32]*model.Category, len(catalog.Categories))
+	for _, c := range catalog.Categories {
+		categories[c.ID] = c
+	}
+	templateIDs := make([]int32, 0, len(catalog.Templates))
+	for _, t := range catalog.Templates {
+		templateIDs = append(templateIDs, t.ID)
+	}
+
+	details := make(map[int32]*model.TemplateDetail, len(templateIDs))
+	if len(templateIDs) > 0 {
+		rows, err := s.q.TemplateDetail.WithContext(ctx).
+			Where(s.q.TemplateDetail.TemplateID.In(templateIDs...)).
+			Find()
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range rows {
+			details[d.TemplateID] = d
+		}
+	}
+
+	tags := catalog.tagsByTemplate()
+	items := make([]FeedItem, 0, len(catalog.Templates))
+	for _, t := range catalog.Templates {
+		category, ok := categories[t.CategoryID]
+		detail, hasDetail := details[t.ID]
+		if !ok || !hasDetail {
+			continue
+		}
+		result := buildTemplateDetailResult(t, detail, category, toTemplateTagItems(tags[t.ID], ""))
+		items = append(items, FeedItem{
+			Slug:         result.Slug,
+			Title:        result.Title,
+			Summary:      result.Description,
+			Category:     result.CategoryName,
+			CategorySlug: result.CategorySlug,
+			Tags:         result.Tags,
+			IsPro:        result.IsPro,
+			LastModified: latestTime(t.UpdatedAt, detail.UpdatedAt),
+			CanonicalURL: s.siteURL + "/templates/" + t.Slug,
+		})
+	}
+	return items, nil
+}
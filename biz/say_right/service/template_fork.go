@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"api/biz/say_right/dal/model"
+)
+
+var ErrNotForked = errors.New("template is not a fork")
+
+// ErrUpstreamGone is returned when a fork's source was deleted or can no
+// longer be read by the user.
+var ErrUpstreamGone = errors.New("source template is no longer available")
+
+// forkFieldOrder lists the snapshot fields a private template has, in the
+// order diffs report them.
+var forkFieldOrder = []string{
+	"title", "description", "tags",
+	"detail.headline", "detail.summary", "detail.reply_soft", "detail.reply_neutral",
+	"detail.reply_firm", "detail.when_not_to_use", "detail.best_practices", "detail.variables",
+}
+
+// UpstreamChange is a field the source changed: From is its value when the
+// fork was last synced, To the current one and Mine the fork's. Conflict
+// means the user edited the field too.
+type UpstreamChange struct {
+	FieldChange
+	Mine     string `json:"mine"`
+	Conflict bool   `json:"conflict"`
+}
+
+type UpstreamDiff struct {
+	SourceTemplateID int32            `json:"source_template_id"`
+	Locale           string           `json:"locale"`
+	Changed          bool             `json:"changed"`
+	Changes          []UpstreamChange `json:"changes"`
+}
+
+// PullInput picks the fields to take from the source. Empty takes every
+// change except conflicts, so the user's own edits are kept.
+type PullInput struct {
+	Fields []string `json:"fields"`
+}
+
+func (s *userTemplateService) ForkTemplate(ctx context.Context, userID int32, templateID int32, locale LocaleRequest) (*UserTemplateResult, error) {
+	detail, err := s.templates.GetTemplateDetail(ctx, userID, templateID, locale)
+	if err != nil {
+		return nil, err
+	}
+	source := forkSource(detail)
+	snapshot, err := json.Marshal(source)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	fork := &model.UserTemplate{
+		OwnerID:          userID,
+		SourceTemplateID: &detail.ID,
+		SourceLocale:     detail.Locale,
+		SourceSnapshot:   string(snapshot),
+		CreatedAt:        now,
+	}
+	applyUserTemplateInput(fork, source, now)
+	if err := s.create(ctx, fork); err != nil {
+		return nil, err
+	}
+	return toUserTemplateResult(fork), nil
+}
+
+func (s *userTemplateService) UpstreamDiff(ctx context.Context, userID int32, templateID int32) (*UpstreamDiff, error) {
+	fork, err := s.find(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	upstream, err := s.upstream(ctx, fork)
+	if err != nil {
+		return nil, err
+	}
+	changes := upstreamChanges(fork, upstream)
+	return &UpstreamDiff{
+		SourceTemplateID: *fork.SourceTemplateID,
+		Locale:           fork.SourceLocale,
+		Changed:          len(changes) > 0,
+		Changes:          changes,
+	}, nil
+}
+
+func (s *userTemplateService) PullUpstream(ctx context.Context, userID int32, templateID int32, input PullInput) (*UserTemplateResult, error) {
+	fork, err := s.findEditable(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	upstream, err := s.upstream(ctx, fork)
+	if err != nil {
+		return nil, err
+	}
+
+	picked := make(map[string]bool, len(input.Fields))
+	for _, field := range input.Fields {
+		if !slices.Contains(forkFieldOrder, field) {
+			return nil, ValidationError{Field: "fields", Message: "unknown field " + field}
+		}
+		picked[field] = true
+	}
+
+	merged := userTemplateInputOf(fork)
+	for _, change := range upstreamChanges(fork, upstream) {
+		take := picked[change.Field]
+		if len(picked) == 0 {
+			take = !change.Conflict
+		}
+		if take {
+			copyForkField(&merged, upstream, change.Field)
+		}
+	}
+	if err := validateUserTemplate(merged); err != nil {
+		return nil, err
+	}
+
+	// The source as it is now becomes the base, so changes not taken count
+	// as the user's own edits from here on
+	snapshot, err := json.Marshal(upstream)
+	if err != nil {
+		return nil, err
+	}
+	fork.SourceSnapshot = string(snapshot)
+	applyUserTemplateInput(fork, merged, time.Now().UTC())
+	if err := s.q.UserTemplate.WithContext(ctx).Save(fork); err != nil {
+		return nil, err
+	}
+	return toUserTemplateResult(fork), nil
+}
+
+// upstream reads the fork's source in the locale it was forked in.
+func (s *userTemplateService) upstream(ctx context.Context, fork *model.UserTemplate) (UserTemplateInput, error) {
+	if fork.SourceTemplateID == nil {
+		if fork.SourceLocale == "" {
+			return UserTemplateInput{}, ErrNotForked
+		}
+		// The source was deleted and the reference cleared
+		return UserTemplateInput{}, ErrUpstreamGone
+	}
+	detail, err := s.templates.GetTemplateDetail(ctx, fork.OwnerID, *fork.SourceTemplateID, LocaleRequest{Locale: fork.SourceLocale})
+	if err == ErrTemplateNotFound {
+		return UserTemplateInput{}, ErrUpstreamGone
+	}
+	if err != nil {
+		return UserTemplateInput{}, err
+	}
+	return forkSource(detail), nil
+}
+
+// upstreamChanged reports whether a fork's source has changed since it was
+// last synced. Sources that cannot be read count as unchanged.
+func (s *userTemplateService) upstreamChanged(ctx context.Context, fork *model.UserTemplate) bool {
+	if fork.SourceTemplateID == nil {
+		return false
+	}
+	upstream, err := s.upstream(ctx, fork)
+	if err != nil {
+		return false
+	}
+	return len(upstreamChanges(fork, upstream)) > 0
+}
+
+func upstreamChanges(fork *model.UserTemplate, upstream UserTemplateInput) []UpstreamChange {
+	var base UserTemplateInput
+	// A broken snapshot makes every source field look changed, which is the
+	// safe side
+	_ = json.Unmarshal([]byte(fork.SourceSnapshot), &base)
+
+	baseFields := forkFields(base)
+	upstreamFields := forkFields(upstream)
+	mineFields := forkFields(userTemplateInputOf(fork))
+
+	changes := make([]UpstreamChange, 0)
+	for _, name := range forkFieldOrder {
+		if baseFields[name] == upstreamFields[name] {
+			continue
+		}
+		changes = append(changes, UpstreamChange{
+			FieldChange: FieldChange{Field: name, From: baseFields[name], To: upstreamFields[name]},
+			Mine:        mineFields[name],
+			Conflict:    mineFields[name] != baseFields[name] && mineFields[name] != upstreamFields[name],
+		})
+	}
+	return changes
+}
+
+// forkSource is the part of a catalog template a fork copies. The detail's
+// title and description already prefer the headline and summary.
+func forkSource(detail *TemplateDetailResult) UserTemplateInput {
+	input := UserTemplateInput{
+		Title:       detail.Title,
+		Description: detail.Description,
+		Tags:        detail.Tags,
+		Detail: TemplateDetailInput{
+			ReplySoft:     detail.ReplySoft,
+			ReplyNeutral:  detail.ReplyNeutral,
+			ReplyFirm:     detail.ReplyFirm,
+			WhenNotToUse:  detail.WhenNotToUse,
+			BestPractices: detail.BestPractices,
+			Variables:     detail.Variables,
+		},
+	}
+	// Round-trip through the stored form so comparisons see what a fork
+	// would actually hold
+	var t model.UserTemplate
+	applyUserTemplateInput(&t, input, time.Time{})
+	return userTemplateInputOf(&t)
+}
+
+func userTemplateInputOf(t *model.UserTemplate) UserTemplateInput {
+	return UserTemplateInput{
+		Title:       t.Title,
+		Description: t.Description,
+		Tags:        splitTags(t.TagsText),
+		Detail: TemplateDetailInput{
+			Headline:      t.Headline,
+			Summary:       t.Summary,
+			ReplySoft:     t.ReplySoft,
+			ReplyNeutral:  t.ReplyNeutral,
+			ReplyFirm:     t.ReplyFirm,
+			WhenNotToUse:  t.WhenNotToUse,
+			BestPractices: splitLines(t.BestPractices),
+			Variables:     parseVariables(t.Variables),
+		},
+	}
+}
+
+func forkFields(input UserTemplateInput) map[string]string {
+	return snapshotFields(TemplateInput{
+		Title:       input.Title,
+		Description: input.Description,
+		Tags:        input.Tags,
+		Detail:      input.Detail,
+	})
+}
+
+func copyForkField(dst *UserTemplateInput, src UserTemplateInput, field string) {
+	switch field {
+	case "title":
+		dst.Title = src.Title
+	case "description":
+		dst.Description = src.Description
+	case "tags":
+		dst.Tags = src.Tags
+	case "detail.headline":
+		dst.Detail.Headline = src.Detail.Headline
+	case "detail.summary":
+		dst.Detail.Summary = src.Detail.Summary
+	case "detail.reply_soft":
+		dst.Detail.ReplySoft = src.Detail.ReplySoft
+	case "detail.reply_neutral":
+		dst.Detail.ReplyNeutral = src.Detail.ReplyNeutral
+	case "detail.reply_firm":
+		dst.Detail.ReplyFirm = src.Detail.ReplyFirm
+	case "detail.when_not_to_use":
+		dst.Detail.WhenNotToUse = src.Detail.WhenNotToUse
+	case "detail.best_practices":
+		dst.Detail.BestPractices = src.Detail.BestPractices
+	case "detail.variables":
+		dst.Detail.Variables = src.Detail.Variables
+	}
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+const (
+	RevisionDraft     = "draft"
+	RevisionPublished = "published"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+var ErrNoDraft = errors.New("no pending draft")
+
+type TemplateRevisionItem struct {
+	ID         int32         `json:"id"`
+	TemplateID int32         `json:"template_id"`
+	Status     string        `json:"status"`
+	AuthorID   int32         `json:"author_id"`
+	Note       string        `json:"note"`
+	CreatedAt  time.Time     `json:"created_at"`
+	Snapshot   TemplateInput `json:"snapshot"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+func (s *contentService) ListRevisions(ctx context.Context, templateID int32) ([]*TemplateRevisionItem, error) {
+	revisions, err := s.q.TemplateRevision.WithContext(ctx).
+		Where(s.q.TemplateRevision.TemplateID.Eq(templateID)).
+		Order(s.q.TemplateRevision.ID.Desc()).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*TemplateRevisionItem, 0, len(revisions))
+	for _, r := range revisions {
+		item, err := toRevisionItem(r)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// DiffRevisions compares two revisions of the same template field by field.
+func (s *contentService) DiffRevisions(ctx context.Context, templateID, fromID, toID int32) ([]FieldChange, error) {
+	from, err := s.getRevision(ctx, s.q, templateID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.getRevision(ctx, s.q, templateID, toID)
+	if err != nil {
+		return nil, err
+	}
+	return diffSnapshots(from.Snapshot, to.Snapshot), nil
+}
+
+// RestoreRevision makes an older revision's content live again; visibility,
+// the Pro flag and position stay as they are. The restore itself is recorded
+// as a new published revision so it can be undone the same way.
+func (s *contentService) RestoreRevision(ctx context.Context, templateID, revisionID int32, expectedUpdatedAt time.Time) (*TemplateWithDetail, error) {
+	var result *TemplateWithDetail
+	err := s.write(ctx, func(tx *query.Query) error {
+		revision, err := s.getRevision(ctx, tx, templateID, revisionID)
+		if err != nil {
+			return err
+		}
+		live, err := tx.Template.WithContext(ctx).Where(tx.Template.ID.Eq(templateID)).First()
+		if err != nil {
+			if isNotFound(err) {
+				return ErrTemplateNotFound
+			}
+			return err
+		}
+		keepLiveFlags(&revision.Snapshot, live)
+		if err := validateTemplate(revision.Snapshot); err != nil {
+			return err
+		}
+		note := "restored revision " + strconv.Itoa(int(revisionID))
+		result, err = s.applyTemplate(ctx, tx, templateID, revision.Snapshot, expectedUpdatedAt, "content.template.restore", note)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SaveDraft stores an edit without touching the live template. The template
+// points at the draft until it is published or superseded by a content edit.
+func (s *contentService) SaveDraft(ctx context.Context, templateID int32, input TemplateInput) (*TemplateRevisionItem, error) {
+	if err := validateTemplate(input); err != nil {
+		return nil, err
+	}
+
+	var revision *model.TemplateRevision
+	err := s.q.Transaction(func(tx *query.Query) error {
+		count, err := tx.Template.WithContext(ctx).Where(tx.Template.ID.Eq(templateID)).Count()
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrTemplateNotFound
+		}
+		if err := ensureCategoryExists(ctx, tx, input.CategoryID); err != nil {
+			return err
+		}
+
+		input.UpdatedAt = time.Time{}
+		revision, err = s.insertRevision(ctx, tx, templateID, RevisionDraft, input, "")
+		if err != nil {
+			return err
+		}
+		// updated_at stays put: the live template has not changed
+		_, err = tx.Template.WithContext(ctx).
+			Where(tx.Template.ID.Eq(templateID)).
+			UpdateSimple(tx.Template.DraftRevisionID.Value(revision.ID))
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.template.save_draft",
+			TargetType: "template",
+			TargetID:   strconv.Itoa(int(templateID)),
+			Detail:     map[string]interface{}{"revision_id": revision.ID},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toRevisionItem(revision)
+}
+
+// PreviewDraft renders the pending draft the way GetTemplateDetail would show it once published.
+func (s *contentService) PreviewDraft(ctx context.Context, templateID int32) (*TemplateDetailResult, error) {
+	draft, live, err := s.pendingDraft(ctx, s.q, templateID)
+	if err != nil {
+		return nil, err
+	}
+	keepLiveFlags(&draft.Snapshot, live)
+
+	category, err := s.q.Category.WithContext(ctx).Where(s.q.Category.ID.Eq(draft.Snapshot.CategoryID)).First()
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	template := &model.Template{ID: templateID, Slug: draft.Snapshot.Slug}
+	applyTemplateInput(template, draft.Snapshot, draft.CreatedAt)
+	detail := &model.TemplateDetail{TemplateID: templateID}
+	applyTemplateDetailInput(detail, draft.Snapshot.Detail, draft.CreatedAt)
+	// Draft tags are only linked once published
+	return buildTemplateDetailResult(template, detail, category, plainTagItems(splitTags(template.TagsText))), nil
+}
+
+func (s *contentService) PublishDraft(ctx context.Context, templateID int32, expectedUpdatedAt time.Time) (*TemplateWithDetail, error) {
+	var result *TemplateWithDetail
+	err := s.write(ctx, func(tx *query.Query) error {
+		draft, live, err := s.pendingDraft(ctx, tx, templateID)
+		if err != nil {
+			return err
+		}
+		keepLiveFlags(&draft.Snapshot, live)
+		note := "published draft " + strconv.Itoa(int(draft.ID))
+		result, err = s.applyTemplate(ctx, tx, templateID, draft.Snapshot, expectedUpdatedAt, "content.template.publish", note)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// pendingDraft returns the draft the template points at, along with the live
+// template.
+func (s *contentService) pendingDraft(ctx context.Context, tx *query.Query, templateID int32) (*TemplateRevisionItem, *model.Template, error) {
+	template, err := tx.Template.WithContext(ctx).Where(tx.Template.ID.Eq(templateID)).First()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil, ErrTemplateNotFound
+		}
+		return nil, nil, err
+	}
+	if template.DraftRevisionID == nil {
+		return nil, nil, ErrNoDraft
+	}
+	draft, err := s.getRevision(ctx, tx, templateID, *template.DraftRevisionID)
+	if err != nil {
+		return nil, nil, ErrNoDraft
+	}
+	return draft, template, nil
+}
+
+// keepLiveFlags makes a draft or restored revision carry the template's
+// current visibility, Pro flag and position. Drafts and restores only change
+// content; those fields are changed with SetTemplateFlags and
+// ReorderTemplates, which may run after the revision was saved.
+func keepLiveFlags(snapshot *TemplateInput, live *model.Template) {
+	snapshot.IsPro = live.IsPro != 0
+	snapshot.IsActive = live.IsActive != 0
+	snapshot.SortOrder = live.SortOrder
+}
+
+func (s *contentService) getRevision(ctx context.Context, tx *query.Query, templateID, revisionID int32) (*TemplateRevisionItem, error) {
+	revision, err := tx.TemplateRevision.WithContext(ctx).
+		Where(tx.TemplateRevision.ID.Eq(revisionID), tx.TemplateRevision.TemplateID.Eq(templateID)).
+		First()
+	if err != nil {
+		return nil, ErrRevisionNotFound
+	}
+	return toRevisionItem(revision)
+}
+
+func (s *contentService) recordRevision(ctx context.Context, tx *query.Query, templateID int32, status string, snapshot TemplateInput, note string) error {
+	_, err := s.insertRevision(ctx, tx, templateID, status, snapshot, note)
+	return err
+}
+
+// recordLiveRevision snapshots the template as currently stored in tx.
+func (s *contentService) recordLiveRevision(ctx context.Context, tx *query.Query, templateID int32, note string) error {
+	template, err := tx.Template.WithContext(ctx).Where(tx.Template.ID.Eq(templateID)).First()
+	if err != nil {
+		return ErrTemplateNotFound
+	}
+	detail, err := tx.TemplateDetail.WithContext(ctx).Where(tx.TemplateDetail.TemplateID.Eq(templateID)).First()
+	if err != nil {
+		detail = &model.TemplateDetail{TemplateID: templateID}
+	}
+	return s.recordRevision(ctx, tx, templateID, RevisionPublished, snapshotOf(template, detail), note)
+}
+
+func (s *contentService) insertRevision(ctx context.Context, tx *query.Query, templateID int32, status string, snapshot TemplateInput, note string) (*model.TemplateRevision, error) {
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	revision := &model.TemplateRevision{
+		TemplateID: templateID,
+		Status:     status,
+		Snapshot:   string(b),
+		AuthorID:   ActorFromContext(ctx).ID,
+		Note:       truncate(note, 255),
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := tx.TemplateRevision.WithContext(ctx).Create(revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+func toRevisionItem(r *model.TemplateRevision) (*TemplateRevisionItem, error) {
+	item := &TemplateRevisionItem{
+		ID:         r.ID,
+		TemplateID: r.TemplateID,
+		Status:     r.Status,
+		AuthorID:   r.AuthorID,
+		Note:       r.Note,
+		CreatedAt:  r.CreatedAt,
+	}
+	if err := json.Unmarshal([]byte(r.Snapshot), &item.Snapshot); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// snapshotOf converts stored rows back into the editor input shape.
+func snapshotOf(template *model.Template, detail *model.TemplateDetail) TemplateInput {
+	return TemplateInput{
+		Slug:        template.Slug,
+		CategoryID:  template.CategoryID,
+		Title:       template.Title,
+		Description: template.Description,
+		Tags:        splitTags(template.TagsText),
+		IsPro:       template.IsPro != 0,
+		SortOrder:   template.SortOrder,
+		IsActive:    template.IsActive != 0,
+		PublishAt:   template.PublishAt,
+		UnpublishAt: template.UnpublishAt,
+		Detail: TemplateDetailInput{
+			Headline:      detail.Headline,
+			Summary:       detail.Summary,
+			ReplySoft:     detail.ReplySoft,
+			ReplyNeutral:  detail.ReplyNeutral,
+			ReplyFirm:     detail.ReplyFirm,
+			WhenNotToUse:  detail.WhenNotToUse,
+			BestPractices: splitLines(detail.BestPractices),
+			Variables:     parseVariables(detail.Variables),
+			Prompt:        detail.Prompt,
+		},
+	}
+}
+
+func diffSnapshots(from, to TemplateInput) []FieldChange {
+	fromFields := snapshotFields(from)
+	toFields := snapshotFields(to)
+
+	changes := make([]FieldChange, 0)
+	for _, name := range snapshotFieldOrder {
+		if fromFields[name] != toFields[name] {
+			changes = append(changes, FieldChange{Field: name, From: fromFields[name], To: toFields[name]})
+		}
+	}
+	return changes
+}
+
+var snapshotFieldOrder = []string{
+	"slug", "category_id", "title", "description", "tags", "is_pro", "sort_order", "is_active",
+	"publish_at", "unpublish_at",
+	"detail.headline", "detail.summary", "detail.reply_soft", "detail.reply_neutral",
+	"detail.reply_firm", "detail.when_not_to_use", "detail.best_practices", "detail.variables",
+	"detail.prompt",
+}
+
+func snapshotFields(s TemplateInput) map[string]string {
+	return map[string]string{
+		"slug":                   s.Slug,
+		"category_id":            strconv.Itoa(int(s.CategoryID)),
+		"title":                  s.Title,
+		"description":            s.Description,
+		"tags":                   strings.Join(s.Tags, ","),
+		"is_pro":                 strconv.FormatBool(s.IsPro),
+		"sort_order":             strconv.Itoa(int(s.SortOrder)),
+		"is_active":              strconv.FormatBool(s.IsActive),
+		"publish_at":             formatScheduleTime(s.PublishAt),
+		"unpublish_at":           formatScheduleTime(s.UnpublishAt),
+		"detail.headline":        s.Detail.Headline,
+		"detail.summary":         s.Detail.Summary,
+		"detail.reply_soft":      s.Detail.ReplySoft,
+		"detail.reply_neutral":   s.Detail.ReplyNeutral,
+		"detail.reply_firm":      s.Detail.ReplyFirm,
+		"detail.when_not_to_use": s.Detail.WhenNotToUse,
+		"detail.best_practices":  strings.Join(s.Detail.BestPractices, "\n"),
+		"detail.variables":       encodeVariables(normalizeVariables(s.Detail.Variables)),
+		"detail.prompt":          s.Detail.Prompt,
+	}
+}
+
+func formatScheduleTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDraftSurvivesFlagsAndReorder(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	svc := NewContentService(NewAuditService())
+
+	category, err := svc.CreateCategory(ctx, CategoryInput{Name: "Work", IsActive: true})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	input := TemplateInput{
+		CategoryID:  category.ID,
+		Title:       "Decline a meeting",
+		Description: "Say no politely",
+		SortOrder:   1,
+		IsActive:    true,
+		Detail: TemplateDetailInput{
+			Headline:     "Decline",
+			Summary:      "A polite no",
+			ReplySoft:    "soft",
+			ReplyNeutral: "neutral",
+			ReplyFirm:    "firm",
+		},
+	}
+	created, err := svc.CreateTemplate(ctx, input)
+	if err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+	id := created.Template.ID
+
+	draft := input
+	draft.Title = "Decline a meeting (new)"
+	draft.Detail.Headline = "Decline kindly"
+	if _, err := svc.SaveDraft(ctx, id, draft); err != nil {
+		t.Fatalf("save draft: %v", err)
+	}
+
+	stored, err := svc.GetTemplate(ctx, id)
+	if err != nil {
+		t.Fatalf("get template: %v", err)
+	}
+	isPro := true
+	flagged, err := svc.SetTemplateFlags(ctx, id, TemplateFlagsInput{IsPro: &isPro, UpdatedAt: stored.Template.UpdatedAt})
+	if err != nil {
+		t.Fatalf("set flags: %v", err)
+	}
+	if err := svc.ReorderTemplates(ctx, []SortItem{{ID: id, SortOrder: 7}}); err != nil {
+		t.Fatalf("reorder: %v", err)
+	}
+
+	preview, err := svc.PreviewDraft(ctx, id)
+	if err != nil {
+		t.Fatalf("preview draft after flag change: %v", err)
+	}
+	if preview.Title != draft.Detail.Headline || !preview.IsPro {
+		t.Errorf("preview = %q pro %v, want draft headline with live Pro flag", preview.Title, preview.IsPro)
+	}
+
+	live, err := svc.GetTemplate(ctx, id)
+	if err != nil {
+		t.Fatalf("get template: %v", err)
+	}
+	published, err := svc.PublishDraft(ctx, id, live.Template.UpdatedAt)
+	if err != nil {
+		t.Fatalf("publish draft: %v", err)
+	}
+	if published.Template.Title != draft.Title {
+		t.Errorf("title = %q, want %q", published.Template.Title, draft.Title)
+	}
+	if published.Template.IsPro != 1 || published.Template.SortOrder != 7 || published.Template.IsActive != 1 {
+		t.Errorf("flags = pro %d active %d sort %d, want the live values kept",
+			published.Template.IsPro, published.Template.IsActive, published.Template.SortOrder)
+	}
+	if flagged.IsPro != 1 {
+		t.Errorf("flag change did not apply")
+	}
+
+	if _, err := svc.PreviewDraft(ctx, id); err != ErrNoDraft {
+		t.Errorf("preview after publish err = %v, want ErrNoDraft", err)
+	}
+}
+
+func TestRestoreKeepsLiveFlags(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	svc := NewContentService(NewAuditService())
+
+	category, err := svc.CreateCategory(ctx, CategoryInput{Name: "Work", IsActive: true})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	input := TemplateInput{
+		CategoryID:  category.ID,
+		Title:       "Decline a meeting",
+		Description: "Say no politely",
+		SortOrder:   1,
+		IsActive:    true,
+		Detail: TemplateDetailInput{
+			Headline:     "Decline",
+			Summary:      "A polite no",
+			ReplySoft:    "soft",
+			ReplyNeutral: "neutral",
+			ReplyFirm:    "firm",
+		},
+	}
+	created, err := svc.CreateTemplate(ctx, input)
+	if err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+	id := created.Template.ID
+	revisions, err := svc.ListRevisions(ctx, id)
+	if err != nil || len(revisions) == 0 {
+		t.Fatalf("list revisions = %d, %v; want the created revision", len(revisions), err)
+	}
+	first := revisions[len(revisions)-1]
+
+	stored, err := svc.GetTemplate(ctx, id)
+	if err != nil {
+		t.Fatalf("get template: %v", err)
+	}
+	isPro, isActive := true, false
+	flagged, err := svc.SetTemplateFlags(ctx, id, TemplateFlagsInput{IsPro: &isPro, IsActive: &isActive, UpdatedAt: stored.Template.UpdatedAt})
+	if err != nil {
+		t.Fatalf("set flags: %v", err)
+	}
+
+	restored, err := svc.RestoreRevision(ctx, id, first.ID, flagged.UpdatedAt)
+	if err != nil {
+		t.Fatalf("restore revision: %v", err)
+	}
+	if restored.Template.IsPro != 1 || restored.Template.IsActive != 0 {
+		t.Errorf("flags = pro %d active %d, want the live values kept", restored.Template.IsPro, restored.Template.IsActive)
+	}
+}
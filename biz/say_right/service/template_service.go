@@ -4,48 +4,140 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
+	"api/biz/say_right/dal/model"
 	"api/biz/say_right/dal/query"
+
+	"gorm.io/gen"
 )
 
 var ErrProRequired = errors.New("pro required")
 var ErrTemplateNotFound = errors.New("template not found")
 
+// teaserMaxLength bounds the reply excerpt shown on public preview pages.
+const teaserMaxLength = 160
+
+// SlugMovedError is returned when a template is looked up by a slug it no
+// longer uses; Slug is the current one.
+type SlugMovedError struct {
+	Slug string
+}
+
+func (e *SlugMovedError) Error() string {
+	return "template slug moved to " + e.Slug
+}
+
 type TemplateItem struct {
 	ID          int32    `json:"id"`
+	Slug        string   `json:"slug"`
 	Title       string   `json:"title"`
 	Description string   `json:"description"`
 	Tags        []string `json:"tags"`
-	IsPro       bool     `json:"is_pro"`
-	IsLocked    bool     `json:"is_locked"`
+	// TagDetails carries the slug, label and color of each of Tags.
+	TagDetails []TemplateTagItem `json:"tag_details"`
+	IsPro      bool              `json:"is_pro"`
+	IsLocked   bool              `json:"is_locked"`
+	// IsOwned marks the user's own templates, which are read through
+	// /my/templates/:id rather than /templates/:id.
+	IsOwned bool `json:"is_owned"`
+	// OrganizationID marks templates from a team library, which are read
+	// through /orgs/:organization_id/templates/:id.
+	OrganizationID int32 `json:"organization_id,omitempty"`
+	// IsFavorite and LastUsedAt are the user's own state on the template;
+	// LastUsedAt is the last view or copy.
+	IsFavorite bool       `json:"is_favorite"`
+	LastUsedAt *time.Time `json:"last_used_at"`
 }
 
 type CategoryWithTemplates struct {
 	ID          int32          `json:"id"`
+	Slug        string         `json:"slug"`
 	Name        string         `json:"name"`
 	Description string         `json:"description"`
 	Icon        string         `json:"icon"`
 	Templates   []TemplateItem `json:"templates"`
 }
 
+// CategoryNode is one category in the catalog tree with its own templates
+// and its visible subcategories.
+type CategoryNode struct {
+	ID          int32           `json:"id"`
+	Slug        string          `json:"slug"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Icon        string          `json:"icon"`
+	Templates   []TemplateItem  `json:"templates"`
+	Children    []*CategoryNode `json:"children"`
+}
+
+type CollectionWithTemplates struct {
+	ID          int32          `json:"id"`
+	Slug        string         `json:"slug"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Templates   []TemplateItem `json:"templates"`
+}
+
+type CatalogTree struct {
+	Locale      string                    `json:"locale"`
+	Categories  []*CategoryNode           `json:"categories"`
+	Collections []CollectionWithTemplates `json:"collections"`
+}
+
 type TemplateDetailResult struct {
-	ID            int32    `json:"id"`
-	CategoryID    int32    `json:"category_id"`
-	CategoryName  string   `json:"category_name"`
-	Title         string   `json:"title"`
-	Description   string   `json:"description"`
-	Tags          []string `json:"tags"`
-	IsPro         bool     `json:"is_pro"`
-	ReplySoft     string   `json:"reply_soft"`
-	ReplyNeutral  string   `json:"reply_neutral"`
-	ReplyFirm     string   `json:"reply_firm"`
-	WhenNotToUse  string   `json:"when_not_to_use"`
-	BestPractices []string `json:"best_practices"`
+	ID            int32              `json:"id"`
+	Slug          string             `json:"slug"`
+	Locale        string             `json:"locale"`
+	CategoryID    int32              `json:"category_id"`
+	CategorySlug  string             `json:"category_slug"`
+	CategoryName  string             `json:"category_name"`
+	Title         string             `json:"title"`
+	Description   string             `json:"description"`
+	Tags          []string           `json:"tags"`
+	TagDetails    []TemplateTagItem  `json:"tag_details"`
+	IsPro         bool               `json:"is_pro"`
+	ReplySoft     string             `json:"reply_soft"`
+	ReplyNeutral  string             `json:"reply_neutral"`
+	ReplyFirm     string             `json:"reply_firm"`
+	WhenNotToUse  string             `json:"when_not_to_use"`
+	BestPractices []string           `json:"best_practices"`
+	Variables     []TemplateVariable `json:"variables"`
+}
+
+// TemplatePreview is the public teaser of a template, safe to show to
+// anonymous visitors and crawlers; it never includes the full replies.
+type TemplatePreview struct {
+	Slug         string            `json:"slug"`
+	Locale       string            `json:"locale"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	CategorySlug string            `json:"category_slug"`
+	CategoryName string            `json:"category_name"`
+	Tags         []string          `json:"tags"`
+	TagDetails   []TemplateTagItem `json:"tag_details"`
+	IsPro        bool              `json:"is_pro"`
+	Teaser       string            `json:"teaser"`
+	UpdatedAt    time.Time         `json:"updated_at"`
 }
 
+// TemplateService serves the catalog to users. Text is translated into the
+// locale resolved from the LocaleRequest and the user's saved preference.
 type TemplateService interface {
-	ListTemplatesByCategory(ctx context.Context, userID int32) ([]CategoryWithTemplates, error)
-	GetTemplateDetail(ctx context.Context, userID int32, templateID int32) (*TemplateDetailResult, error)
+	// ListTemplatesByCategory lists visible templates; with tagSlugs set, only
+	// templates carrying every one of those tags are returned. The user's own
+	// templates come first under the MyTemplatesSlug category, followed by
+	// the library of each team they belong to.
+	ListTemplatesByCategory(ctx context.Context, userID int32, tagSlugs []string, locale LocaleRequest) ([]CategoryWithTemplates, error)
+	// GetCatalogTree returns categories nested under their parents plus the
+	// curated collections. Children of hidden categories are hidden too.
+	GetCatalogTree(ctx context.Context, userID int32, locale LocaleRequest) (*CatalogTree, error)
+	GetTemplateDetail(ctx context.Context, userID int32, templateID int32, locale LocaleRequest) (*TemplateDetailResult, error)
+	// GetTemplateDetailBySlug returns *SlugMovedError for a slug the template used before.
+	GetTemplateDetailBySlug(ctx context.Context, userID int32, slug string, locale LocaleRequest) (*TemplateDetailResult, error)
+	GetTemplatePreview(ctx context.Context, slug string, locale LocaleRequest) (*TemplatePreview, error)
+	// RenderTemplate fills the template's variables into its three replies.
+	RenderTemplate(ctx context.Context, userID int32, templateID int32, input RenderInput, locale LocaleRequest) (*RenderedReplies, error)
 }
 
 type templateService struct {
@@ -58,46 +150,58 @@ func NewTemplateService() TemplateService {
 	}
 }
 
-func (s *templateService) ListTemplatesByCategory(ctx context.Context, userID int32) ([]CategoryWithTemplates, error) {
+func (s *templateService) ListTemplatesByCategory(ctx context.Context, userID int32, tagSlugs []string, locale LocaleRequest) ([]CategoryWithTemplates, error) {
 	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
 	if err != nil {
 		return nil, err
 	}
 
-	categories, err := s.q.Category.WithContext(ctx).
-		Where(s.q.Category.IsActive.Eq(1)).
-		Order(s.q.Category.SortOrder, s.q.Category.ID).
-		Find()
+	catalog, err := loadCatalog(ctx, s.q)
 	if err != nil {
 		return nil, err
 	}
-
-	templates, err := s.q.Template.WithContext(ctx).
-		Where(s.q.Template.IsActive.Eq(1)).
-		Order(s.q.Template.CategoryID, s.q.Template.SortOrder, s.q.Template.ID).
-		Find()
+	resolved := locale.Resolve(user.Locale)
+	catalog = catalog.localized(resolved)
+	activity, err := loadTemplateActivity(ctx, s.q, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	var tagged map[int32]bool
+	if len(tagSlugs) > 0 {
+		tagged = catalog.templatesWithTags(tagSlugs)
+	}
+
+	tags := catalog.tagsByTemplate()
 	templatesByCategory := make(map[int32][]TemplateItem)
-	for _, t := range templates {
-		isPro := t.IsPro != 0
-		item := TemplateItem{
-			ID:          t.ID,
-			Title:       t.Title,
-			Description: t.Description,
-			Tags:        splitTags(t.TagsText),
-			IsPro:       isPro,
-			IsLocked:    isPro && user.IsPro == 0,
+	for _, t := range catalog.Templates {
+		if tagged != nil && !tagged[t.ID] {
+			continue
 		}
+		item := toTemplateItem(t, toTemplateTagItems(tags[t.ID], resolved), user, activity)
 		templatesByCategory[t.CategoryID] = append(templatesByCategory[t.CategoryID], item)
 	}
 
-	result := make([]CategoryWithTemplates, 0, len(categories))
-	for _, c := range categories {
+	result := make([]CategoryWithTemplates, 0, len(catalog.Categories)+1)
+	own, err := myTemplatesCategory(ctx, s.q, userID, tagSlugs, resolved)
+	if err != nil {
+		return nil, err
+	}
+	if own != nil {
+		result = append(result, *own)
+	}
+	teams, err := teamTemplateCategories(ctx, s.q, userID, tagSlugs)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, teams...)
+	for _, c := range catalog.Categories {
+		if tagged != nil && len(templatesByCategory[c.ID]) == 0 {
+			continue
+		}
 		result = append(result, CategoryWithTemplates{
 			ID:          c.ID,
+			Slug:        c.Slug,
 			Name:        c.Name,
 			Description: c.Description,
 			Icon:        c.Icon,
@@ -108,60 +212,315 @@ func (s *templateService) ListTemplatesByCategory(ctx context.Context, userID in
 	return result, nil
 }
 
-func (s *templateService) GetTemplateDetail(ctx context.Context, userID int32, templateID int32) (*TemplateDetailResult, error) {
+func (s *templateService) GetCatalogTree(ctx context.Context, userID int32, locale LocaleRequest) (*CatalogTree, error) {
 	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
 	if err != nil {
 		return nil, err
 	}
 
-	template, err := s.q.Template.WithContext(ctx).
-		Where(s.q.Template.ID.Eq(templateID)).
-		Where(s.q.Template.IsActive.Eq(1)).
-		First()
+	catalog, err := loadCatalog(ctx, s.q)
+	if err != nil {
+		return nil, err
+	}
+	resolved := locale.Resolve(user.Locale)
+	catalog = catalog.localized(resolved)
+	activity, err := loadTemplateActivity(ctx, s.q, userID)
 	if err != nil {
-		return nil, ErrTemplateNotFound
+		return nil, err
+	}
+
+	nodes := make(map[int32]*CategoryNode, len(catalog.Categories))
+	for _, c := range catalog.Categories {
+		nodes[c.ID] = &CategoryNode{
+			ID:          c.ID,
+			Slug:        c.Slug,
+			Name:        c.Name,
+			Description: c.Description,
+			Icon:        c.Icon,
+			Templates:   []TemplateItem{},
+			Children:    []*CategoryNode{},
+		}
+	}
+
+	tags := catalog.tagsByTemplate()
+	templates := make(map[int32]TemplateItem, len(catalog.Templates))
+	for _, t := range catalog.Templates {
+		node, ok := nodes[t.CategoryID]
+		if !ok {
+			continue
+		}
+		item := toTemplateItem(t, toTemplateTagItems(tags[t.ID], resolved), user, activity)
+		node.Templates = append(node.Templates, item)
+		templates[t.ID] = item
 	}
 
-	isPro := template.IsPro != 0
-	if isPro && user.IsPro == 0 {
+	// Categories arrive in sort order, so appending keeps siblings sorted
+	tree := &CatalogTree{
+		Locale:      resolved,
+		Categories:  []*CategoryNode{},
+		Collections: make([]CollectionWithTemplates, 0, len(catalog.Collections)),
+	}
+	for _, c := range catalog.Categories {
+		node := nodes[c.ID]
+		if c.ParentID == nil {
+			tree.Categories = append(tree.Categories, node)
+		} else if parent, ok := nodes[*c.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	itemsByCollection := make(map[int32][]TemplateItem)
+	for _, ci := range catalog.CollectionItems {
+		if item, ok := templates[ci.TemplateID]; ok {
+			itemsByCollection[ci.CollectionID] = append(itemsByCollection[ci.CollectionID], item)
+		}
+	}
+	for _, c := range catalog.Collections {
+		items := itemsByCollection[c.ID]
+		if len(items) == 0 {
+			continue
+		}
+		tree.Collections = append(tree.Collections, CollectionWithTemplates{
+			ID:          c.ID,
+			Slug:        c.Slug,
+			Title:       c.Title,
+			Description: c.Description,
+			Templates:   items,
+		})
+	}
+
+	return tree, nil
+}
+
+func (s *templateService) GetTemplateDetail(ctx context.Context, userID int32, templateID int32, locale LocaleRequest) (*TemplateDetailResult, error) {
+	return s.getTemplateDetail(ctx, userID, s.q.Template.ID.Eq(templateID), locale)
+}
+
+func (s *templateService) GetTemplateDetailBySlug(ctx context.Context, userID int32, slug string, locale LocaleRequest) (*TemplateDetailResult, error) {
+	result, err := s.getTemplateDetail(ctx, userID, s.q.Template.Slug.Eq(slug), locale)
+	if err == ErrTemplateNotFound {
+		return nil, s.redirectedSlug(ctx, slug)
+	}
+	return result, err
+}
+
+func (s *templateService) GetTemplatePreview(ctx context.Context, slug string, locale LocaleRequest) (*TemplatePreview, error) {
+	template, detail, category, err := s.findVisibleTemplate(ctx, s.q.Template.Slug.Eq(slug))
+	if err == ErrTemplateNotFound {
+		return nil, s.redirectedSlug(ctx, slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resolved := locale.Resolve("")
+	template, detail, category, err = localizeTemplateContent(ctx, s.q, resolved, template, detail, category)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := templateTagItems(ctx, s.q, template.ID, resolved)
+	if err != nil {
+		return nil, err
+	}
+	result := buildTemplateDetailResult(template, detail, category, tags)
+	return &TemplatePreview{
+		Slug:         result.Slug,
+		Locale:       resolved,
+		Title:        result.Title,
+		Description:  result.Description,
+		CategorySlug: result.CategorySlug,
+		CategoryName: result.CategoryName,
+		Tags:         result.Tags,
+		TagDetails:   result.TagDetails,
+		IsPro:        result.IsPro,
+		Teaser:       teaserText(previewTeaserSource(template, detail), teaserMaxLength),
+		UpdatedAt:    latestTime(template.UpdatedAt, detail.UpdatedAt),
+	}, nil
+}
+
+func (s *templateService) RenderTemplate(ctx context.Context, userID int32, templateID int32, input RenderInput, locale LocaleRequest) (*RenderedReplies, error) {
+	format := input.Format
+	if format == "" {
+		format = RenderFormatText
+	}
+	switch format {
+	case RenderFormatText, RenderFormatHTML, RenderFormatMarkdown:
+	default:
+		return nil, ValidationError{Field: "format", Message: "must be text, html or markdown"}
+	}
+
+	detail, err := s.GetTemplateDetail(ctx, userID, templateID, locale)
+	if err != nil {
+		return nil, err
+	}
+	values, err := resolveVariableValues(detail.Variables, input.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenderedReplies{
+		TemplateID:   detail.ID,
+		Locale:       detail.Locale,
+		Format:       format,
+		ReplySoft:    renderReply(detail.ReplySoft, values, format),
+		ReplyNeutral: renderReply(detail.ReplyNeutral, values, format),
+		ReplyFirm:    renderReply(detail.ReplyFirm, values, format),
+		Values:       values,
+	}, nil
+}
+
+func (s *templateService) getTemplateDetail(ctx context.Context, userID int32, where gen.Condition, locale LocaleRequest) (*TemplateDetailResult, error) {
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, err
+	}
+
+	template, detail, category, err := s.findVisibleTemplate(ctx, where)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.IsPro != 0 && !hasPro(user) {
 		return nil, ErrProRequired
 	}
 
+	resolved := locale.Resolve(user.Locale)
+	template, detail, category, err = localizeTemplateContent(ctx, s.q, resolved, template, detail, category)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := templateTagItems(ctx, s.q, template.ID, resolved)
+	if err != nil {
+		return nil, err
+	}
+	result := buildTemplateDetailResult(template, detail, category, tags)
+	result.Locale = resolved
+	return result, nil
+}
+
+// findVisibleTemplate loads a live template with its detail and category.
+// A template is only reachable while its category and every ancestor of it
+// are live as well.
+func (s *templateService) findVisibleTemplate(ctx context.Context, where gen.Condition) (*model.Template, *model.TemplateDetail, *model.Category, error) {
+	now := time.Now().UTC()
+	template, err := s.q.Template.WithContext(ctx).
+		Where(where).
+		Where(s.q.Template.IsActive.Eq(1)).
+		Where(templateScheduleVisible(s.q, now)...).
+		First()
+	if err != nil {
+		return nil, nil, nil, ErrTemplateNotFound
+	}
+
 	detail, err := s.q.TemplateDetail.WithContext(ctx).
-		Where(s.q.TemplateDetail.TemplateID.Eq(templateID)).
+		Where(s.q.TemplateDetail.TemplateID.Eq(template.ID)).
 		First()
 	if err != nil {
-		return nil, ErrTemplateNotFound
+		return nil, nil, nil, ErrTemplateNotFound
+	}
+
+	category, err := findVisibleCategory(ctx, s.q, template.CategoryID, now)
+	if err != nil {
+		return nil, nil, nil, ErrTemplateNotFound
 	}
 
-	category, err := s.q.Category.WithContext(ctx).
-		Where(s.q.Category.ID.Eq(template.CategoryID)).
+	return template, detail, category, nil
+}
+
+// redirectedSlug reports where an old slug now lives, or ErrTemplateNotFound
+// when it never existed or its template is not visible.
+func (s *templateService) redirectedSlug(ctx context.Context, slug string) error {
+	redirect, err := s.q.TemplateSlugRedirect.WithContext(ctx).
+		Where(s.q.TemplateSlugRedirect.OldSlug.Eq(slug)).
 		First()
 	if err != nil {
-		return nil, err
+		return ErrTemplateNotFound
+	}
+	template, _, _, err := s.findVisibleTemplate(ctx, s.q.Template.ID.Eq(redirect.TemplateID))
+	if err != nil {
+		return err
 	}
+	return &SlugMovedError{Slug: template.Slug}
+}
 
+func toTemplateItem(t *model.Template, tags []TemplateTagItem, user *model.User, activity templateActivity) TemplateItem {
+	isPro := t.IsPro != 0
+	item := TemplateItem{
+		ID:          t.ID,
+		Slug:        t.Slug,
+		Title:       t.Title,
+		Description: t.Description,
+		Tags:        tagNames(tags),
+		TagDetails:  tags,
+		IsPro:       isPro,
+		IsLocked:    isPro && !hasPro(user),
+		IsFavorite:  activity.favorites[t.ID],
+	}
+	if lastUsed, ok := activity.lastUsed[t.ID]; ok {
+		item.LastUsedAt = &lastUsed
+	}
+	return item
+}
+
+func buildTemplateDetailResult(template *model.Template, detail *model.TemplateDetail, category *model.Category, tags []TemplateTagItem) *TemplateDetailResult {
 	title := template.Title
 	if detail.Headline != "" {
 		title = detail.Headline
 	}
 
-	result := &TemplateDetailResult{
+	return &TemplateDetailResult{
 		ID:            template.ID,
+		Slug:          template.Slug,
 		CategoryID:    template.CategoryID,
+		CategorySlug:  category.Slug,
 		CategoryName:  category.Name,
 		Title:         title,
 		Description:   detail.Summary,
-		Tags:          splitTags(template.TagsText),
-		IsPro:         isPro,
+		Tags:          tagNames(tags),
+		TagDetails:    tags,
+		IsPro:         template.IsPro != 0,
 		ReplySoft:     detail.ReplySoft,
 		ReplyNeutral:  detail.ReplyNeutral,
 		ReplyFirm:     detail.ReplyFirm,
 		WhenNotToUse:  detail.WhenNotToUse,
 		BestPractices: splitLines(detail.BestPractices),
+		Variables:     parseVariables(detail.Variables),
 	}
+}
 
-	return result, nil
+// previewTeaserSource picks the text the public preview is cut from. Pro
+// replies are paid content, so their teaser comes from the summary (or the
+// headline when the summary is empty) instead of the neutral reply.
+func previewTeaserSource(template *model.Template, detail *model.TemplateDetail) string {
+	if template.IsPro == 0 {
+		return detail.ReplyNeutral
+	}
+	if strings.TrimSpace(detail.Summary) != "" {
+		return detail.Summary
+	}
+	return detail.Headline
+}
+
+// teaserText cuts value to at most max runes, preferring a word boundary.
+func teaserText(value string, max int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	cut := string(runes[:max])
+	if i := strings.LastIndex(cut, " "); i > len(cut)/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
+
+func latestTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
 }
 
 func splitTags(value string) []string {
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+func TestPreviewTeaserSource(t *testing.T) {
+	detail := &model.TemplateDetail{
+		Headline:     "Decline kindly",
+		Summary:      "A polite way to say no",
+		ReplyNeutral: "Thanks for the invite, but I can't make it.",
+	}
+	tests := []struct {
+		name    string
+		isPro   int32
+		summary string
+		want    string
+	}{
+		{"free template shows the reply", 0, detail.Summary, detail.ReplyNeutral},
+		{"pro template shows the summary", 1, detail.Summary, detail.Summary},
+		{"pro template without summary shows the headline", 1, " ", detail.Headline},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := *detail
+			d.Summary = tt.summary
+			got := previewTeaserSource(&model.Template{IsPro: tt.isPro}, &d)
+			if got != tt.want {
+				t.Errorf("previewTeaserSource() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHiddenParentHidesSubcategories(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	content := NewContentService(NewAuditService())
+
+	parent, err := content.CreateCategory(ctx, CategoryInput{Name: "Work", IsActive: true})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	child, err := content.CreateCategory(ctx, CategoryInput{Name: "Meetings", ParentID: &parent.ID, IsActive: true})
+	if err != nil {
+		t.Fatalf("create subcategory: %v", err)
+	}
+	created, err := content.CreateTemplate(ctx, TemplateInput{
+		CategoryID:  child.ID,
+		Title:       "Decline a meeting",
+		Description: "Say no politely",
+		IsActive:    true,
+		Detail: TemplateDetailInput{
+			Headline:     "Decline",
+			Summary:      "A polite no",
+			ReplySoft:    "soft",
+			ReplyNeutral: "neutral",
+			ReplyFirm:    "firm",
+		},
+	})
+	if err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+	_, err = content.UpdateCategory(ctx, parent.ID, CategoryInput{Name: "Work", IsActive: false, UpdatedAt: parent.UpdatedAt})
+	if err != nil {
+		t.Fatalf("hide parent: %v", err)
+	}
+
+	now := time.Now().UTC()
+	user := &model.User{Email: "a@example.com", EmailNorm: "a@example.com", Role: RoleUser, Status: UserStatusActive, CreatedAt: now, UpdatedAt: now}
+	if err := query.Q.User.WithContext(ctx).Create(user); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewTemplateService()
+	categories, err := svc.ListTemplatesByCategory(ctx, user.ID, nil, LocaleRequest{})
+	if err != nil {
+		t.Fatalf("list templates: %v", err)
+	}
+	if len(categories) != 0 {
+		t.Errorf("categories = %+v, want the subcategory hidden with its parent", categories)
+	}
+	if _, err := svc.GetTemplateDetail(ctx, user.ID, created.Template.ID, LocaleRequest{}); err != ErrTemplateNotFound {
+		t.Errorf("detail err = %v, want ErrTemplateNotFound", err)
+	}
+	if _, err := svc.GetTemplatePreview(ctx, created.Template.Slug, LocaleRequest{}); err != ErrTemplateNotFound {
+		t.Errorf("preview err = %v, want ErrTemplateNotFound", err)
+	}
+
+	collection, err := content.CreateCollection(ctx, CollectionInput{Title: "Picks", IsActive: false})
+	if err != nil {
+		t.Fatalf("create collection: %v", err)
+	}
+	if collection.Collection.IsActive != 0 {
+		t.Errorf("new inactive collection stored as active")
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"encoding/json"
+	"html"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	VariableText   = "text"
+	VariableNumber = "number"
+	VariableDate   = "date"
+	VariableEmail  = "email"
+)
+
+const (
+	RenderFormatText     = "text"
+	RenderFormatHTML     = "html"
+	RenderFormatMarkdown = "markdown"
+)
+
+const (
+	templateMaxVariables = 20
+	variableMaxValue     = 500
+)
+
+// placeholderPattern matches {{name}}, allowing spaces inside the braces.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+var variableNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// markdownEscaper backslash-escapes every character Markdown could treat as syntax.
+var markdownEscaper = func() *strings.Replacer {
+	var pairs []string
+	for _, r := range "\\`*_{}[]()#+-.!|<>~" {
+		pairs = append(pairs, string(r), `\`+string(r))
+	}
+	return strings.NewReplacer(pairs...)
+}()
+
+// TemplateVariable declares a {{name}} placeholder used in a template's replies.
+type TemplateVariable struct {
+	Name  string `json:"name"`
+	Label string `json:"label,omitempty"`
+	// Type is one of text, number, date (YYYY-MM-DD) or email; empty means text.
+	Type     string `json:"type"`
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required"`
+}
+
+// RenderInput fills a template's variables. Format is text, html or markdown.
+type RenderInput struct {
+	Values map[string]string `json:"values"`
+	Format string            `json:"format"`
+}
+
+// RenderedReplies are a template's replies with every placeholder filled in.
+type RenderedReplies struct {
+	TemplateID   int32  `json:"template_id"`
+	Locale       string `json:"locale"`
+	Format       string `json:"format"`
+	ReplySoft    string `json:"reply_soft"`
+	ReplyNeutral string `json:"reply_neutral"`
+	ReplyFirm    string `json:"reply_firm"`
+	// Values are the values actually used, defaults included.
+	Values map[string]string `json:"values"`
+}
+
+func parseVariables(value string) []TemplateVariable {
+	variables := []TemplateVariable{}
+	if value != "" {
+		// Rows were validated on write; a broken value renders without variables
+		_ = json.Unmarshal([]byte(value), &variables)
+	}
+	return variables
+}
+
+func encodeVariables(variables []TemplateVariable) string {
+	if len(variables) == 0 {
+		return "[]"
+	}
+	b, _ := json.Marshal(variables)
+	return string(b)
+}
+
+func normalizeVariables(variables []TemplateVariable) []TemplateVariable {
+	result := make([]TemplateVariable, 0, len(variables))
+	for _, v := range variables {
+		v.Name = strings.TrimSpace(v.Name)
+		v.Label = strings.TrimSpace(v.Label)
+		v.Type = strings.ToLower(strings.TrimSpace(v.Type))
+		if v.Type == "" {
+			v.Type = VariableText
+		}
+		v.Default = strings.TrimSpace(v.Default)
+		result = append(result, v)
+	}
+	return result
+}
+
+// validateTemplateVariables checks the declarations and that the replies only
+// use declared placeholders.
+func validateTemplateVariables(detail TemplateDetailInput) error {
+	variables := normalizeVariables(detail.Variables)
+	if len(variables) > templateMaxVariables {
+		return ValidationError{Field: "detail.variables", Message: "has too many variables"}
+	}
+
+	declared := make(map[string]bool, len(variables))
+	for _, v := range variables {
+		field := "detail.variables." + v.Name
+		if !variableNamePattern.MatchString(v.Name) {
+			return ValidationError{Field: "detail.variables", Message: "names must be lower case letters, digits and _, starting with a letter"}
+		}
+		if declared[v.Name] {
+			return ValidationError{Field: field, Message: "is declared twice"}
+		}
+		declared[v.Name] = true
+		if err := limitText(field+".label", v.Label, 64); err != nil {
+			return err
+		}
+		switch v.Type {
+		case VariableText, VariableNumber, VariableDate, VariableEmail:
+		default:
+			return ValidationError{Field: field + ".type", Message: "must be text, number, date or email"}
+		}
+		if v.Default != "" {
+			if problem := variableValueProblem(v, v.Default); problem != "" {
+				return ValidationError{Field: field + ".default", Message: problem}
+			}
+		}
+	}
+
+	replies := []struct{ field, text string }{
+		{"detail.reply_soft", detail.ReplySoft},
+		{"detail.reply_neutral", detail.ReplyNeutral},
+		{"detail.reply_firm", detail.ReplyFirm},
+	}
+	for _, reply := range replies {
+		if err := checkPlaceholders(reply.field, reply.text, declared); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPlaceholders reports the first placeholder in text that is not declared.
+func checkPlaceholders(field, text string, declared map[string]bool) error {
+	for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		if !declared[m[1]] {
+			return ValidationError{Field: field, Message: "uses undeclared variable " + m[1]}
+		}
+	}
+	return nil
+}
+
+// variableValueProblem describes why value does not fit v, or returns "".
+func variableValueProblem(v TemplateVariable, value string) string {
+	if utf8.RuneCountInString(value) > variableMaxValue {
+		return "is too long"
+	}
+	switch v.Type {
+	case VariableNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "must be a number"
+		}
+	case VariableDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return "must be a date like 2006-01-02"
+		}
+	case VariableEmail:
+		if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+			return "must be an email address"
+		}
+	}
+	return ""
+}
+
+// resolveVariableValues checks input values against the declarations and fills
+// in defaults. Unknown names are rejected so typos do not pass silently.
+func resolveVariableValues(variables []TemplateVariable, values map[string]string) (map[string]string, error) {
+	declared := make(map[string]TemplateVariable, len(variables))
+	for _, v := range variables {
+		declared[v.Name] = v
+	}
+	for name := range values {
+		if _, ok := declared[name]; !ok {
+			return nil, ValidationError{Field: "values." + name, Message: "is not a variable of this template"}
+		}
+	}
+
+	resolved := make(map[string]string, len(variables))
+	for _, v := range variables {
+		value := strings.TrimSpace(values[v.Name])
+		if value == "" {
+			value = v.Default
+		}
+		if value == "" {
+			if v.Required {
+				return nil, ValidationError{Field: "values." + v.Name, Message: "is required"}
+			}
+			continue
+		}
+		if problem := variableValueProblem(v, value); problem != "" {
+			return nil, ValidationError{Field: "values." + v.Name, Message: problem}
+		}
+		resolved[v.Name] = value
+	}
+	return resolved, nil
+}
+
+// renderReply fills the placeholders in text for the given output format.
+// Reply text is plain text: HTML output escapes it and turns line breaks into
+// <br>, Markdown output keeps it as written. Values always come from users,
+// so they are escaped for the format. Placeholders without a value stay as
+// written so the user can see what is left to fill in.
+func renderReply(text string, values map[string]string, format string) string {
+	escapeText, escapeValue := func(s string) string { return s }, func(s string) string { return s }
+	switch format {
+	case RenderFormatHTML:
+		escapeText = func(s string) string {
+			return strings.ReplaceAll(html.EscapeString(s), "\n", "<br>\n")
+		}
+		escapeValue = escapeText
+	case RenderFormatMarkdown:
+		escapeValue = markdownEscaper.Replace
+	}
+
+	var b strings.Builder
+	pos := 0
+	for _, m := range placeholderPattern.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(escapeText(text[pos:m[0]]))
+		if value, ok := values[text[m[2]:m[3]]]; ok {
+			b.WriteString(escapeValue(value))
+		} else {
+			b.WriteString(escapeText(text[m[0]:m[1]]))
+		}
+		pos = m[1]
+	}
+	b.WriteString(escapeText(text[pos:]))
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderReply(t *testing.T) {
+	values := map[string]string{
+		"name":  "<b>Sam</b>",
+		"topic": "*budget* {{name}}",
+	}
+	tests := []struct {
+		name   string
+		text   string
+		format string
+		want   string
+	}{
+		{"text keeps values as is", "Hi {{name}}, about {{ topic }}.", RenderFormatText,
+			"Hi <b>Sam</b>, about *budget* {{name}}."},
+		{"text leaves unknown placeholders", "Hi {{missing}}", RenderFormatText,
+			"Hi {{missing}}"},
+		{"html escapes text and values", "Hi {{name}} & co\nThanks", RenderFormatHTML,
+			"Hi &lt;b&gt;Sam&lt;/b&gt; &amp; co<br>\nThanks"},
+		{"html does not expand placeholders inside values", "Re: {{topic}}", RenderFormatHTML,
+			"Re: *budget* {{name}}"},
+		{"markdown escapes values only", "**Hi** {{name}}", RenderFormatMarkdown,
+			`**Hi** \<b\>Sam\</b\>`},
+		{"markdown escapes braces in values", "Re: {{topic}}", RenderFormatMarkdown,
+			`Re: \*budget\* \{\{name\}\}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderReply(tt.text, values, tt.format); got != tt.want {
+				t.Errorf("renderReply(%q, %s) = %q, want %q", tt.text, tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVariableValueProblem(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     TemplateVariable
+		value string
+		want  string
+	}{
+		{"text accepts anything", TemplateVariable{Type: VariableText}, "<{{x}}>", ""},
+		{"untyped is text", TemplateVariable{}, "*", ""},
+		{"text too long", TemplateVariable{Type: VariableText}, strings.Repeat("a", variableMaxValue+1), "is too long"},
+		{"long in bytes but not runes", TemplateVariable{}, strings.Repeat("界", variableMaxValue), ""},
+		{"number", TemplateVariable{Type: VariableNumber}, "12.5", ""},
+		{"not a number", TemplateVariable{Type: VariableNumber}, "12 apples", "must be a number"},
+		{"date", TemplateVariable{Type: VariableDate}, "2026-03-01", ""},
+		{"date with time", TemplateVariable{Type: VariableDate}, "2026-03-01T10:00:00Z", "must be a date like 2006-01-02"},
+		{"email", TemplateVariable{Type: VariableEmail}, "sam@example.com", ""},
+		{"email with display name", TemplateVariable{Type: VariableEmail}, "Sam <sam@example.com>", "must be an email address"},
+		{"not an email", TemplateVariable{Type: VariableEmail}, "sam", "must be an email address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := variableValueProblem(tt.v, tt.value); got != tt.want {
+				t.Errorf("variableValueProblem() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"api/biz/say_right/dal/query"
+	"api/infra/redis"
+
+	goredis "github.com/redis/go-redis/v9"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupTestDB points query.Q at a fresh in-memory SQLite database built from
+// scripts/init_sqlite.sql. Redis points at a closed port, so cache reads miss
+// and writes fail quietly, as they do when Redis is down.
+func setupTestDB(t *testing.T) {
+	t.Helper()
+	schema, err := os.ReadFile("../../../scripts/init_sqlite.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db, err := gorm.Open(sqlite.Open("file:"+name+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec(string(schema)).Error; err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	query.SetDefault(db)
+	redis.Client = goredis.NewClient(&goredis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+}
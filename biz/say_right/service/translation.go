@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+)
+
+// Translations are partial: an empty field falls back to the next locale in
+// the chain and finally to the source text, so editors can translate a
+// template one field at a time.
+
+func templateTranslationFields(t *model.TemplateTranslation) []*string {
+	return []*string{
+		&t.Title, &t.Description, &t.Headline, &t.Summary,
+		&t.ReplySoft, &t.ReplyNeutral, &t.ReplyFirm,
+		&t.WhenNotToUse, &t.BestPractices,
+	}
+}
+
+func categoryTranslationFields(t *model.CategoryTranslation) []*string {
+	return []*string{&t.Name, &t.Description}
+}
+
+// mergeTemplateTranslations folds rows into one translation, taking each field
+// from the first locale in chain that fills it.
+func mergeTemplateTranslations(chain []string, rows []*model.TemplateTranslation) *model.TemplateTranslation {
+	merged := &model.TemplateTranslation{}
+	for _, locale := range chain {
+		for _, row := range rows {
+			if row.Locale == locale {
+				fillEmpty(templateTranslationFields(merged), templateTranslationFields(row))
+			}
+		}
+	}
+	return merged
+}
+
+func mergeCategoryTranslations(chain []string, rows []*model.CategoryTranslation) *model.CategoryTranslation {
+	merged := &model.CategoryTranslation{}
+	for _, locale := range chain {
+		for _, row := range rows {
+			if row.Locale == locale {
+				fillEmpty(categoryTranslationFields(merged), categoryTranslationFields(row))
+			}
+		}
+	}
+	return merged
+}
+
+func fillEmpty(dst, src []*string) {
+	for i := range dst {
+		if *dst[i] == "" {
+			*dst[i] = *src[i]
+		}
+	}
+}
+
+func overlay(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
+
+// localizeTemplate returns a copy of t showing the translated text.
+func localizeTemplate(t *model.Template, tr *model.TemplateTranslation) *model.Template {
+	localized := *t
+	if tr != nil {
+		overlay(&localized.Title, tr.Title)
+		overlay(&localized.Description, tr.Description)
+	}
+	return &localized
+}
+
+func localizeTemplateDetail(d *model.TemplateDetail, tr *model.TemplateTranslation) *model.TemplateDetail {
+	localized := *d
+	if tr != nil {
+		overlay(&localized.Headline, tr.Headline)
+		overlay(&localized.Summary, tr.Summary)
+		overlay(&localized.ReplySoft, tr.ReplySoft)
+		overlay(&localized.ReplyNeutral, tr.ReplyNeutral)
+		overlay(&localized.ReplyFirm, tr.ReplyFirm)
+		overlay(&localized.WhenNotToUse, tr.WhenNotToUse)
+		overlay(&localized.BestPractices, tr.BestPractices)
+	}
+	return &localized
+}
+
+func localizeCategory(c *model.Category, tr *model.CategoryTranslation) *model.Category {
+	localized := *c
+	if tr != nil {
+		overlay(&localized.Name, tr.Name)
+		overlay(&localized.Description, tr.Description)
+	}
+	return &localized
+}
+
+// localized returns a copy of the snapshot whose categories and templates
+// show their text in locale. Detail fields are not part of the snapshot.
+func (c *catalogSnapshot) localized(locale string) *catalogSnapshot {
+	chain := translationChain(locale)
+	if len(chain) == 0 {
+		return c
+	}
+
+	categoryRows := make(map[int32][]*model.CategoryTranslation)
+	for _, t := range c.CategoryTranslations {
+		categoryRows[t.CategoryID] = append(categoryRows[t.CategoryID], t)
+	}
+	templateRows := make(map[int32][]*model.TemplateTranslation)
+	for _, t := range c.TemplateTranslations {
+		templateRows[t.TemplateID] = append(templateRows[t.TemplateID], t)
+	}
+
+	result := *c
+	result.Categories = make([]*model.Category, len(c.Categories))
+	for i, category := range c.Categories {
+		result.Categories[i] = localizeCategory(category, mergeCategoryTranslations(chain, categoryRows[category.ID]))
+	}
+	result.Templates = make([]*model.Template, len(c.Templates))
+	for i, template := range c.Templates {
+		result.Templates[i] = localizeTemplate(template, mergeTemplateTranslations(chain, templateRows[template.ID]))
+	}
+	return &result
+}
+
+// localizeTemplateContent translates a template loaded outside the catalog
+// snapshot, together with its detail and category.
+func localizeTemplateContent(ctx context.Context, q *query.Query, locale string, template *model.Template, detail *model.TemplateDetail, category *model.Category) (*model.Template, *model.TemplateDetail, *model.Category, error) {
+	chain := translationChain(locale)
+	if len(chain) == 0 {
+		return template, detail, category, nil
+	}
+
+	templateRows, err := q.TemplateTranslation.WithContext(ctx).
+		Where(q.TemplateTranslation.TemplateID.Eq(template.ID), q.TemplateTranslation.Locale.In(chain...)).
+		Find()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	categoryRows, err := q.CategoryTranslation.WithContext(ctx).
+		Where(q.CategoryTranslation.CategoryID.Eq(category.ID), q.CategoryTranslation.Locale.In(chain...)).
+		Find()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tr := mergeTemplateTranslations(chain, templateRows)
+	return localizeTemplate(template, tr),
+		localizeTemplateDetail(detail, tr),
+		localizeCategory(category, mergeCategoryTranslations(chain, categoryRows)),
+		nil
+}
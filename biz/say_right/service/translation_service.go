@@ -0,0 +1,461 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+
+	"gorm.io/gorm/clause"
+)
+
+var ErrTranslationNotFound = errors.New("translation not found")
+
+// templateTranslationFieldNames names the fields of templateTranslationFields, in order.
+var templateTranslationFieldNames = []string{
+	"title", "description", "headline", "summary",
+	"reply_soft", "reply_neutral", "reply_firm",
+	"when_not_to_use", "best_practices",
+}
+
+var categoryTranslationFieldNames = []string{"name", "description"}
+
+// CategoryTranslationInput replaces a category's text in one locale. Empty
+// fields fall back to the source text.
+type CategoryTranslationInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// UpdatedAt must echo the stored translation's value when replacing one; ignored when creating.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TemplateTranslationInput replaces a template's text in one locale, shaped
+// like TemplateInput. Empty fields fall back to the source text. Variables are
+// shared by every locale, so Detail.Variables is ignored; the translated
+// replies may only use the template's declared placeholders.
+type TemplateTranslationInput struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Detail      TemplateDetailInput `json:"detail"`
+	// UpdatedAt must echo the stored translation's value when replacing one; ignored when creating.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MissingTranslation is a category or template whose translation lacks
+// fields that have source text. Fallback locales are not counted.
+type MissingTranslation struct {
+	ID      int32    `json:"id"`
+	Slug    string   `json:"slug"`
+	Name    string   `json:"name"`
+	Missing []string `json:"missing"`
+	// Outdated is set when the source text changed after the translation was saved.
+	Outdated bool `json:"outdated"`
+}
+
+type TranslationReport struct {
+	Locale     string               `json:"locale"`
+	Categories []MissingTranslation `json:"categories"`
+	Templates  []MissingTranslation `json:"templates"`
+	// Complete counts categories and templates translated in every field and up to date.
+	Complete int `json:"complete"`
+	Total    int `json:"total"`
+}
+
+type TranslationService interface {
+	ListCategoryTranslations(ctx context.Context, categoryID int32) ([]*model.CategoryTranslation, error)
+	SaveCategoryTranslation(ctx context.Context, categoryID int32, locale string, input CategoryTranslationInput) (*model.CategoryTranslation, error)
+	DeleteCategoryTranslation(ctx context.Context, categoryID int32, locale string) error
+
+	ListTemplateTranslations(ctx context.Context, templateID int32) ([]*model.TemplateTranslation, error)
+	SaveTemplateTranslation(ctx context.Context, templateID int32, locale string, input TemplateTranslationInput) (*model.TemplateTranslation, error)
+	DeleteTemplateTranslation(ctx context.Context, templateID int32, locale string) error
+
+	// MissingTranslations reports, for locale, every category and template
+	// that is untranslated, partly translated or outdated.
+	MissingTranslations(ctx context.Context, locale string) (*TranslationReport, error)
+}
+
+type translationService struct {
+	*contentService
+}
+
+func NewTranslationService(audit AuditService) TranslationService {
+	return &translationService{
+		contentService: &contentService{
+			q:     query.Q,
+			audit: audit,
+		},
+	}
+}
+
+func (s *translationService) ListCategoryTranslations(ctx context.Context, categoryID int32) ([]*model.CategoryTranslation, error) {
+	if _, err := s.q.Category.WithContext(ctx).Where(s.q.Category.ID.Eq(categoryID)).First(); err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	return s.q.CategoryTranslation.WithContext(ctx).
+		Where(s.q.CategoryTranslation.CategoryID.Eq(categoryID)).
+		Order(s.q.CategoryTranslation.Locale).
+		Find()
+}
+
+func (s *translationService) SaveCategoryTranslation(ctx context.Context, categoryID int32, locale string, input CategoryTranslationInput) (*model.CategoryTranslation, error) {
+	locale, err := translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	next := &model.CategoryTranslation{
+		CategoryID:  categoryID,
+		Locale:      locale,
+		Name:        strings.TrimSpace(input.Name),
+		Description: strings.TrimSpace(input.Description),
+	}
+	checks := []error{
+		limitText("name", next.Name, 64),
+		limitText("description", next.Description, 255),
+		requireTranslatedField(categoryTranslationFields(next)),
+	}
+	for _, err := range checks {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.write(ctx, func(tx *query.Query) error {
+		if _, err := tx.Category.WithContext(ctx).Where(tx.Category.ID.Eq(categoryID)).First(); err != nil {
+			return ErrCategoryNotFound
+		}
+		current, err := tx.CategoryTranslation.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(tx.CategoryTranslation.CategoryID.Eq(categoryID), tx.CategoryTranslation.Locale.Eq(locale)).
+			First()
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+
+		next.UpdatedAt = editTimestamp()
+		if current == nil {
+			next.CreatedAt = next.UpdatedAt
+			err = tx.CategoryTranslation.WithContext(ctx).Create(next)
+		} else {
+			if !sameEditTimestamp(current.UpdatedAt, input.UpdatedAt) {
+				return ErrConflict
+			}
+			next.ID = current.ID
+			next.CreatedAt = current.CreatedAt
+			err = tx.CategoryTranslation.WithContext(ctx).Save(next)
+		}
+		if err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.category.translate",
+			TargetType: "category",
+			TargetID:   strconv.Itoa(int(categoryID)),
+			Before:     current,
+			After:      next,
+			Detail:     map[string]interface{}{"locale": locale},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+func (s *translationService) DeleteCategoryTranslation(ctx context.Context, categoryID int32, locale string) error {
+	locale, err := translationLocale(locale)
+	if err != nil {
+		return err
+	}
+	return s.write(ctx, func(tx *query.Query) error {
+		current, err := tx.CategoryTranslation.WithContext(ctx).
+			Where(tx.CategoryTranslation.CategoryID.Eq(categoryID), tx.CategoryTranslation.Locale.Eq(locale)).
+			First()
+		if err != nil {
+			return ErrTranslationNotFound
+		}
+		if _, err := tx.CategoryTranslation.WithContext(ctx).Where(tx.CategoryTranslation.ID.Eq(current.ID)).Delete(); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.category.translation.delete",
+			TargetType: "category",
+			TargetID:   strconv.Itoa(int(categoryID)),
+			Before:     current,
+			Detail:     map[string]interface{}{"locale": locale},
+		})
+	})
+}
+
+func (s *translationService) ListTemplateTranslations(ctx context.Context, templateID int32) ([]*model.TemplateTranslation, error) {
+	if _, err := s.q.Template.WithContext(ctx).Where(s.q.Template.ID.Eq(templateID)).First(); err != nil {
+		return nil, ErrTemplateNotFound
+	}
+	return s.q.TemplateTranslation.WithContext(ctx).
+		Where(s.q.TemplateTranslation.TemplateID.Eq(templateID)).
+		Order(s.q.TemplateTranslation.Locale).
+		Find()
+}
+
+func (s *translationService) SaveTemplateTranslation(ctx context.Context, templateID int32, locale string, input TemplateTranslationInput) (*model.TemplateTranslation, error) {
+	locale, err := translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	next := &model.TemplateTranslation{
+		TemplateID:    templateID,
+		Locale:        locale,
+		Title:         strings.TrimSpace(input.Title),
+		Description:   strings.TrimSpace(input.Description),
+		Headline:      strings.TrimSpace(input.Detail.Headline),
+		Summary:       strings.TrimSpace(input.Detail.Summary),
+		ReplySoft:     strings.TrimSpace(input.Detail.ReplySoft),
+		ReplyNeutral:  strings.TrimSpace(input.Detail.ReplyNeutral),
+		ReplyFirm:     strings.TrimSpace(input.Detail.ReplyFirm),
+		WhenNotToUse:  strings.TrimSpace(input.Detail.WhenNotToUse),
+		BestPractices: strings.Join(splitLines(strings.Join(input.Detail.BestPractices, "\n")), "\n"),
+	}
+	checks := []error{
+		limitText("title", next.Title, 128),
+		limitText("description", next.Description, 512),
+		limitText("detail.headline", next.Headline, 128),
+		limitText("detail.summary", next.Summary, 512),
+		requireTranslatedField(templateTranslationFields(next)),
+	}
+	for _, err := range checks {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.write(ctx, func(tx *query.Query) error {
+		if _, err := tx.Template.WithContext(ctx).Where(tx.Template.ID.Eq(templateID)).First(); err != nil {
+			return ErrTemplateNotFound
+		}
+		if err := checkTranslatedPlaceholders(ctx, tx, next); err != nil {
+			return err
+		}
+		current, err := tx.TemplateTranslation.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(tx.TemplateTranslation.TemplateID.Eq(templateID), tx.TemplateTranslation.Locale.Eq(locale)).
+			First()
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+
+		next.UpdatedAt = editTimestamp()
+		if current == nil {
+			next.CreatedAt = next.UpdatedAt
+			err = tx.TemplateTranslation.WithContext(ctx).Create(next)
+		} else {
+			if !sameEditTimestamp(current.UpdatedAt, input.UpdatedAt) {
+				return ErrConflict
+			}
+			next.ID = current.ID
+			next.CreatedAt = current.CreatedAt
+			err = tx.TemplateTranslation.WithContext(ctx).Save(next)
+		}
+		if err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.template.translate",
+			TargetType: "template",
+			TargetID:   strconv.Itoa(int(templateID)),
+			Before:     current,
+			After:      next,
+			Detail:     map[string]interface{}{"locale": locale},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+func (s *translationService) DeleteTemplateTranslation(ctx context.Context, templateID int32, locale string) error {
+	locale, err := translationLocale(locale)
+	if err != nil {
+		return err
+	}
+	return s.write(ctx, func(tx *query.Query) error {
+		current, err := tx.TemplateTranslation.WithContext(ctx).
+			Where(tx.TemplateTranslation.TemplateID.Eq(templateID), tx.TemplateTranslation.Locale.Eq(locale)).
+			First()
+		if err != nil {
+			return ErrTranslationNotFound
+		}
+		if _, err := tx.TemplateTranslation.WithContext(ctx).Where(tx.TemplateTranslation.ID.Eq(current.ID)).Delete(); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "content.template.translation.delete",
+			TargetType: "template",
+			TargetID:   strconv.Itoa(int(templateID)),
+			Before:     current,
+			Detail:     map[string]interface{}{"locale": locale},
+		})
+	})
+}
+
+func (s *translationService) MissingTranslations(ctx context.Context, locale string) (*TranslationReport, error) {
+	locale, err := translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.q.Category.WithContext(ctx).Order(s.q.Category.SortOrder, s.q.Category.ID).Find()
+	if err != nil {
+		return nil, err
+	}
+	categoryRows, err := s.q.CategoryTranslation.WithContext(ctx).Where(s.q.CategoryTranslation.Locale.Eq(locale)).Find()
+	if err != nil {
+		return nil, err
+	}
+	templates, err := s.q.Template.WithContext(ctx).
+		Order(s.q.Template.CategoryID, s.q.Template.SortOrder, s.q.Template.ID).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.q.TemplateDetail.WithContext(ctx).Find()
+	if err != nil {
+		return nil, err
+	}
+	templateRows, err := s.q.TemplateTranslation.WithContext(ctx).Where(s.q.TemplateTranslation.Locale.Eq(locale)).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TranslationReport{
+		Locale:     locale,
+		Categories: []MissingTranslation{},
+		Templates:  []MissingTranslation{},
+		Total:      len(categories) + len(templates),
+	}
+
+	categoryTranslations := make(map[int32]*model.CategoryTranslation, len(categoryRows))
+	for _, row := range categoryRows {
+		categoryTranslations[row.CategoryID] = row
+	}
+	for _, c := range categories {
+		source := &model.CategoryTranslation{Name: c.Name, Description: c.Description}
+		tr, ok := categoryTranslations[c.ID]
+		if !ok {
+			tr = &model.CategoryTranslation{}
+		}
+		item := MissingTranslation{
+			ID:       c.ID,
+			Slug:     c.Slug,
+			Name:     c.Name,
+			Missing:  missingFields(categoryTranslationFieldNames, categoryTranslationFields(source), categoryTranslationFields(tr)),
+			Outdated: ok && tr.UpdatedAt.Before(c.UpdatedAt),
+		}
+		if len(item.Missing) == 0 && !item.Outdated {
+			report.Complete++
+			continue
+		}
+		report.Categories = append(report.Categories, item)
+	}
+
+	detailsByTemplate := make(map[int32]*model.TemplateDetail, len(details))
+	for _, d := range details {
+		detailsByTemplate[d.TemplateID] = d
+	}
+	templateTranslations := make(map[int32]*model.TemplateTranslation, len(templateRows))
+	for _, row := range templateRows {
+		templateTranslations[row.TemplateID] = row
+	}
+	for _, t := range templates {
+		detail, ok := detailsByTemplate[t.ID]
+		if !ok {
+			detail = &model.TemplateDetail{}
+		}
+		source := sourceTemplateText(t, detail)
+		tr, ok := templateTranslations[t.ID]
+		if !ok {
+			tr = &model.TemplateTranslation{}
+		}
+		item := MissingTranslation{
+			ID:       t.ID,
+			Slug:     t.Slug,
+			Name:     t.Title,
+			Missing:  missingFields(templateTranslationFieldNames, templateTranslationFields(source), templateTranslationFields(tr)),
+			Outdated: ok && tr.UpdatedAt.Before(latestTime(t.UpdatedAt, detail.UpdatedAt)),
+		}
+		if len(item.Missing) == 0 && !item.Outdated {
+			report.Complete++
+			continue
+		}
+		report.Templates = append(report.Templates, item)
+	}
+	return report, nil
+}
+
+// checkTranslatedPlaceholders makes sure translated replies only use the
+// variables the template declares.
+func checkTranslatedPlaceholders(ctx context.Context, tx *query.Query, tr *model.TemplateTranslation) error {
+	declared := make(map[string]bool)
+	detail, err := tx.TemplateDetail.WithContext(ctx).Where(tx.TemplateDetail.TemplateID.Eq(tr.TemplateID)).First()
+	if err == nil {
+		for _, v := range parseVariables(detail.Variables) {
+			declared[v.Name] = true
+		}
+	} else if !isNotFound(err) {
+		return err
+	}
+
+	replies := []struct{ field, text string }{
+		{"detail.reply_soft", tr.ReplySoft},
+		{"detail.reply_neutral", tr.ReplyNeutral},
+		{"detail.reply_firm", tr.ReplyFirm},
+	}
+	for _, reply := range replies {
+		if err := checkPlaceholders(reply.field, reply.text, declared); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sourceTemplateText lays out a template's source text like a translation so
+// the two can be compared field by field.
+func sourceTemplateText(t *model.Template, d *model.TemplateDetail) *model.TemplateTranslation {
+	return &model.TemplateTranslation{
+		Title:         t.Title,
+		Description:   t.Description,
+		Headline:      d.Headline,
+		Summary:       d.Summary,
+		ReplySoft:     d.ReplySoft,
+		ReplyNeutral:  d.ReplyNeutral,
+		ReplyFirm:     d.ReplyFirm,
+		WhenNotToUse:  d.WhenNotToUse,
+		BestPractices: d.BestPractices,
+	}
+}
+
+// missingFields names the fields that have source text but no translation.
+func missingFields(names []string, source, translated []*string) []string {
+	missing := []string{}
+	for i, name := range names {
+		if strings.TrimSpace(*source[i]) != "" && *translated[i] == "" {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+func requireTranslatedField(fields []*string) error {
+	for _, f := range fields {
+		if *f != "" {
+			return nil
+		}
+	}
+	return ValidationError{Field: "translation", Message: "is empty; delete it instead"}
+}
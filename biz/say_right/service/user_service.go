@@ -5,7 +5,9 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"log"
 	"math/big"
+	"strconv"
 	"time"
 
 	"api/biz/say_right/dal/model"
@@ -14,22 +16,38 @@ import (
 	"api/infra/redis"
 )
 
+const (
+	UserStatusActive    int32 = 1
+	UserStatusSuspended int32 = 2
+)
+
 type UserService interface {
 	CreateUser(ctx context.Context, user *model.User) error
 	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
-	FindOrCreateUser(ctx context.Context, email string) (*model.User, error)
+	// FindOrCreateUser attributes a new user to the owner of referralCode, if
+	// given; existing users are never re-attributed.
+	FindOrCreateUser(ctx context.Context, email string, referralCode string) (*model.User, error)
 	SendVerificationCode(ctx context.Context, email string) error
 	VerifyCode(ctx context.Context, email, code string) (bool, error)
 	UpgradeUserToPro(ctx context.Context, email string) error
+	RecordLogin(ctx context.Context, email string, user *model.User) error
+	// SetLocale saves the user's content language and returns its normalized
+	// form; an empty locale clears it so Accept-Language applies again.
+	SetLocale(ctx context.Context, userID int32, locale string) (string, error)
 }
 
 type userService struct {
-	q *query.Query
+	q         *query.Query
+	audit     AuditService
+	referrals ReferralService
 }
 
 func NewUserService() UserService {
+	audit := NewAuditService()
 	return &userService{
-		q: query.Q,
+		q:         query.Q,
+		audit:     audit,
+		referrals: NewReferralService(audit),
 	}
 }
 
@@ -37,14 +55,31 @@ func (s *userService) CreateUser(ctx context.Context, user *model.User) error {
 	user.EmailVerifiedAt = time.Now().UTC()
 	user.CreatedAt = time.Now().UTC()
 	user.UpdatedAt = time.Now().UTC()
-	return s.q.User.WithContext(ctx).Create(user)
+	return s.q.Transaction(func(tx *query.Query) error {
+		if user.ReferralCode == nil {
+			code, err := unusedReferralCode(ctx, tx)
+			if err != nil {
+				return err
+			}
+			user.ReferralCode = &code
+		}
+		if err := tx.User.WithContext(ctx).Create(user); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "user.create",
+			TargetType: "user",
+			TargetID:   strconv.Itoa(int(user.ID)),
+			After:      user,
+		})
+	})
 }
 
 func (s *userService) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	return s.q.User.WithContext(ctx).Where(s.q.User.EmailNorm.Eq(email)).First()
 }
 
-func (s *userService) FindOrCreateUser(ctx context.Context, email string) (*model.User, error) {
+func (s *userService) FindOrCreateUser(ctx context.Context, email string, referralCode string) (*model.User, error) {
 	user, err := s.GetUserByEmail(ctx, email)
 	if err == nil {
 		return user, nil
@@ -57,6 +92,10 @@ func (s *userService) FindOrCreateUser(ctx context.Context, email string) (*mode
 	if err := s.CreateUser(ctx, newUser); err != nil {
 		return nil, err
 	}
+	// A lost referral must not keep the user from signing in
+	if err := s.referrals.AttributeSignup(ctx, newUser, referralCode); err != nil {
+		log.Printf("Failed to attribute referral for user %d: %v", newUser.ID, err)
+	}
 	return newUser, nil
 }
 
@@ -134,12 +173,24 @@ func (s *userService) SendVerificationCode(ctx context.Context, email string) er
 		return err
 	}
 
-	// 4. Send Email
-	// Using empty subject to use default
-	if err := mail.SendEmailCode(email, code, "Say Right Verify Code"); err != nil {
+	// 4. Record and send in one transaction: the entry is only committed
+	// once the email went out, and the code is dropped if either step fails
+	err = s.q.Transaction(func(tx *query.Query) error {
+		err := s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "auth.send_code",
+			TargetType: "email",
+			TargetID:   email,
+		})
+		if err != nil {
+			return err
+		}
+		// Using empty subject to use default
+		return mail.SendEmailCode(email, code, "Say Right Verify Code")
+	})
+	if err != nil {
+		redis.Client.Del(ctx, KeyPrefixCode+code, verifyKey)
 		return err
 	}
-
 	return nil
 }
 
@@ -171,12 +222,57 @@ func (s *userService) UpgradeUserToPro(ctx context.Context, email string) error
 		return errors.New("user not found")
 	}
 
-	user.IsPro = 1
-	// Update specific columns using map since IsPro is not in generated query yet
-	_, err = s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(user.ID)).Updates(map[string]interface{}{
+	changes := map[string]interface{}{
 		"is_pro": 1,
+	}
+	return s.q.Transaction(func(tx *query.Query) error {
+		// Update specific columns using map since IsPro is not in generated query yet
+		if _, err := tx.User.WithContext(ctx).Where(tx.User.ID.Eq(user.ID)).Updates(changes); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, AuditEntry{
+			Action:     "billing.upgrade_pro",
+			TargetType: "user",
+			TargetID:   strconv.Itoa(int(user.ID)),
+			Before:     user,
+			After:      changes,
+		})
+	})
+}
+
+// RecordLogin writes a login attempt to the audit log; user is nil when the code was rejected.
+func (s *userService) RecordLogin(ctx context.Context, email string, user *model.User) error {
+	if user == nil {
+		return s.audit.Record(ctx, nil, AuditEntry{
+			Action:     "auth.login_failed",
+			TargetType: "email",
+			TargetID:   email,
+		})
+	}
+	// The session does not exist yet, so the actor is the user who just logged in
+	actor := ActorFromContext(ctx)
+	actor.ID = user.ID
+	actor.Type = ActorTypeUser
+	return s.audit.Record(WithActor(ctx, actor), nil, AuditEntry{
+		Action:     "auth.login",
+		TargetType: "user",
+		TargetID:   strconv.Itoa(int(user.ID)),
 	})
-	return err
+}
+
+func (s *userService) SetLocale(ctx context.Context, userID int32, locale string) (string, error) {
+	if locale != "" {
+		normalized, ok := NormalizeLocale(locale)
+		if !ok {
+			return "", ValidationError{Field: "locale", Message: "is not a supported locale"}
+		}
+		locale = normalized
+	}
+	_, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).Update(s.q.User.Locale, locale)
+	if err != nil {
+		return "", err
+	}
+	return locale, nil
 }
 
 func generateCode() (string, error) {
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFindOrCreateUserAssignsReferralCode(t *testing.T) {
+	setupTestDB(t)
+	ctx := context.Background()
+	svc := NewUserService()
+
+	first, err := svc.FindOrCreateUser(ctx, "a@example.com", "")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if first.ReferralCode == nil || len(*first.ReferralCode) != referralCodeLength {
+		t.Fatalf("referral code = %v, want a new %d character code", first.ReferralCode, referralCodeLength)
+	}
+
+	second, err := svc.FindOrCreateUser(ctx, "b@example.com", *first.ReferralCode)
+	if err != nil {
+		t.Fatalf("create referred user: %v", err)
+	}
+	if second.ReferralCode == nil || *second.ReferralCode == *first.ReferralCode {
+		t.Errorf("referred user code = %v, want its own code", second.ReferralCode)
+	}
+
+	again, err := svc.FindOrCreateUser(ctx, "a@example.com", "")
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if again.ReferralCode == nil || *again.ReferralCode != *first.ReferralCode {
+		t.Errorf("code changed on sign-in: %v, want %s", again.ReferralCode, *first.ReferralCode)
+	}
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"api/biz/say_right/dal/model"
+	"api/biz/say_right/dal/query"
+
+	"gorm.io/gorm/clause"
+)
+
+// MyTemplatesSlug is the slug of the pseudo category that holds the user's
+// own templates in ListTemplatesByCategory. It has ID 0, which no catalog
+// category uses.
+const MyTemplatesSlug = "my-templates"
+
+const (
+	defaultFreeUserTemplateLimit = 0
+	defaultProUserTemplateLimit  = 50
+)
+
+var myTemplatesNames = map[string]string{
+	SourceLocale: "My templates",
+	"zh-CN":      "我的模板",
+	"zh-TW":      "我的範本",
+}
+
+// UserTemplateLimitError means the user already has as many templates as
+// their plan allows.
+type UserTemplateLimitError struct {
+	Limit int
+}
+
+func (e UserTemplateLimitError) Error() string {
+	return fmt.Sprintf("Plan limit of %d templates reached", e.Limit)
+}
+
+// UserTemplateInput is a private template. Detail has the same fields as a
+// catalog template's detail except Prompt, which is ignored.
+type UserTemplateInput struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Tags        []string            `json:"tags"`
+	Detail      TemplateDetailInput `json:"detail"`
+}
+
+type UserTemplateResult struct {
+	ID            int32              `json:"id"`
+	Title         string             `json:"title"`
+	Description   string             `json:"description"`
+	Tags          []string           `json:"tags"`
+	Headline      string             `json:"headline"`
+	Summary       string             `json:"summary"`
+	ReplySoft     string             `json:"reply_soft"`
+	ReplyNeutral  string             `json:"reply_neutral"`
+	ReplyFirm     string             `json:"reply_firm"`
+	WhenNotToUse  string             `json:"when_not_to_use"`
+	BestPractices []string           `json:"best_practices"`
+	Variables     []TemplateVariable `json:"variables"`
+	// SourceTemplateID is the catalog template this one was forked from;
+	// UpstreamChanged reports that its content has changed since the fork
+	// or the last pull.
+	SourceTemplateID *int32    `json:"source_template_id"`
+	UpstreamChanged  bool      `json:"upstream_changed"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type UserTemplateItem struct {
+	TemplateItem
+	SourceTemplateID *int32 `json:"source_template_id"`
+	UpstreamChanged  bool   `json:"upstream_changed"`
+}
+
+type UserTemplateList struct {
+	Plan      string             `json:"plan"`
+	Limit     int                `json:"limit"`
+	Count     int                `json:"count"`
+	Templates []UserTemplateItem `json:"templates"`
+}
+
+// UserTemplateService manages templates users write for themselves. Only the
+// owner ever sees them; team libraries are in OrganizationService. Creating
+// and editing need a plan with a non-zero limit; reading and deleting stay
+// available after a downgrade.
+type UserTemplateService interface {
+	ListUserTemplates(ctx context.Context, userID int32) (*UserTemplateList, error)
+	GetUserTemplate(ctx context.Context, userID int32, templateID int32) (*UserTemplateResult, error)
+	CreateUserTemplate(ctx context.Context, userID int32, input UserTemplateInput) (*UserTemplateResult, error)
+	UpdateUserTemplate(ctx context.Context, userID int32, templateID int32, input UserTemplateInput) (*UserTemplateResult, error)
+	DeleteUserTemplate(ctx context.Context, userID int32, templateID int32) error
+
+	// ForkTemplate copies a catalog template, in the user's locale, into a
+	// new private template that remembers where it came from.
+	ForkTemplate(ctx context.Context, userID int32, templateID int32, locale LocaleRequest) (*UserTemplateResult, error)
+	// UpstreamDiff lists what changed in the source of a fork since it was
+	// forked or last pulled, marking fields the user has edited too.
+	UpstreamDiff(ctx context.Context, userID int32, templateID int32) (*UpstreamDiff, error)
+	// PullUpstream takes the source's changes into a fork.
+	PullUpstream(ctx context.Context, userID int32, templateID int32, input PullInput) (*UserTemplateResult, error)
+}
+
+type userTemplateService struct {
+	q         *query.Query
+	templates TemplateService
+	limits    map[string]int
+}
+
+// NewUserTemplateService reads the per-plan template counts from
+// USER_TEMPLATE_LIMIT_FREE and USER_TEMPLATE_LIMIT_PRO. Forks read their
+// source through templates, so they follow the same access rules.
+func NewUserTemplateService(templates TemplateService) UserTemplateService {
+	return &userTemplateService{
+		q:         query.Q,
+		templates: templates,
+		limits: map[string]int{
+			PlanFree: limitFromEnv("USER_TEMPLATE_LIMIT_FREE", defaultFreeUserTemplateLimit),
+			PlanPro:  limitFromEnv("USER_TEMPLATE_LIMIT_PRO", defaultProUserTemplateLimit),
+		},
+	}
+}
+
+func (s *userTemplateService) ListUserTemplates(ctx context.Context, userID int32) (*UserTemplateList, error) {
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, err
+	}
+	templates, err := findUserTemplates(ctx, s.q, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := userPlan(user)
+	result := &UserTemplateList{
+		Plan:      plan,
+		Limit:     s.limits[plan],
+		Count:     len(templates),
+		Templates: make([]UserTemplateItem, 0, len(templates)),
+	}
+	for _, t := range templates {
+		result.Templates = append(result.Templates, UserTemplateItem{
+			TemplateItem:     toUserTemplateItem(t),
+			SourceTemplateID: t.SourceTemplateID,
+			UpstreamChanged:  s.upstreamChanged(ctx, t),
+		})
+	}
+	return result, nil
+}
+
+func (s *userTemplateService) GetUserTemplate(ctx context.Context, userID int32, templateID int32) (*UserTemplateResult, error) {
+	template, err := s.find(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	result := toUserTemplateResult(template)
+	result.UpstreamChanged = s.upstreamChanged(ctx, template)
+	return result, nil
+}
+
+func (s *userTemplateService) CreateUserTemplate(ctx context.Context, userID int32, input UserTemplateInput) (*UserTemplateResult, error) {
+	if err := validateUserTemplate(input); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	created := &model.UserTemplate{OwnerID: userID, CreatedAt: now}
+	applyUserTemplateInput(created, input, now)
+	if err := s.create(ctx, created); err != nil {
+		return nil, err
+	}
+	return toUserTemplateResult(created), nil
+}
+
+// create inserts template unless its owner is at their plan's limit.
+func (s *userTemplateService) create(ctx context.Context, template *model.UserTemplate) error {
+	return s.q.Transaction(func(tx *query.Query) error {
+		// Locking the owner serializes concurrent creates so the count check holds
+		user, err := tx.User.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(tx.User.ID.Eq(template.OwnerID)).
+			First()
+		if err != nil {
+			return err
+		}
+		limit, err := s.limit(user)
+		if err != nil {
+			return err
+		}
+		count, err := tx.UserTemplate.WithContext(ctx).
+			Where(tx.UserTemplate.OwnerID.Eq(template.OwnerID), tx.UserTemplate.OrganizationID.IsNull()).
+			Count()
+		if err != nil {
+			return err
+		}
+		if count >= int64(limit) {
+			return UserTemplateLimitError{Limit: limit}
+		}
+		return tx.UserTemplate.WithContext(ctx).Create(template)
+	})
+}
+
+func (s *userTemplateService) UpdateUserTemplate(ctx context.Context, userID int32, templateID int32, input UserTemplateInput) (*UserTemplateResult, error) {
+	if err := validateUserTemplate(input); err != nil {
+		return nil, err
+	}
+	template, err := s.findEditable(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	applyUserTemplateInput(template, input, time.Now().UTC())
+	if err := s.q.UserTemplate.WithContext(ctx).Save(template); err != nil {
+		return nil, err
+	}
+	result := toUserTemplateResult(template)
+	result.UpstreamChanged = s.upstreamChanged(ctx, template)
+	return result, nil
+}
+
+func (s *userTemplateService) DeleteUserTemplate(ctx context.Context, userID int32, templateID int32) error {
+	u := s.q.UserTemplate
+	info, err := u.WithContext(ctx).Where(u.ID.Eq(templateID), u.OwnerID.Eq(userID), u.OrganizationID.IsNull()).Delete()
+	if err != nil {
+		return err
+	}
+	if info.RowsAffected == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+// limit is how many templates the user's plan allows; a plan without any
+// needs Pro.
+func (s *userTemplateService) limit(user *model.User) (int, error) {
+	limit := s.limits[userPlan(user)]
+	if limit == 0 {
+		return 0, ErrProRequired
+	}
+	return limit, nil
+}
+
+// findEditable is find for users whose plan lets them edit.
+func (s *userTemplateService) findEditable(ctx context.Context, userID, templateID int32) (*model.UserTemplate, error) {
+	user, err := s.q.User.WithContext(ctx).Where(s.q.User.ID.Eq(userID)).First()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.limit(user); err != nil {
+		return nil, err
+	}
+	return s.find(ctx, userID, templateID)
+}
+
+// find loads one of the user's templates. Other users' templates are reported
+// as missing so ids cannot be probed; team templates are read through the
+// organization.
+func (s *userTemplateService) find(ctx context.Context, userID, templateID int32) (*model.UserTemplate, error) {
+	u := s.q.UserTemplate
+	template, err := u.WithContext(ctx).Where(u.ID.Eq(templateID), u.OwnerID.Eq(userID), u.OrganizationID.IsNull()).First()
+	if isNotFound(err) {
+		return nil, ErrTemplateNotFound
+	}
+	return template, err
+}
+
+func findUserTemplates(ctx context.Context, q *query.Query, userID int32) ([]*model.UserTemplate, error) {
+	u := q.UserTemplate
+	return u.WithContext(ctx).Where(u.OwnerID.Eq(userID), u.OrganizationID.IsNull()).Order(u.UpdatedAt.Desc(), u.ID.Desc()).Find()
+}
+
+// myTemplatesCategory lists the user's own templates as a category, keeping
+// only those carrying every one of tagSlugs. It returns nil when none are left.
+func myTemplatesCategory(ctx context.Context, q *query.Query, userID int32, tagSlugs []string, locale string) (*CategoryWithTemplates, error) {
+	templates, err := findUserTemplates(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]TemplateItem, 0, len(templates))
+	for _, t := range templates {
+		item := toUserTemplateItem(t)
+		if hasTagSlugs(item.TagDetails, tagSlugs) {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	name, ok := myTemplatesNames[locale]
+	if !ok {
+		name = myTemplatesNames[SourceLocale]
+	}
+	return &CategoryWithTemplates{
+		Slug:      MyTemplatesSlug,
+		Name:      name,
+		Templates: items,
+	}, nil
+}
+
+func hasTagSlugs(tags []TemplateTagItem, tagSlugs []string) bool {
+	have := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		have[tag.Slug] = true
+	}
+	for _, slug := range tagSlugs {
+		if !have[slug] {
+			return false
+		}
+	}
+	return true
+}
+
+func validateUserTemplate(input UserTemplateInput) error {
+	checks := []error{
+		requireText("title", input.Title, 128),
+		limitText("description", input.Description, 512),
+		limitText("tags", joinTags(input.Tags), 512),
+		limitText("detail.headline", input.Detail.Headline, 128),
+		limitText("detail.summary", input.Detail.Summary, 512),
+		requireText("detail.reply_soft", input.Detail.ReplySoft, 0),
+		requireText("detail.reply_neutral", input.Detail.ReplyNeutral, 0),
+		requireText("detail.reply_firm", input.Detail.ReplyFirm, 0),
+		validateTemplateVariables(input.Detail),
+	}
+	for _, err := range checks {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyUserTemplateInput(t *model.UserTemplate, input UserTemplateInput, now time.Time) {
+	t.Title = strings.TrimSpace(input.Title)
+	t.Description = strings.TrimSpace(input.Description)
+	t.TagsText = joinTags(input.Tags)
+	t.Headline = strings.TrimSpace(input.Detail.Headline)
+	t.Summary = strings.TrimSpace(input.Detail.Summary)
+	t.ReplySoft = strings.TrimSpace(input.Detail.ReplySoft)
+	t.ReplyNeutral = strings.TrimSpace(input.Detail.ReplyNeutral)
+	t.ReplyFirm = strings.TrimSpace(input.Detail.ReplyFirm)
+	t.WhenNotToUse = strings.TrimSpace(input.Detail.WhenNotToUse)
+	t.BestPractices = strings.Join(splitLines(strings.Join(input.Detail.BestPractices, "\n")), "\n")
+	t.Variables = encodeVariables(normalizeVariables(input.Detail.Variables))
+	t.UpdatedAt = now
+}
+
+func toUserTemplateItem(t *model.UserTemplate) TemplateItem {
+	return TemplateItem{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Tags:        splitTags(t.TagsText),
+		TagDetails:  plainTagItems(splitTags(t.TagsText)),
+		IsOwned:     true,
+	}
+}
+
+func toUserTemplateResult(t *model.UserTemplate) *UserTemplateResult {
+	return &UserTemplateResult{
+		ID:               t.ID,
+		Title:            t.Title,
+		Description:      t.Description,
+		Tags:             splitTags(t.TagsText),
+		Headline:         t.Headline,
+		Summary:          t.Summary,
+		ReplySoft:        t.ReplySoft,
+		ReplyNeutral:     t.ReplyNeutral,
+		ReplyFirm:        t.ReplyFirm,
+		WhenNotToUse:     t.WhenNotToUse,
+		BestPractices:    splitLines(t.BestPractices),
+		Variables:        parseVariables(t.Variables),
+		SourceTemplateID: t.SourceTemplateID,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+}
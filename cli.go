@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"api/biz/say_right/service"
+)
+
+// runCommand handles maintenance subcommands run as `api <command> ...` instead
+// of starting the server. It returns the process exit code.
+func runCommand(args []string) int {
+	switch args[0] {
+	case "catalog":
+		return runCatalogCommand(args[1:])
+	case "tags":
+		return runTagsCommand(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+		fmt.Fprintln(os.Stderr, "usage: api catalog <export|import> [flags]")
+		fmt.Fprintln(os.Stderr, "       api tags backfill")
+		return 2
+	}
+}
+
+// runTagsCommand handles `api tags backfill`, which links existing templates
+// to tags parsed from their tags_text. It is safe to run more than once.
+func runTagsCommand(args []string) int {
+	if len(args) == 0 || args[0] != "backfill" {
+		fmt.Fprintln(os.Stderr, "usage: api tags backfill")
+		return 2
+	}
+
+	n, err := service.NewTagService(service.NewAuditService()).Backfill(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backfill failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("linked tags for %d templates\n", n)
+	return 0
+}
+
+func runCatalogCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: api catalog <export|import> [flags]")
+		return 2
+	}
+
+	catalogService := service.NewCatalogService(service.NewAuditService())
+	ctx := context.Background()
+
+	switch args[0] {
+	case "export":
+		fs := flag.NewFlagSet("catalog export", flag.ContinueOnError)
+		format := fs.String("format", service.CatalogFormatJSON, "json, yaml or csv")
+		out := fs.String("out", "", "output file (default stdout)")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+
+		doc, err := catalogService.Export(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+			return 1
+		}
+
+		var w io.Writer = os.Stdout
+		if *out != "" {
+			f, err := os.Create(*out)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+				return 1
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := service.EncodeCatalog(w, *format, doc); err != nil {
+			fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "import":
+		fs := flag.NewFlagSet("catalog import", flag.ContinueOnError)
+		format := fs.String("format", service.CatalogFormatJSON, "json, yaml or csv")
+		file := fs.String("file", "", "catalog file to import (required)")
+		dryRun := fs.Bool("dry-run", false, "report changes without writing them")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		if *file == "" {
+			fmt.Fprintln(os.Stderr, "-file is required")
+			return 2
+		}
+
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import failed: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+
+		doc, err := service.DecodeCatalog(f, *format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import failed: %v\n", err)
+			return 1
+		}
+		result, err := catalogService.Import(ctx, doc, *dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import failed: %v\n", err)
+			return 1
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(result)
+		if len(result.Errors) > 0 {
+			return 1
+		}
+		return 0
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown catalog command %q\n", args[0])
+		return 2
+	}
+}
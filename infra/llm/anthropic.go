@@ -0,0 +1,167 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const (
+	anthropicBaseURL = "https://api.anthropic.com/v1"
+	anthropicModel   = "claude-3-5-haiku-latest"
+	anthropicVersion = "2023-06-01"
+	// The messages API requires max_tokens
+	anthropicMaxTokens = 1024
+)
+
+// anthropic talks to any server implementing the Anthropic messages API.
+type anthropic struct {
+	http    *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+func NewAnthropic(httpClient *http.Client, baseURL, apiKey, model string) Provider {
+	if baseURL == "" {
+		baseURL = anthropicBaseURL
+	}
+	if model == "" {
+		model = anthropicModel
+	}
+	return &anthropic{http: httpClient, baseURL: baseURL, apiKey: apiKey, model: model}
+}
+
+func (p *anthropic) Name() string  { return ProviderAnthropic }
+func (p *anthropic) Model() string { return p.model }
+
+type anthropicRequest struct {
+	Model       string    `json:"model"`
+	System      string    `json:"system,omitempty"`
+	Messages    []Message `json:"messages"`
+	MaxTokens   int       `json:"max_tokens"`
+	Temperature float64   `json:"temperature"`
+	Stream      bool      `json:"stream,omitempty"`
+}
+
+// anthropicEvent covers the fields used from every streamed event type.
+type anthropicEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Model string `json:"model"`
+		Usage struct {
+			InputTokens int `json:"input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Usage struct {
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type anthropicResponse struct {
+	Model   string `json:"model"`
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+func (p *anthropic) request(req Request) anthropicRequest {
+	maxTokens := req.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = anthropicMaxTokens
+	}
+	return anthropicRequest{
+		Model:       p.model,
+		System:      req.System,
+		Messages:    req.Messages,
+		MaxTokens:   maxTokens,
+		Temperature: req.Temperature,
+	}
+}
+
+func (p *anthropic) headers() map[string]string {
+	return map[string]string{
+		"x-api-key":         p.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+}
+
+func (p *anthropic) Generate(ctx context.Context, req Request) (*Response, error) {
+	var out anthropicResponse
+	if err := postJSON(ctx, p.http, p.Name(), p.baseURL+"/messages", p.headers(), p.request(req), &out); err != nil {
+		return nil, err
+	}
+
+	var text strings.Builder
+	for _, block := range out.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	resp := &Response{
+		Text:         text.String(),
+		Model:        out.Model,
+		InputTokens:  out.Usage.InputTokens,
+		OutputTokens: out.Usage.OutputTokens,
+	}
+	if resp.Model == "" {
+		resp.Model = p.model
+	}
+	return resp, nil
+}
+
+func (p *anthropic) Stream(ctx context.Context, req Request, onDelta func(text string)) (*Response, error) {
+	body := p.request(req)
+	body.Stream = true
+	httpResp, err := post(ctx, p.http, p.Name(), p.baseURL+"/messages", p.headers(), body)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	resp := &Response{Model: p.model}
+	var text strings.Builder
+	err = readEvents(httpResp.Body, func(_, data string) error {
+		var event anthropicEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return err
+		}
+		switch event.Type {
+		case "message_start":
+			if event.Message.Model != "" {
+				resp.Model = event.Message.Model
+			}
+			resp.InputTokens = event.Message.Usage.InputTokens
+		case "content_block_delta":
+			if event.Delta.Type == "text_delta" && event.Delta.Text != "" {
+				text.WriteString(event.Delta.Text)
+				onDelta(event.Delta.Text)
+			}
+		case "message_delta":
+			resp.OutputTokens = event.Usage.OutputTokens
+		case "message_stop":
+			return errStopEvents
+		case "error":
+			// Errors after the 200 status line arrive as an event
+			return &Error{Provider: p.Name(), StatusCode: http.StatusOK, Message: event.Error.Type + ": " + event.Error.Message}
+		}
+		return nil
+	})
+	// A stream cut short still returns what arrived, for cost tracking
+	resp.Text = text.String()
+	return resp, err
+}
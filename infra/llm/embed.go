@@ -0,0 +1,169 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const (
+	openAIEmbeddingModel = "text-embedding-3-small"
+	stubEmbeddingModel   = "stub-embedding-1"
+	stubDimensions       = 256
+)
+
+// Embedder turns texts into vectors whose cosine similarity reflects how
+// close their meanings are. Vectors are only comparable within one model.
+type Embedder interface {
+	Name() string
+	Model() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Embeddings is the configured embedder, or nil when callers should fall
+// back to lexical ranking.
+var Embeddings Embedder
+
+// initEmbeddings reads EMBEDDING_PROVIDER (openai or stub). The key and base
+// URL default to the LLM_ ones so one OpenAI account can serve both.
+func initEmbeddings() {
+	name := strings.ToLower(strings.TrimSpace(os.Getenv("EMBEDDING_PROVIDER")))
+	if name == "" {
+		log.Println("EMBEDDING_PROVIDER is not set, suggestions use lexical ranking")
+		return
+	}
+
+	apiKey := firstEnv("EMBEDDING_API_KEY", "LLM_API_KEY")
+	baseURL := strings.TrimRight(firstEnv("EMBEDDING_BASE_URL", "LLM_BASE_URL"), "/")
+	model := os.Getenv("EMBEDDING_MODEL")
+
+	switch name {
+	case ProviderOpenAI:
+		if apiKey == "" {
+			log.Fatal("EMBEDDING_API_KEY environment variable is not set")
+		}
+		Embeddings = NewOpenAIEmbedder(&http.Client{Timeout: Timeout}, baseURL, apiKey, model)
+	case ProviderStub:
+		Embeddings = NewStubEmbedder()
+	default:
+		log.Fatal("Unknown EMBEDDING_PROVIDER: ", name)
+	}
+
+	log.Printf("Embedding provider %s (%s) configured", Embeddings.Name(), Embeddings.Model())
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// openAIEmbedder talks to any server implementing the OpenAI embeddings API.
+type openAIEmbedder struct {
+	http    *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+func NewOpenAIEmbedder(httpClient *http.Client, baseURL, apiKey, model string) Embedder {
+	if baseURL == "" {
+		baseURL = openAIBaseURL
+	}
+	if model == "" {
+		model = openAIEmbeddingModel
+	}
+	return &openAIEmbedder{http: httpClient, baseURL: baseURL, apiKey: apiKey, model: model}
+}
+
+func (e *openAIEmbedder) Name() string  { return ProviderOpenAI }
+func (e *openAIEmbedder) Model() string { return e.model }
+
+type openAIEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	var out openAIEmbeddingResponse
+	headers := map[string]string{"Authorization": "Bearer " + e.apiKey}
+	body := openAIEmbeddingRequest{Model: e.model, Input: texts}
+	if err := postJSON(ctx, e.http, e.Name(), e.baseURL+"/embeddings", headers, body, &out); err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range out.Data {
+		if d.Index >= 0 && d.Index < len(vectors) {
+			vectors[d.Index] = d.Embedding
+		}
+	}
+	for i, v := range vectors {
+		if v == nil {
+			return nil, fmt.Errorf("%s: no embedding for input %d", e.Name(), i)
+		}
+	}
+	return vectors, nil
+}
+
+// stubEmbedder hashes words into a fixed number of buckets. It only captures
+// shared vocabulary, which is enough for tests and local development.
+type stubEmbedder struct{}
+
+func NewStubEmbedder() Embedder {
+	return stubEmbedder{}
+}
+
+func (stubEmbedder) Name() string  { return ProviderStub }
+func (stubEmbedder) Model() string { return stubEmbeddingModel }
+
+func (stubEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		v := make([]float32, stubDimensions)
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			h := fnv.New32a()
+			h.Write([]byte(word))
+			v[h.Sum32()%stubDimensions]++
+		}
+		vectors[i] = normalize(v)
+	}
+	return vectors, nil
+}
+
+func normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return v
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
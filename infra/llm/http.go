@@ -0,0 +1,51 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody caps how much of an error response ends up in logs and usage rows.
+const maxErrorBody = 512
+
+// postJSON sends body to url and decodes a 2xx response into out. Other
+// statuses become *Error carrying the start of the response body.
+func postJSON(ctx context.Context, client *http.Client, provider, url string, headers map[string]string, body, out any) error {
+	resp, err := post(ctx, client, provider, url, headers, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// post sends body and returns the open response when its status is 2xx.
+func post(ctx context.Context, client *http.Client, provider, url string, headers map[string]string, body any) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return nil, &Error{Provider: provider, StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(message))}
+	}
+	return resp, nil
+}
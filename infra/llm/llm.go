@@ -0,0 +1,147 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	ProviderStub      = "stub"
+)
+
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// ErrNotConfigured is returned when LLM_PROVIDER is not set.
+var ErrNotConfigured = errors.New("llm provider is not configured")
+
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type Request struct {
+	System      string
+	Messages    []Message
+	MaxTokens   int
+	Temperature float64
+}
+
+type Response struct {
+	Text         string
+	Model        string
+	InputTokens  int
+	OutputTokens int
+}
+
+// Provider sends one completion request to a model. Implementations must
+// stop when ctx is done.
+type Provider interface {
+	Name() string
+	Model() string
+	Generate(ctx context.Context, req Request) (*Response, error)
+	// Stream is Generate, calling onDelta with each piece of text as the
+	// model produces it. The returned Response holds the full text.
+	Stream(ctx context.Context, req Request, onDelta func(text string)) (*Response, error)
+}
+
+// Error is a non-2xx answer from the provider's API.
+type Error struct {
+	Provider   string
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: status %d: %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// Client is the configured provider, or nil when generation is disabled.
+var Client Provider
+
+// Timeout bounds a single Generate call.
+var Timeout = defaultTimeout
+
+// Prices are in US dollars per million tokens and only feed cost tracking.
+var (
+	InputPrice  float64
+	OutputPrice float64
+)
+
+func Init() {
+	initGeneration()
+	initEmbeddings()
+}
+
+func initGeneration() {
+	name := strings.ToLower(strings.TrimSpace(os.Getenv("LLM_PROVIDER")))
+	if name == "" {
+		log.Println("LLM_PROVIDER is not set, AI generation is disabled")
+		return
+	}
+
+	if v := os.Getenv("LLM_TIMEOUT"); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed <= 0 {
+			log.Fatal("Failed to parse LLM_TIMEOUT:", v)
+		}
+		Timeout = parsed
+	}
+	InputPrice = priceFromEnv("LLM_INPUT_PRICE")
+	OutputPrice = priceFromEnv("LLM_OUTPUT_PRICE")
+
+	apiKey := os.Getenv("LLM_API_KEY")
+	baseURL := strings.TrimRight(os.Getenv("LLM_BASE_URL"), "/")
+	model := os.Getenv("LLM_MODEL")
+	// The context deadline does the real work; this only guards against a
+	// caller that forgets one.
+	httpClient := &http.Client{Timeout: Timeout + 5*time.Second}
+
+	switch name {
+	case ProviderOpenAI:
+		Client = NewOpenAI(httpClient, baseURL, apiKey, model)
+	case ProviderAnthropic:
+		Client = NewAnthropic(httpClient, baseURL, apiKey, model)
+	case ProviderStub:
+		Client = NewStub(model)
+	default:
+		log.Fatal("Unknown LLM_PROVIDER: ", name)
+	}
+	if name != ProviderStub && apiKey == "" {
+		log.Fatal("LLM_API_KEY environment variable is not set")
+	}
+
+	log.Printf("LLM provider %s (%s) configured", Client.Name(), Client.Model())
+}
+
+func priceFromEnv(name string) float64 {
+	v := os.Getenv(name)
+	if v == "" {
+		return 0
+	}
+	price, err := strconv.ParseFloat(v, 64)
+	if err != nil || price < 0 {
+		log.Fatal("Failed to parse ", name, ": ", v)
+	}
+	return price
+}
+
+// CostMicros prices a call in millionths of a US dollar.
+func CostMicros(inputTokens, outputTokens int) int64 {
+	// Prices are per million tokens, so the token count times the price is
+	// already in micro-dollars.
+	return int64(float64(inputTokens)*InputPrice + float64(outputTokens)*OutputPrice + 0.5)
+}
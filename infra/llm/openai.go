@@ -0,0 +1,148 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const (
+	openAIBaseURL = "https://api.openai.com/v1"
+	openAIModel   = "gpt-4o-mini"
+)
+
+// openAI talks to any server implementing the OpenAI chat completions API.
+type openAI struct {
+	http    *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+func NewOpenAI(httpClient *http.Client, baseURL, apiKey, model string) Provider {
+	if baseURL == "" {
+		baseURL = openAIBaseURL
+	}
+	if model == "" {
+		model = openAIModel
+	}
+	return &openAI{http: httpClient, baseURL: baseURL, apiKey: apiKey, model: model}
+}
+
+func (p *openAI) Name() string  { return ProviderOpenAI }
+func (p *openAI) Model() string { return p.model }
+
+type openAIRequest struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+	Temperature float64   `json:"temperature"`
+	Stream      bool      `json:"stream,omitempty"`
+	// StreamOptions asks for a last chunk carrying the token usage
+	StreamOptions *openAIStreamOptions `json:"stream_options,omitempty"`
+}
+
+type openAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type openAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+type openAIResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message Message `json:"message"`
+	} `json:"choices"`
+	Usage openAIUsage `json:"usage"`
+}
+
+type openAIChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta Message `json:"delta"`
+	} `json:"choices"`
+	Usage *openAIUsage `json:"usage"`
+}
+
+func (p *openAI) request(req Request) openAIRequest {
+	// The system prompt is just the first message in this API
+	messages := make([]Message, 0, len(req.Messages)+1)
+	if req.System != "" {
+		messages = append(messages, Message{Role: "system", Content: req.System})
+	}
+	messages = append(messages, req.Messages...)
+	return openAIRequest{
+		Model:       p.model,
+		Messages:    messages,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+	}
+}
+
+func (p *openAI) headers() map[string]string {
+	return map[string]string{"Authorization": "Bearer " + p.apiKey}
+}
+
+func (p *openAI) Generate(ctx context.Context, req Request) (*Response, error) {
+	var out openAIResponse
+	if err := postJSON(ctx, p.http, p.Name(), p.baseURL+"/chat/completions", p.headers(), p.request(req), &out); err != nil {
+		return nil, err
+	}
+
+	resp := &Response{
+		Model:        out.Model,
+		InputTokens:  out.Usage.PromptTokens,
+		OutputTokens: out.Usage.CompletionTokens,
+	}
+	if resp.Model == "" {
+		resp.Model = p.model
+	}
+	if len(out.Choices) > 0 {
+		resp.Text = out.Choices[0].Message.Content
+	}
+	return resp, nil
+}
+
+func (p *openAI) Stream(ctx context.Context, req Request, onDelta func(text string)) (*Response, error) {
+	body := p.request(req)
+	body.Stream = true
+	body.StreamOptions = &openAIStreamOptions{IncludeUsage: true}
+	httpResp, err := post(ctx, p.http, p.Name(), p.baseURL+"/chat/completions", p.headers(), body)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	resp := &Response{Model: p.model}
+	var text strings.Builder
+	err = readEvents(httpResp.Body, func(_, data string) error {
+		if data == "[DONE]" {
+			return errStopEvents
+		}
+		var chunk openAIChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return err
+		}
+		if chunk.Model != "" {
+			resp.Model = chunk.Model
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content != "" {
+				text.WriteString(choice.Delta.Content)
+				onDelta(choice.Delta.Content)
+			}
+		}
+		if chunk.Usage != nil {
+			resp.InputTokens = chunk.Usage.PromptTokens
+			resp.OutputTokens = chunk.Usage.CompletionTokens
+		}
+		return nil
+	})
+	// A stream cut short still returns what arrived, for cost tracking
+	resp.Text = text.String()
+	return resp, err
+}
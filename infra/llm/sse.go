@@ -0,0 +1,51 @@
+package llm
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// errStopEvents ends readEvents early without an error.
+var errStopEvents = errors.New("stop reading events")
+
+// maxEventLine bounds one line of a provider's event stream.
+const maxEventLine = 1 << 20
+
+// readEvents parses a text/event-stream body and calls fn for every event
+// with its name (empty when the stream sends none) and joined data lines.
+func readEvents(r io.Reader, fn func(event, data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventLine)
+
+	var event string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				if err := fn(event, strings.Join(data, "\n")); err != nil {
+					if err == errStopEvents {
+						return nil
+					}
+					return err
+				}
+			}
+			event, data = "", nil
+		case strings.HasPrefix(line, ":"):
+			// Comment, used by servers as a keep-alive
+		default:
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "event":
+				event = value
+			case "data":
+				data = append(data, value)
+			}
+		}
+	}
+	return scanner.Err()
+}
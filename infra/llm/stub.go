@@ -0,0 +1,77 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+const stubModel = "stub-1"
+
+// stub answers locally without a network call. The reply depends only on
+// the request, so tests and local development get stable output.
+type stub struct {
+	model string
+}
+
+func NewStub(model string) Provider {
+	if model == "" {
+		model = stubModel
+	}
+	return &stub{model: model}
+}
+
+func (p *stub) Name() string  { return ProviderStub }
+func (p *stub) Model() string { return p.model }
+
+func (p *stub) Generate(ctx context.Context, req Request) (*Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(req.System))
+	input := strings.Fields(req.System)
+	var last string
+	for _, m := range req.Messages {
+		h.Write([]byte(m.Role))
+		h.Write([]byte(m.Content))
+		input = append(input, strings.Fields(m.Content)...)
+		if m.Role == RoleUser {
+			last = m.Content
+		}
+	}
+
+	text := fmt.Sprintf("Stub reply %08x: %s", h.Sum32(), firstLine(last))
+	return &Response{
+		Text:         text,
+		Model:        p.model,
+		InputTokens:  len(input),
+		OutputTokens: len(strings.Fields(text)),
+	}, nil
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+// Stream sends the Generate reply one word at a time.
+func (p *stub) Stream(ctx context.Context, req Request, onDelta func(text string)) (*Response, error) {
+	resp, err := p.Generate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	words := strings.SplitAfter(resp.Text, " ")
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		onDelta(word)
+	}
+	return resp, nil
+}
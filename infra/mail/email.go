@@ -44,8 +44,11 @@ func SendEmailCode(toAddress, code string, subject string) error {
 		subject = "SayRight Verify Code"
 	}
 
-	textBody := fmt.Sprintf("Your Verify Code Is %s", code)
+	return SendEmail(toAddress, subject, fmt.Sprintf("Your Verify Code Is %s", code))
+}
 
+// SendEmail 发送纯文本邮件
+func SendEmail(toAddress, subject, textBody string) error {
 	singleSendMailRequest := &dm20151123.SingleSendMailRequest{
 		AccountName:    tea.String("no-reply@mail.simpleaiwork.com"),
 		AddressType:    tea.Int32(1),
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"log"
 	"net/http"
 	"os"
 	"strings"
@@ -11,6 +13,7 @@ import (
 	"api/biz/say_right/service"
 	"api/cert"
 	"api/database"
+	"api/infra/llm"
 	"api/infra/mail"
 	"api/infra/redis"
 	"api/middleware"
@@ -28,6 +31,7 @@ func main() {
 	godotenv.Load()
 	mail.CreateEmailClient()
 	redis.Init()
+	llm.Init()
 
 	// Initialize Database
 	database.Connect()
@@ -35,8 +39,24 @@ func main() {
 	// Initialize GORM Gen Query
 	query.SetDefault(database.DB)
 
+	// Run a maintenance subcommand instead of the server, e.g. `api catalog export`
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
+	}
+
+	// Link tags for templates saved before tags had their own table
+	if n, err := service.NewTagService(service.NewAuditService()).BackfillMissing(context.Background()); err != nil {
+		log.Printf("Tag backfill failed: %v", err)
+	} else if n > 0 {
+		log.Printf("Linked tags for %d templates", n)
+	}
+
+	// Drop the catalog cache whenever scheduled content goes live or expires
+	service.NewPublishScheduler().Start(context.Background())
+
 	// Initialize Gin engine
 	r := gin.Default()
+	r.Use(middleware.RequestID())
 
 	store := buildRedisStore()
 	r.Use(sessions.Sessions("mysession", store))
@@ -44,7 +64,8 @@ func main() {
 	// Configure CORS
 	config := cors.DefaultConfig()
 	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization"}
+	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization", middleware.RequestIDHeader}
+	config.ExposeHeaders = []string{middleware.RequestIDHeader}
 	config.AllowCredentials = true
 	config.AllowOriginFunc = func(origin string) bool {
 		// Allow localhost for development
@@ -80,7 +101,12 @@ func main() {
 // registerProductRoutes registers routes for all products
 func registerProductRoutes(r *gin.Engine) {
 	// Initialize Paddle handler for global webhooks
-	paddleHandler := handler.NewPaddleHandler(service.NewUserService())
+	paddleHandler := handler.NewPaddleHandler(
+		service.NewUserService(),
+		service.NewBillingService(),
+		service.NewOrganizationService(service.NewAuditService()),
+		service.NewReferralService(service.NewAuditService()),
+	)
 
 	// Global webhooks
 	r.POST("/webhooks/paddle", paddleHandler.HandleWebhook)
@@ -88,6 +114,9 @@ func registerProductRoutes(r *gin.Engine) {
 	// Register sayright routes
 	registerSayRightRoutes(r)
 
+	// Register internal admin routes
+	registerAdminRoutes(r)
+
 	// Future products can be added here
 	// registerCareerGameRoutes(r)
 	// registerOtherProductRoutes(r)
@@ -97,7 +126,21 @@ func registerProductRoutes(r *gin.Engine) {
 func registerSayRightRoutes(r *gin.Engine) {
 	// Initialize Service and Handler
 	userHandler := handler.NewUserHandler(service.NewUserService())
-	templateHandler := handler.NewTemplateHandler(service.NewTemplateService())
+	templateService := service.NewTemplateService()
+	activityService := service.NewActivityService()
+	templateHandler := handler.NewTemplateHandler(templateService, activityService)
+	activityHandler := handler.NewActivityHandler(activityService)
+	generationHandler := handler.NewGenerationHandler(service.NewGenerationService(templateService))
+	rewriteHandler := handler.NewRewriteHandler(service.NewRewriteService())
+	feedHandler := handler.NewFeedHandler(service.NewFeedService())
+	searchHandler := handler.NewSearchHandler(service.NewSearchService())
+	suggestHandler := handler.NewSuggestHandler(service.NewSuggestService())
+	tagHandler := handler.NewTagHandler(service.NewTagService(service.NewAuditService()))
+	userTemplateHandler := handler.NewUserTemplateHandler(service.NewUserTemplateService(templateService))
+	ratingHandler := handler.NewRatingHandler(service.NewRatingService(service.NewAuditService()))
+	shareHandler := handler.NewShareHandler(service.NewShareService(templateService))
+	organizationHandler := handler.NewOrganizationHandler(service.NewOrganizationService(service.NewAuditService()))
+	referralHandler := handler.NewReferralHandler(service.NewReferralService(service.NewAuditService()))
 
 	// Create product route group with prefix
 	sayRightGroup := r.Group("/sayright")
@@ -114,12 +157,140 @@ func registerSayRightRoutes(r *gin.Engine) {
 		protected.Use(middleware.AuthMiddleware())
 		{
 			protected.GET("/users", userHandler.GetUser)
+			protected.PUT("/users/locale", userHandler.SetLocale)
 			protected.GET("/templates", templateHandler.ListTemplates)
 			protected.GET("/templates/:id", templateHandler.GetTemplateDetail)
+			protected.POST("/templates/:id/render", templateHandler.RenderTemplate)
+			protected.POST("/templates/:id/fork", userTemplateHandler.ForkTemplate)
+			protected.PUT("/templates/:id/favorite", activityHandler.AddFavorite)
+			protected.DELETE("/templates/:id/favorite", activityHandler.RemoveFavorite)
+			protected.POST("/templates/:id/copied", activityHandler.RecordCopy)
+			protected.GET("/templates/favorites", activityHandler.ListFavorites)
+			protected.GET("/templates/recent", activityHandler.ListRecent)
+			protected.POST("/templates/:id/ratings", ratingHandler.RateTemplate)
+			protected.GET("/templates/:id/ratings", ratingHandler.ListMyRatings)
+			protected.POST("/templates/:id/share", shareHandler.CreateShare)
+			protected.POST("/templates/:id/generate", generationHandler.Generate)
+			protected.POST("/templates/:id/generate/stream", generationHandler.StreamGenerate)
+			protected.GET("/generation/quota", generationHandler.GetQuota)
+			protected.POST("/rewrite", rewriteHandler.Rewrite)
+			protected.GET("/rewrite/quota", rewriteHandler.GetQuota)
+			protected.GET("/templates/search", searchHandler.SearchTemplates)
+			protected.POST("/templates/suggest", suggestHandler.SuggestTemplates)
+			protected.GET("/tags", tagHandler.ListTags)
+			protected.GET("/catalog/tree", templateHandler.GetCatalogTree)
+			protected.GET("/templates/by-slug/:slug", templateHandler.GetTemplateBySlug)
+			protected.GET("/my/templates", userTemplateHandler.ListUserTemplates)
+			protected.POST("/my/templates", userTemplateHandler.CreateUserTemplate)
+			protected.GET("/my/templates/:id", userTemplateHandler.GetUserTemplate)
+			protected.PUT("/my/templates/:id", userTemplateHandler.UpdateUserTemplate)
+			protected.DELETE("/my/templates/:id", userTemplateHandler.DeleteUserTemplate)
+			protected.GET("/my/templates/:id/upstream", userTemplateHandler.UpstreamDiff)
+			protected.POST("/my/templates/:id/pull", userTemplateHandler.PullUpstream)
+			protected.GET("/my/shares", shareHandler.ListShares)
+			protected.DELETE("/my/shares/:id", shareHandler.RevokeShare)
+			protected.GET("/my/referrals", referralHandler.GetDashboard)
+			protected.GET("/orgs", organizationHandler.ListOrganizations)
+			protected.POST("/orgs", organizationHandler.CreateOrganization)
+			protected.GET("/orgs/:id", organizationHandler.GetOrganization)
+			protected.PUT("/orgs/:id", organizationHandler.UpdateOrganization)
+			protected.POST("/orgs/:id/invitations", organizationHandler.InviteMember)
+			protected.DELETE("/orgs/:id/invitations/:invitation_id", organizationHandler.RevokeInvitation)
+			protected.POST("/orgs/:id/checkout", organizationHandler.StartCheckout)
+			protected.PUT("/orgs/:id/members/:user_id/role", organizationHandler.SetMemberRole)
+			protected.DELETE("/orgs/:id/members/:user_id", organizationHandler.RemoveMember)
+			protected.GET("/orgs/:id/templates", organizationHandler.ListTeamTemplates)
+			protected.POST("/orgs/:id/templates", organizationHandler.CreateTeamTemplate)
+			protected.GET("/orgs/:id/templates/:template_id", organizationHandler.GetTeamTemplate)
+			protected.PUT("/orgs/:id/templates/:template_id", organizationHandler.UpdateTeamTemplate)
+			protected.DELETE("/orgs/:id/templates/:template_id", organizationHandler.DeleteTeamTemplate)
+			protected.POST("/invitations/:token/accept", organizationHandler.AcceptInvitation)
 		}
 
 		// Public route
 		sayRightGroup.POST("/users", userHandler.Register)
+		sayRightGroup.GET("/templates/preview/:slug", templateHandler.GetTemplatePreview)
+		sayRightGroup.GET("/shares/:token", shareHandler.ViewShare)
+		sayRightGroup.GET("/sitemap.xml", feedHandler.Sitemap)
+		sayRightGroup.GET("/feed.json", feedHandler.Feed)
+	}
+}
+
+// registerAdminRoutes registers routes for internal staff tools
+func registerAdminRoutes(r *gin.Engine) {
+	userService := service.NewUserService()
+	templateService := service.NewTemplateService()
+	auditService := service.NewAuditService()
+	adminService := service.NewAdminService(userService, service.NewBillingService(), auditService)
+	adminHandler := handler.NewAdminHandler(adminService, templateService, auditService)
+	contentHandler := handler.NewContentHandler(service.NewContentService(auditService))
+	catalogHandler := handler.NewCatalogHandler(service.NewCatalogService(auditService))
+	tagHandler := handler.NewTagHandler(service.NewTagService(auditService))
+	translationHandler := handler.NewTranslationHandler(service.NewTranslationService(auditService))
+	generationHandler := handler.NewGenerationHandler(service.NewGenerationService(templateService))
+	ratingHandler := handler.NewRatingHandler(service.NewRatingService(auditService))
+
+	// Every admin route must declare the permission it needs
+	adminGroup := r.Group("/admin")
+	adminGroup.Use(middleware.AuthMiddleware())
+	{
+		adminGroup.GET("/roles", middleware.RequirePermission(service.PermRolesManage), adminHandler.ListRoles)
+
+		adminGroup.GET("/users", middleware.RequirePermission(service.PermUsersRead), adminHandler.SearchUsers)
+		adminGroup.GET("/users/:id", middleware.RequirePermission(service.PermUsersRead), adminHandler.GetUser)
+		adminGroup.GET("/users/:id/identities", middleware.RequirePermission(service.PermUsersRead), adminHandler.ListIdentities)
+		adminGroup.GET("/users/:id/billing", middleware.RequirePermission(service.PermBillingRead), adminHandler.ListBillingEvents)
+		adminGroup.GET("/generation/costs", middleware.RequirePermission(service.PermBillingRead), generationHandler.CostReport)
+		adminGroup.POST("/users/:id/grant-pro", middleware.RequirePermission(service.PermUsersWrite), adminHandler.GrantPro)
+		adminGroup.POST("/users/:id/revoke-pro", middleware.RequirePermission(service.PermUsersWrite), adminHandler.RevokePro)
+		adminGroup.POST("/users/:id/suspend", middleware.RequirePermission(service.PermUsersWrite), adminHandler.SuspendUser)
+		adminGroup.POST("/users/:id/unsuspend", middleware.RequirePermission(service.PermUsersWrite), adminHandler.UnsuspendUser)
+		adminGroup.POST("/users/:id/resend-code", middleware.RequirePermission(service.PermUsersWrite), adminHandler.ResendCode)
+		adminGroup.POST("/users/:id/role", middleware.RequirePermission(service.PermRolesManage), adminHandler.SetRole)
+
+		adminGroup.GET("/audit-logs", middleware.RequirePermission(service.PermAuditRead), adminHandler.ListAuditLogs)
+		adminGroup.GET("/audit-logs/export", middleware.RequirePermission(service.PermAuditRead), adminHandler.ExportAuditLogs)
+
+		// Read-only impersonation
+		adminGroup.GET("/users/:id/impersonate/templates", middleware.RequirePermission(service.PermUsersImpersonate), adminHandler.ImpersonateTemplates)
+		adminGroup.GET("/users/:id/impersonate/templates/:template_id", middleware.RequirePermission(service.PermUsersImpersonate), adminHandler.ImpersonateTemplateDetail)
+
+		// Content management
+		adminGroup.GET("/categories", middleware.RequirePermission(service.PermContentRead), contentHandler.ListCategories)
+		adminGroup.POST("/categories", middleware.RequirePermission(service.PermContentWrite), contentHandler.CreateCategory)
+		adminGroup.PUT("/categories/:id", middleware.RequirePermission(service.PermContentWrite), contentHandler.UpdateCategory)
+		adminGroup.POST("/categories/reorder", middleware.RequirePermission(service.PermContentWrite), contentHandler.ReorderCategories)
+		adminGroup.GET("/collections", middleware.RequirePermission(service.PermContentRead), contentHandler.ListCollections)
+		adminGroup.POST("/collections", middleware.RequirePermission(service.PermContentWrite), contentHandler.CreateCollection)
+		adminGroup.PUT("/collections/:id", middleware.RequirePermission(service.PermContentWrite), contentHandler.UpdateCollection)
+		adminGroup.GET("/templates", middleware.RequirePermission(service.PermContentRead), contentHandler.ListTemplates)
+		adminGroup.GET("/templates/:id", middleware.RequirePermission(service.PermContentRead), contentHandler.GetTemplate)
+		adminGroup.POST("/templates", middleware.RequirePermission(service.PermContentWrite), contentHandler.CreateTemplate)
+		adminGroup.PUT("/templates/:id", middleware.RequirePermission(service.PermContentWrite), contentHandler.UpdateTemplate)
+		adminGroup.PATCH("/templates/:id/flags", middleware.RequirePermission(service.PermContentWrite), contentHandler.SetTemplateFlags)
+		adminGroup.POST("/templates/reorder", middleware.RequirePermission(service.PermContentWrite), contentHandler.ReorderTemplates)
+		adminGroup.GET("/templates/:id/revisions", middleware.RequirePermission(service.PermContentRead), contentHandler.ListRevisions)
+		adminGroup.GET("/templates/:id/revisions/diff", middleware.RequirePermission(service.PermContentRead), contentHandler.DiffRevisions)
+		adminGroup.POST("/templates/:id/revisions/:revision_id/restore", middleware.RequirePermission(service.PermContentWrite), contentHandler.RestoreRevision)
+		adminGroup.PUT("/templates/:id/draft", middleware.RequirePermission(service.PermContentWrite), contentHandler.SaveDraft)
+		adminGroup.GET("/templates/:id/draft/preview", middleware.RequirePermission(service.PermContentRead), contentHandler.PreviewDraft)
+		adminGroup.POST("/templates/:id/publish", middleware.RequirePermission(service.PermContentWrite), contentHandler.PublishDraft)
+		adminGroup.GET("/templates/:id/ratings", middleware.RequirePermission(service.PermContentRead), ratingHandler.GetTemplateRatings)
+		adminGroup.GET("/ratings/scores", middleware.RequirePermission(service.PermContentRead), ratingHandler.ListScores)
+		adminGroup.GET("/rating-comments", middleware.RequirePermission(service.PermContentRead), ratingHandler.ListComments)
+		adminGroup.POST("/rating-comments/:id/moderate", middleware.RequirePermission(service.PermContentWrite), ratingHandler.ModerateComment)
+		adminGroup.GET("/categories/:id/translations", middleware.RequirePermission(service.PermContentRead), translationHandler.ListCategoryTranslations)
+		adminGroup.PUT("/categories/:id/translations/:locale", middleware.RequirePermission(service.PermContentWrite), translationHandler.SaveCategoryTranslation)
+		adminGroup.DELETE("/categories/:id/translations/:locale", middleware.RequirePermission(service.PermContentWrite), translationHandler.DeleteCategoryTranslation)
+		adminGroup.GET("/templates/:id/translations", middleware.RequirePermission(service.PermContentRead), translationHandler.ListTemplateTranslations)
+		adminGroup.PUT("/templates/:id/translations/:locale", middleware.RequirePermission(service.PermContentWrite), translationHandler.SaveTemplateTranslation)
+		adminGroup.DELETE("/templates/:id/translations/:locale", middleware.RequirePermission(service.PermContentWrite), translationHandler.DeleteTemplateTranslation)
+		adminGroup.GET("/translations/locales", middleware.RequirePermission(service.PermContentRead), translationHandler.ListLocales)
+		adminGroup.GET("/translations/missing", middleware.RequirePermission(service.PermContentRead), translationHandler.MissingTranslations)
+		adminGroup.GET("/tags", middleware.RequirePermission(service.PermContentRead), tagHandler.ListAllTags)
+		adminGroup.PUT("/tags/:id", middleware.RequirePermission(service.PermContentWrite), tagHandler.UpdateTag)
+		adminGroup.GET("/catalog/export", middleware.RequirePermission(service.PermContentRead), catalogHandler.Export)
+		adminGroup.POST("/catalog/import", middleware.RequirePermission(service.PermContentWrite), catalogHandler.Import)
 	}
 }
 
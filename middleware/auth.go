@@ -1,18 +1,24 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 
+	"api/biz/say_right/dal/query"
+	"api/biz/say_right/service"
+
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+// AuthMiddleware only lets through requests with a session for an existing,
+// non-suspended user. The status is read on every request so a suspension
+// ends sessions that are already open.
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		session := sessions.Default(c)
-		userID := session.Get("user_id")
-
-		if userID == nil {
+		userID, ok := SessionUserID(c)
+		if !ok {
 			// If not logged in, return 401
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error":   "Unauthorized",
@@ -22,6 +28,30 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		user, err := query.User.WithContext(c.Request.Context()).Where(query.User.ID.Eq(userID)).First()
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		if user == nil || user.Status == service.UserStatusSuspended {
+			// Drop the session so the client has to log in again
+			session := sessions.Default(c)
+			session.Clear()
+			_ = session.Save()
+
+			if user == nil {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":   "Unauthorized",
+					"message": "Please login first",
+				})
+			} else {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Account suspended"})
+			}
+			c.Abort()
+			return
+		}
+
 		// Proceed
 		c.Next()
 	}
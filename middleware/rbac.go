@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+
+	"api/biz/say_right/dal/query"
+	"api/biz/say_right/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission only lets through logged-in, active users whose role grants
+// every listed permission. Use it after AuthMiddleware on staff routes. The role
+// is read from the database on every request so changes take effect immediately.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := SessionUserID(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "Please login first",
+			})
+			c.Abort()
+			return
+		}
+
+		user, err := query.User.WithContext(c.Request.Context()).Where(query.User.ID.Eq(userID)).First()
+		if err != nil || user.Status != service.UserStatusActive {
+			forbid(c)
+			return
+		}
+		for _, p := range permissions {
+			if !service.RoleHasPermission(user.Role, p) {
+				forbid(c)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func forbid(c *gin.Context) {
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":   "Forbidden",
+		"message": "You do not have permission to perform this action",
+	})
+	c.Abort()
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// SessionUserID reads the logged-in user id from the session. The cookie
+// store may hand the value back as any numeric type or a string.
+func SessionUserID(c *gin.Context) (int32, bool) {
+	session := sessions.Default(c)
+	value := session.Get("user_id")
+	if value == nil {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case int32:
+		return v, v > 0
+	case int:
+		return int32(v), v > 0
+	case int64:
+		return int32(v), v > 0
+	case float64:
+		return int32(v), v > 0
+	case string:
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, false
+		}
+		return int32(parsed), parsed > 0
+	default:
+		return 0, false
+	}
+}
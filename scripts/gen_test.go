@@ -49,10 +49,10 @@ func TestGen(t *testing.T) {
 	g.UseDB(db)
 
 	// Map SQLite types to Go types
-	// Note: GORM Gen handles mapping, but SQLite might need hints for integers to be int64
+	// Note: integers are mapped to int32 to match the MySQL models already in use
 	dataMap := map[string]func(gorm.ColumnType) (dataType string){
 		"integer": func(detail gorm.ColumnType) (dataType string) {
-			return "int64"
+			return "int32"
 		},
 		"text": func(detail gorm.ColumnType) (dataType string) {
 			return "string"
@@ -68,12 +68,64 @@ func TestGen(t *testing.T) {
 
 	// Generate all tables
 	g.ApplyBasic(
-		g.GenerateModel("users"),
+		g.GenerateModel("users",
+			gen.FieldType("pro_until", "*time.Time"),
+			gen.FieldType("referral_code", "*string"),
+		),
 		g.GenerateModel("user_identities"),
 		g.GenerateModel("email_verifications"),
-		g.GenerateModel("categories"),
-		g.GenerateModel("templates"),
+		g.GenerateModel("categories",
+			gen.FieldType("parent_id", "*int32"),
+			gen.FieldType("publish_at", "*time.Time"),
+			gen.FieldType("unpublish_at", "*time.Time"),
+		),
+		g.GenerateModel("templates",
+			gen.FieldType("publish_at", "*time.Time"),
+			gen.FieldType("unpublish_at", "*time.Time"),
+			gen.FieldType("draft_revision_id", "*int32"),
+		),
 		g.GenerateModel("template_details"),
+		g.GenerateModel("billing_events"),
+		g.GenerateModel("audit_logs"),
+		g.GenerateModel("template_revisions"),
+		g.GenerateModel("template_slug_redirects"),
+		g.GenerateModel("tags"),
+		g.GenerateModel("template_tags"),
+		g.GenerateModel("collections"),
+		g.GenerateModel("collection_items"),
+		g.GenerateModel("category_translations"),
+		g.GenerateModel("template_translations"),
+		g.GenerateModel("generation_usages",
+			gen.FieldType("cost_micros", "int64"),
+		),
+		g.GenerateModel("template_embeddings"),
+		g.GenerateModel("organizations",
+			gen.FieldType("current_period_end", "*time.Time"),
+			gen.FieldType("subscription_event_at", "*time.Time"),
+		),
+		g.GenerateModel("organization_members"),
+		g.GenerateModel("organization_invitations"),
+		g.GenerateModel("user_templates",
+			gen.FieldType("organization_id", "*int32"),
+			gen.FieldType("source_template_id", "*int32"),
+		),
+		g.GenerateModel("template_favorites"),
+		g.GenerateModel("template_activities",
+			gen.FieldType("last_viewed_at", "*time.Time"),
+			gen.FieldType("last_copied_at", "*time.Time"),
+		),
+		g.GenerateModel("template_copies"),
+		g.GenerateModel("template_ratings",
+			gen.FieldType("moderated_at", "*time.Time"),
+		),
+		g.GenerateModel("template_shares",
+			gen.FieldType("last_viewed_at", "*time.Time"),
+			gen.FieldType("expires_at", "*time.Time"),
+			gen.FieldType("revoked_at", "*time.Time"),
+		),
+		g.GenerateModel("referrals",
+			gen.FieldType("rewarded_at", "*time.Time"),
+		),
 	)
 
 	g.Execute()
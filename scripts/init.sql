@@ -7,12 +7,18 @@ CREATE TABLE users
 
     status            TINYINT      NOT NULL DEFAULT 1,
     is_pro            TINYINT(1)      NOT NULL DEFAULT 0,
+    team_pro          TINYINT(1)      NOT NULL DEFAULT 0, -- 所在团队订阅有效时由系统维护
+    pro_until         DATETIME(3)     NULL, -- 邀请奖励等限时 Pro 的到期时间
+    role              VARCHAR(32)  NOT NULL DEFAULT 'user',
+    locale            VARCHAR(16)  NOT NULL DEFAULT '', -- 内容语言偏好（为空则按 Accept-Language）
+    referral_code     VARCHAR(16)  NULL, -- 邀请码，首次使用时生成
 
     created_at        DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
     updated_at        DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
 
     PRIMARY KEY (id),
-    UNIQUE KEY `ux_users_email_norm` (email_norm)
+    UNIQUE KEY `ux_users_email_norm` (email_norm),
+    UNIQUE KEY `ux_users_referral_code` (referral_code)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 
 
@@ -71,18 +77,72 @@ CREATE TABLE email_verifications
     KEY           ix_ev_consumed (consumed_at)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 
+-- 账单事件表（Paddle webhook 原始记录）
+CREATE TABLE billing_events
+(
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    event_id        VARCHAR(64)  NOT NULL,
+    event_type      VARCHAR(64)  NOT NULL,
+    user_id         BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    email           VARCHAR(320) NOT NULL DEFAULT '',
+    transaction_id  VARCHAR(64)  NOT NULL DEFAULT '',
+    subscription_id VARCHAR(64)  NOT NULL DEFAULT '',
+    status          VARCHAR(32)  NOT NULL DEFAULT '',
+    amount          VARCHAR(32)  NOT NULL DEFAULT '',
+    currency        VARCHAR(8)   NOT NULL DEFAULT '',
+    payload         MEDIUMTEXT   NOT NULL,
+    occurred_at     DATETIME(3)  NULL,
+    created_at      DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY ux_billing_events_event (event_id),
+    KEY             ix_billing_events_user (user_id, created_at),
+    KEY             ix_billing_events_email (email)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- 审计日志表（只追加）
+CREATE TABLE audit_logs
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    actor_id    BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    actor_type  VARCHAR(16)  NOT NULL DEFAULT 'user', -- user / system / webhook
+    action      VARCHAR(64)  NOT NULL,
+    target_type VARCHAR(32)  NOT NULL DEFAULT '',
+    target_id   VARCHAR(64)  NOT NULL DEFAULT '',
+    before_data TEXT         NULL,                    -- 变更前（仅变化字段）
+    after_data  TEXT         NULL,                    -- 变更后（仅变化字段）
+    detail      TEXT         NOT NULL,
+    ip          VARCHAR(64)  NOT NULL DEFAULT '',
+    user_agent  VARCHAR(512) NOT NULL DEFAULT '',
+    request_id  VARCHAR(64)  NOT NULL DEFAULT '',
+    created_at  DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    KEY         ix_audit_logs_actor (actor_id, created_at),
+    KEY         ix_audit_logs_action (action, created_at),
+    KEY         ix_audit_logs_target (target_type, target_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
 -- 目录表
 CREATE TABLE categories
 (
     id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
     name        VARCHAR(64) NOT NULL,
+    slug        VARCHAR(96) NOT NULL,              -- 稳定标识，用于导入导出与 URL
+    parent_id   BIGINT UNSIGNED      DEFAULT NULL, -- 上级目录（为空表示顶级）
     description VARCHAR(255)         DEFAULT NULL,
     sort_order  INT         NOT NULL DEFAULT 0,
-    icon        VARCHAR(255)         DEFAULT NULL is_active TINYINT(1) NOT NULL DEFAULT 1,
+    icon        VARCHAR(255)         DEFAULT NULL,
+    is_active   TINYINT(1) NOT NULL DEFAULT 1,
+    publish_at  DATETIME    NULL,                 -- 定时上线（为空表示立即）
+    unpublish_at DATETIME   NULL,                 -- 定时下线（为空表示不下线）
     created_at  TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at  TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (id),
-    UNIQUE KEY uk_categories_name (name)
+    UNIQUE KEY uk_categories_name (name),
+    UNIQUE KEY uk_categories_slug (slug),
+    KEY         ix_categories_parent (parent_id, sort_order),
+    CONSTRAINT fk_categories_parent
+        FOREIGN KEY (parent_id) REFERENCES categories (id)
+            ON DELETE SET NULL ON UPDATE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
 -- 模板表（归属目录，一对多）
@@ -91,15 +151,21 @@ CREATE TABLE templates
     id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
     category_id BIGINT UNSIGNED NOT NULL,
     title       VARCHAR(128) NOT NULL,
+    slug        VARCHAR(96)  NOT NULL,             -- 稳定标识，用于导入导出与 URL
     description VARCHAR(512) NOT NULL,
     tags_text   VARCHAR(512) NOT NULL, -- 冗余文本，如 "Interrupt,Hard Reject"
     is_pro      TINYINT(1) NOT NULL DEFAULT 0,
     sort_order  INT          NOT NULL DEFAULT 0,
     is_active   TINYINT(1) NOT NULL DEFAULT 1,
+    publish_at  DATETIME     NULL,                -- 定时上线（为空表示立即）
+    unpublish_at DATETIME    NULL,                -- 定时下线（为空表示不下线）
+    draft_revision_id BIGINT UNSIGNED NULL,       -- 待发布的草稿版本（为空表示没有草稿）
     created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (id),
+    UNIQUE KEY uk_templates_slug (slug),
     KEY         idx_templates_category (category_id, sort_order),
+    FULLTEXT KEY ft_templates_search (title, description, tags_text) WITH PARSER ngram,
     CONSTRAINT fk_templates_category
         FOREIGN KEY (category_id) REFERENCES categories (id)
             ON DELETE RESTRICT ON UPDATE CASCADE
@@ -117,10 +183,13 @@ CREATE TABLE template_details
     reply_firm      TEXT         NOT NULL, -- 强烈
     when_not_to_use TEXT         NOT NULL, -- 何时不用
     best_practices  TEXT         NOT NULL, -- 最佳实践
+    variables       TEXT         NOT NULL, -- 回复中占位变量的定义 JSON，如 [{"name":"deadline","type":"date"}]
+    prompt          TEXT         NOT NULL, -- AI 生成用的提示词模板，空则使用默认提示词
     created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (id),
     UNIQUE KEY uk_template_details_template (template_id),
+    FULLTEXT KEY ft_template_details_search (headline, summary, reply_soft, reply_neutral, reply_firm) WITH PARSER ngram,
     CONSTRAINT fk_template_details_template
         FOREIGN KEY (template_id) REFERENCES templates (id)
             ON DELETE CASCADE ON UPDATE CASCADE
@@ -133,15 +202,21 @@ CREATE TABLE templates
     id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
     category_id BIGINT UNSIGNED NOT NULL,
     title       VARCHAR(128) NOT NULL,
+    slug        VARCHAR(96)  NOT NULL,             -- 稳定标识，用于导入导出与 URL
     description VARCHAR(512) NOT NULL,
     tags_text   VARCHAR(512) NOT NULL, -- 冗余文本，如 "Interrupt,Hard Reject"
     is_pro      TINYINT(1) NOT NULL DEFAULT 0,
     sort_order  INT          NOT NULL DEFAULT 0,
     is_active   TINYINT(1) NOT NULL DEFAULT 1,
+    publish_at  DATETIME     NULL,                -- 定时上线（为空表示立即）
+    unpublish_at DATETIME    NULL,                -- 定时下线（为空表示不下线）
+    draft_revision_id BIGINT UNSIGNED NULL,       -- 待发布的草稿版本（为空表示没有草稿）
     created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (id),
-    KEY         idx_templates_category (category_id, sort_order)
+    UNIQUE KEY uk_templates_slug (slug),
+    KEY         idx_templates_category (category_id, sort_order),
+    FULLTEXT KEY ft_templates_search (title, description, tags_text) WITH PARSER ngram
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
 -- 模板详情表（1:1）
@@ -156,11 +231,404 @@ CREATE TABLE template_details
     reply_firm      TEXT         NOT NULL, -- 强烈
     when_not_to_use TEXT         NOT NULL, -- 何时不用
     best_practices  TEXT         NOT NULL, -- 最佳实践
+    variables       TEXT         NOT NULL, -- 回复中占位变量的定义 JSON，如 [{"name":"deadline","type":"date"}]
+    prompt          TEXT         NOT NULL, -- AI 生成用的提示词模板，空则使用默认提示词
     created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (id),
     UNIQUE KEY uk_template_details_template (template_id),
+    FULLTEXT KEY ft_template_details_search (headline, summary, reply_soft, reply_neutral, reply_firm) WITH PARSER ngram,
     CONSTRAINT fk_template_details_template
         FOREIGN KEY (template_id) REFERENCES templates (id)
             ON DELETE CASCADE ON UPDATE CASCADE
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
\ No newline at end of file
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 模板修订历史（草稿与已发布版本）
+CREATE TABLE template_revisions
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    template_id BIGINT UNSIGNED NOT NULL,
+    status      VARCHAR(16)  NOT NULL,               -- draft / published
+    snapshot    MEDIUMTEXT   NOT NULL,               -- 模板 + 详情的 JSON 快照
+    author_id   BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    note        VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    KEY         ix_template_revisions_template (template_id, id),
+    CONSTRAINT fk_template_revisions_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 模板旧 slug 跳转（slug 变更后旧链接 301 到新地址）
+CREATE TABLE template_slug_redirects
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    old_slug    VARCHAR(96)     NOT NULL,
+    template_id BIGINT UNSIGNED NOT NULL,
+    created_at  DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_template_slug_redirects_slug (old_slug),
+    CONSTRAINT fk_template_slug_redirects_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 标签（模板多对多），tags_text 仍冗余保存用于展示
+CREATE TABLE tags
+(
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    slug       VARCHAR(96)  NOT NULL,               -- 由名称生成的稳定标识
+    name       VARCHAR(64)  NOT NULL,               -- 显示名称
+    color      VARCHAR(16)  NOT NULL DEFAULT '',    -- 如 #FF8800
+    labels     TEXT         NOT NULL,               -- 本地化名称 JSON，如 {"zh-CN":"打断"}
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_tags_slug (slug)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 模板-标签关联
+CREATE TABLE template_tags
+(
+    template_id BIGINT UNSIGNED NOT NULL,
+    tag_id      BIGINT UNSIGNED NOT NULL,
+    sort_order  INT             NOT NULL DEFAULT 0, -- 标签在模板内的顺序
+    PRIMARY KEY (template_id, tag_id),
+    KEY         ix_template_tags_tag (tag_id),
+    CONSTRAINT fk_template_tags_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_template_tags_tag
+        FOREIGN KEY (tag_id) REFERENCES tags (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 编辑精选合集（跨目录组合模板）
+CREATE TABLE collections
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    slug        VARCHAR(96)  NOT NULL,
+    title       VARCHAR(128) NOT NULL,
+    description VARCHAR(512) NOT NULL DEFAULT '',
+    sort_order  INT          NOT NULL DEFAULT 0,
+    is_active   TINYINT(1)   NOT NULL DEFAULT 1,
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_collections_slug (slug)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 合集内模板及其顺序
+CREATE TABLE collection_items
+(
+    collection_id BIGINT UNSIGNED NOT NULL,
+    template_id   BIGINT UNSIGNED NOT NULL,
+    sort_order    INT             NOT NULL DEFAULT 0,
+    PRIMARY KEY (collection_id, template_id),
+    KEY         ix_collection_items_template (template_id),
+    CONSTRAINT fk_collection_items_collection
+        FOREIGN KEY (collection_id) REFERENCES collections (id)
+            ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_collection_items_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 目录多语言翻译（空字段回退到上一级语言或原文）
+CREATE TABLE category_translations
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    category_id BIGINT UNSIGNED NOT NULL,
+    locale      VARCHAR(16)  NOT NULL,               -- 如 zh-CN
+    name        VARCHAR(64)  NOT NULL DEFAULT '',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_category_translations_locale (category_id, locale),
+    CONSTRAINT fk_category_translations_category
+        FOREIGN KEY (category_id) REFERENCES categories (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 模板多语言翻译（覆盖模板与详情的全部文案字段）
+CREATE TABLE template_translations
+(
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    template_id     BIGINT UNSIGNED NOT NULL,
+    locale          VARCHAR(16)  NOT NULL,
+    title           VARCHAR(128) NOT NULL DEFAULT '',
+    description     VARCHAR(512) NOT NULL DEFAULT '',
+    headline        VARCHAR(128) NOT NULL DEFAULT '',
+    summary         VARCHAR(512) NOT NULL DEFAULT '',
+    reply_soft      TEXT         NOT NULL,
+    reply_neutral   TEXT         NOT NULL,
+    reply_firm      TEXT         NOT NULL,
+    when_not_to_use TEXT         NOT NULL,
+    best_practices  TEXT         NOT NULL,
+    created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_template_translations_locale (template_id, locale),
+    KEY         ix_template_translations_locale (locale),
+    CONSTRAINT fk_template_translations_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- AI 生成调用记录（用于配额核对与成本统计）
+CREATE TABLE generation_usages
+(
+    id            BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    user_id       BIGINT UNSIGNED NOT NULL,
+    template_id   BIGINT UNSIGNED NOT NULL DEFAULT 0,  -- 改写时为 0
+    kind          VARCHAR(16)  NOT NULL DEFAULT 'generate', -- generate / rewrite
+    provider      VARCHAR(32)  NOT NULL,               -- openai / anthropic / stub
+    model         VARCHAR(128) NOT NULL DEFAULT '',
+    tone          VARCHAR(16)  NOT NULL DEFAULT '',
+    status        VARCHAR(16)  NOT NULL,               -- ok / error / timeout / cancelled
+    input_tokens  INT          NOT NULL DEFAULT 0,
+    output_tokens INT          NOT NULL DEFAULT 0,
+    cost_micros   BIGINT       NOT NULL DEFAULT 0,     -- 美元的百万分之一
+    latency_ms    INT          NOT NULL DEFAULT 0,
+    error         VARCHAR(512) NOT NULL DEFAULT '',
+    created_at    DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    KEY           ix_generation_usages_user (user_id, created_at),
+    KEY           ix_generation_usages_created (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- 模板语义向量（用于按情境推荐模板，内容变化后按哈希重新生成）
+CREATE TABLE template_embeddings
+(
+    id           BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    template_id  BIGINT UNSIGNED NOT NULL,
+    model        VARCHAR(128) NOT NULL,
+    content_hash CHAR(64)     NOT NULL,             -- 生成向量时内容的 SHA-256
+    dimensions   INT          NOT NULL,
+    vector       MEDIUMBLOB   NOT NULL,             -- float32 小端序
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_template_embeddings_model (template_id, model),
+    CONSTRAINT fk_template_embeddings_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 团队（按席位订阅，成员全部获得 Pro）
+CREATE TABLE organizations
+(
+    id                  BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name                VARCHAR(128)    NOT NULL,
+    seats               INT UNSIGNED    NOT NULL DEFAULT 0,    -- Paddle 订阅的席位数
+    subscription_id     VARCHAR(64)     NOT NULL DEFAULT '',
+    subscription_status VARCHAR(32)     NOT NULL DEFAULT '',   -- Paddle 订阅状态：active / trialing / past_due / canceled ...
+    current_period_end  DATETIME(3)     NULL,
+    checkout_token      VARCHAR(32)     NOT NULL DEFAULT '',   -- 服务端签发的结账凭证，subscription.created 凭此绑定订阅
+    subscription_event_at DATETIME(3)   NULL,                  -- 最近一次已应用的订阅事件时间，更早的事件忽略
+    created_at          DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at          DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    KEY         ix_organizations_subscription (subscription_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- 团队成员
+CREATE TABLE organization_members
+(
+    organization_id BIGINT UNSIGNED NOT NULL,
+    user_id         BIGINT UNSIGNED NOT NULL,
+    role            VARCHAR(16)     NOT NULL,   -- owner / admin / member
+    created_at      DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (organization_id, user_id),
+    KEY         ix_organization_members_user (user_id),
+    CONSTRAINT fk_organization_members_organization
+        FOREIGN KEY (organization_id) REFERENCES organizations (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_organization_members_user
+        FOREIGN KEY (user_id) REFERENCES users (id)
+            ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- 团队邀请（按邮箱，接受后删除）
+CREATE TABLE organization_invitations
+(
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    organization_id BIGINT UNSIGNED NOT NULL,
+    email           VARCHAR(320)    NOT NULL,   -- 规范化后的邮箱
+    role            VARCHAR(16)     NOT NULL,   -- 接受后获得的角色：admin / member
+    token           VARCHAR(32)     NOT NULL,   -- 邀请链接中的随机令牌
+    invited_by      BIGINT UNSIGNED NOT NULL,
+    expires_at      DATETIME(3)     NOT NULL,
+    created_at      DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_organization_invitations_token (token),
+    UNIQUE KEY uk_organization_invitations_email (organization_id, email),
+    CONSTRAINT fk_organization_invitations_organization
+        FOREIGN KEY (organization_id) REFERENCES organizations (id)
+            ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- 用户自建的私有模板（结构同模板 + 模板详情，仅所有者可见）
+CREATE TABLE user_templates
+(
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    owner_id        BIGINT UNSIGNED NOT NULL,
+    organization_id BIGINT UNSIGNED NULL,              -- 团队模板所属团队，个人模板为空
+    source_template_id BIGINT UNSIGNED NULL,           -- 从目录模板复制而来时的来源
+    source_locale   VARCHAR(16)  NOT NULL DEFAULT '',
+    title           VARCHAR(128) NOT NULL,
+    description     VARCHAR(512) NOT NULL DEFAULT '',
+    tags_text       VARCHAR(512) NOT NULL DEFAULT '',  -- 逗号分隔
+    headline        VARCHAR(128) NOT NULL DEFAULT '',
+    summary         VARCHAR(512) NOT NULL DEFAULT '',
+    reply_soft      TEXT         NOT NULL,
+    reply_neutral   TEXT         NOT NULL,
+    reply_firm      TEXT         NOT NULL,
+    when_not_to_use TEXT         NOT NULL,
+    best_practices  TEXT         NOT NULL,             -- 每行一条
+    variables       TEXT         NOT NULL,             -- JSON 数组
+    source_snapshot TEXT         NOT NULL,             -- 上次同步时来源模板的内容（JSON），用于比较上游变化
+    created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY ix_user_templates_owner (owner_id, updated_at),
+    KEY ix_user_templates_source (source_template_id),
+    KEY ix_user_templates_organization (organization_id, updated_at),
+    CONSTRAINT fk_user_templates_owner
+        FOREIGN KEY (owner_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_user_templates_organization
+        FOREIGN KEY (organization_id) REFERENCES organizations (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_user_templates_source
+        FOREIGN KEY (source_template_id) REFERENCES templates (id)
+            ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- 用户收藏的模板
+CREATE TABLE template_favorites
+(
+    user_id     BIGINT UNSIGNED NOT NULL,
+    template_id BIGINT UNSIGNED NOT NULL,
+    created_at  TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, template_id),
+    KEY         ix_template_favorites_template (template_id),
+    CONSTRAINT fk_template_favorites_user
+        FOREIGN KEY (user_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_template_favorites_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 用户最近查看、复制模板的记录（每人每模板一行）
+CREATE TABLE template_activities
+(
+    user_id        BIGINT UNSIGNED NOT NULL,
+    template_id    BIGINT UNSIGNED NOT NULL,
+    view_count     INT             NOT NULL DEFAULT 0,
+    copy_count     INT             NOT NULL DEFAULT 0,
+    last_viewed_at DATETIME(3)     NULL,
+    last_copied_at DATETIME(3)     NULL,
+    last_used_at   DATETIME(3)     NOT NULL,   -- 最近一次查看或复制
+    PRIMARY KEY (user_id, template_id),
+    KEY         ix_template_activities_viewed (user_id, last_viewed_at),
+    CONSTRAINT fk_template_activities_user
+        FOREIGN KEY (user_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_template_activities_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 复制回复的事件流水
+CREATE TABLE template_copies
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    user_id     BIGINT UNSIGNED NOT NULL,
+    template_id BIGINT UNSIGNED NOT NULL,
+    tone        VARCHAR(16)     NOT NULL,   -- 复制的语气：soft / neutral / firm
+    created_at  DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    KEY         ix_template_copies_template (template_id, created_at),
+    KEY         ix_template_copies_user (user_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 用户对模板各语气回复的评价（每人每模板每语气一条，评论需审核）
+CREATE TABLE template_ratings
+(
+    id             BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    user_id        BIGINT UNSIGNED NOT NULL,
+    template_id    BIGINT UNSIGNED NOT NULL,
+    tone           VARCHAR(16)     NOT NULL,   -- soft / neutral / firm
+    vote           TINYINT         NOT NULL,   -- 1 赞 / -1 踩
+    comment        VARCHAR(1000)   NOT NULL DEFAULT '',
+    comment_status VARCHAR(16)     NOT NULL DEFAULT '',   -- 空表示无评论 / pending / approved / rejected
+    moderated_by   BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    moderated_at   DATETIME(3)     NULL,
+    created_at     DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at     DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_template_ratings_user (user_id, template_id, tone),
+    KEY         ix_template_ratings_template (template_id, tone),
+    KEY         ix_template_ratings_comment (comment_status, updated_at),
+    CONSTRAINT fk_template_ratings_user
+        FOREIGN KEY (user_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_template_ratings_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- 模板分享链接（公开访问，可带已填写的变量，支持过期与撤销）
+CREATE TABLE template_shares
+(
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    token           VARCHAR(32)     NOT NULL,   -- 不可猜测的随机令牌，用于公开链接
+    user_id         BIGINT UNSIGNED NOT NULL,   -- 分享者
+    template_id     BIGINT UNSIGNED NOT NULL,
+    locale          VARCHAR(16)     NOT NULL,   -- 分享时的语言
+    tone            VARCHAR(16)     NOT NULL DEFAULT '',   -- 空表示三种语气都分享
+    variable_values TEXT            NOT NULL,   -- 变量取值 JSON
+    view_count      INT UNSIGNED    NOT NULL DEFAULT 0,
+    last_viewed_at  DATETIME(3)     NULL,
+    expires_at      DATETIME(3)     NULL,       -- 空表示永不过期
+    revoked_at      DATETIME(3)     NULL,
+    created_at      DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_template_shares_token (token),
+    KEY         ix_template_shares_user (user_id, created_at),
+    CONSTRAINT fk_template_shares_user
+        FOREIGN KEY (user_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_template_shares_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- 邀请记录（每个被邀请用户最多一条，被邀请人首次付费后给邀请人发放 Pro 时长）
+CREATE TABLE referrals
+(
+    id             BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    referrer_id    BIGINT UNSIGNED NOT NULL,   -- 邀请人
+    referee_id     BIGINT UNSIGNED NOT NULL,   -- 被邀请人
+    status         VARCHAR(16)     NOT NULL,   -- pending / rewarded / rejected
+    reason         VARCHAR(32)     NOT NULL DEFAULT '',   -- 拒绝原因，如 same_ip、disposable_email
+    signup_ip      VARCHAR(64)     NOT NULL DEFAULT '',   -- 被邀请人注册时的 IP
+    reward_days    INT UNSIGNED    NOT NULL DEFAULT 0,    -- 已发放的 Pro 天数
+    transaction_id VARCHAR(64)     NOT NULL DEFAULT '',   -- 触发奖励的 Paddle 交易
+    rewarded_at    DATETIME(3)     NULL,
+    created_at     DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at     DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_referrals_referee (referee_id),
+    KEY         ix_referrals_referrer (referrer_id, created_at),
+    CONSTRAINT fk_referrals_referrer
+        FOREIGN KEY (referrer_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_referrals_referee
+        FOREIGN KEY (referee_id) REFERENCES users (id)
+            ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -6,9 +6,15 @@ CREATE TABLE IF NOT EXISTS users (
     email_verified_at DATETIME NULL,
     status            INTEGER NOT NULL DEFAULT 1,
     is_pro            INTEGER NOT NULL DEFAULT 0,
+    team_pro          INTEGER NOT NULL DEFAULT 0,
+    pro_until         DATETIME NULL,
+    role              TEXT NOT NULL DEFAULT 'user',
+    locale            TEXT NOT NULL DEFAULT '',
+    referral_code     TEXT NULL,
     created_at        DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at        DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    UNIQUE (email_norm)
+    UNIQUE (email_norm),
+    UNIQUE (referral_code)
 );
 
 -- User Identities table
@@ -47,29 +53,82 @@ CREATE INDEX IF NOT EXISTS ix_ev_lookup ON email_verifications (email_norm, purp
 CREATE INDEX IF NOT EXISTS ix_ev_expire ON email_verifications (expires_at);
 CREATE INDEX IF NOT EXISTS ix_ev_consumed ON email_verifications (consumed_at);
 
+-- Billing events table (raw Paddle webhook records)
+CREATE TABLE IF NOT EXISTS billing_events (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id        TEXT NOT NULL,
+    event_type      TEXT NOT NULL,
+    user_id         INTEGER NOT NULL DEFAULT 0,
+    email           TEXT NOT NULL DEFAULT '',
+    transaction_id  TEXT NOT NULL DEFAULT '',
+    subscription_id TEXT NOT NULL DEFAULT '',
+    status          TEXT NOT NULL DEFAULT '',
+    amount          TEXT NOT NULL DEFAULT '',
+    currency        TEXT NOT NULL DEFAULT '',
+    payload         TEXT NOT NULL,
+    occurred_at     DATETIME NULL,
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (event_id)
+);
+
+CREATE INDEX IF NOT EXISTS ix_billing_events_user ON billing_events (user_id, created_at);
+CREATE INDEX IF NOT EXISTS ix_billing_events_email ON billing_events (email);
+
+-- Audit logs table (append-only)
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor_id    INTEGER NOT NULL DEFAULT 0,
+    actor_type  TEXT NOT NULL DEFAULT 'user',
+    action      TEXT NOT NULL,
+    target_type TEXT NOT NULL DEFAULT '',
+    target_id   TEXT NOT NULL DEFAULT '',
+    before_data TEXT NULL,
+    after_data  TEXT NULL,
+    detail      TEXT NOT NULL,
+    ip          TEXT NOT NULL DEFAULT '',
+    user_agent  TEXT NOT NULL DEFAULT '',
+    request_id  TEXT NOT NULL DEFAULT '',
+    created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS ix_audit_logs_actor ON audit_logs (actor_id, created_at);
+CREATE INDEX IF NOT EXISTS ix_audit_logs_action ON audit_logs (action, created_at);
+CREATE INDEX IF NOT EXISTS ix_audit_logs_target ON audit_logs (target_type, target_id, created_at);
+
 CREATE TABLE IF NOT EXISTS categories (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     name TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    parent_id INTEGER NULL,
     description TEXT NULL,
     sort_order INTEGER NOT NULL DEFAULT 0,
     icon TEXT NULL,
     is_active INTEGER NOT NULL DEFAULT 1,
+    publish_at DATETIME NULL,
+    unpublish_at DATETIME NULL,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    UNIQUE (name)
+    UNIQUE (name),
+    UNIQUE (slug),
+    CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE SET NULL
 );
 
 CREATE TABLE IF NOT EXISTS templates (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     category_id INTEGER NOT NULL,
     title TEXT NOT NULL,
+    slug TEXT NOT NULL,
     description TEXT NOT NULL,
     tags_text TEXT NOT NULL,
     is_pro INTEGER NOT NULL DEFAULT 0,
     sort_order INTEGER NOT NULL DEFAULT 0,
     is_active INTEGER NOT NULL DEFAULT 1,
+    publish_at DATETIME NULL,
+    unpublish_at DATETIME NULL,
+    draft_revision_id INTEGER NULL,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (slug),
     CONSTRAINT fk_templates_category FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE RESTRICT
 );
 
@@ -85,8 +144,310 @@ CREATE TABLE IF NOT EXISTS template_details (
     reply_firm TEXT NOT NULL,
     when_not_to_use TEXT NOT NULL,
     best_practices TEXT NOT NULL,
+    variables TEXT NOT NULL DEFAULT '[]',
+    prompt TEXT NOT NULL DEFAULT '',
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     UNIQUE (template_id),
     CONSTRAINT fk_template_details_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE
 );
+
+CREATE TABLE IF NOT EXISTS template_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    template_id INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    snapshot TEXT NOT NULL,
+    author_id INTEGER NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_template_revisions_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ix_template_revisions_template ON template_revisions (template_id, id);
+
+CREATE TABLE IF NOT EXISTS template_slug_redirects (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    old_slug TEXT NOT NULL,
+    template_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (old_slug),
+    CONSTRAINT fk_template_slug_redirects_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slug TEXT NOT NULL,
+    name TEXT NOT NULL,
+    color TEXT NOT NULL DEFAULT '',
+    labels TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (slug)
+);
+
+CREATE TABLE IF NOT EXISTS template_tags (
+    template_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (template_id, tag_id),
+    CONSTRAINT fk_template_tags_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE,
+    CONSTRAINT fk_template_tags_tag FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ix_template_tags_tag ON template_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS collections (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slug TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    is_active INTEGER NOT NULL DEFAULT 1,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (slug)
+);
+
+CREATE TABLE IF NOT EXISTS collection_items (
+    collection_id INTEGER NOT NULL,
+    template_id INTEGER NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (collection_id, template_id),
+    CONSTRAINT fk_collection_items_collection FOREIGN KEY (collection_id) REFERENCES collections(id) ON DELETE CASCADE,
+    CONSTRAINT fk_collection_items_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ix_collection_items_template ON collection_items (template_id);
+
+CREATE TABLE IF NOT EXISTS category_translations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    category_id INTEGER NOT NULL,
+    locale TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (category_id, locale),
+    CONSTRAINT fk_category_translations_category FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS template_translations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    template_id INTEGER NOT NULL,
+    locale TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    headline TEXT NOT NULL DEFAULT '',
+    summary TEXT NOT NULL DEFAULT '',
+    reply_soft TEXT NOT NULL DEFAULT '',
+    reply_neutral TEXT NOT NULL DEFAULT '',
+    reply_firm TEXT NOT NULL DEFAULT '',
+    when_not_to_use TEXT NOT NULL DEFAULT '',
+    best_practices TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (template_id, locale),
+    CONSTRAINT fk_template_translations_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ix_template_translations_locale ON template_translations (locale);
+
+-- AI generation calls (quota reconciliation and cost tracking)
+CREATE TABLE IF NOT EXISTS generation_usages (
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id       INTEGER NOT NULL,
+    template_id   INTEGER NOT NULL DEFAULT 0,
+    kind          TEXT NOT NULL DEFAULT 'generate',
+    provider      TEXT NOT NULL,
+    model         TEXT NOT NULL DEFAULT '',
+    tone          TEXT NOT NULL DEFAULT '',
+    status        TEXT NOT NULL,
+    input_tokens  INTEGER NOT NULL DEFAULT 0,
+    output_tokens INTEGER NOT NULL DEFAULT 0,
+    cost_micros   INTEGER NOT NULL DEFAULT 0,
+    latency_ms    INTEGER NOT NULL DEFAULT 0,
+    error         TEXT NOT NULL DEFAULT '',
+    created_at    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS ix_generation_usages_user ON generation_usages (user_id, created_at);
+CREATE INDEX IF NOT EXISTS ix_generation_usages_created ON generation_usages (created_at);
+
+CREATE TABLE IF NOT EXISTS template_embeddings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    template_id INTEGER NOT NULL,
+    model TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    dimensions INTEGER NOT NULL,
+    vector BLOB NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (template_id, model),
+    CONSTRAINT fk_template_embeddings_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    seats INTEGER NOT NULL DEFAULT 0,
+    subscription_id TEXT NOT NULL DEFAULT '',
+    subscription_status TEXT NOT NULL DEFAULT '',
+    current_period_end DATETIME NULL,
+    checkout_token TEXT NOT NULL DEFAULT '',
+    subscription_event_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS ix_organizations_subscription ON organizations (subscription_id);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    role TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, user_id),
+    CONSTRAINT fk_organization_members_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_organization_members_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ix_organization_members_user ON organization_members (user_id);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    organization_id INTEGER NOT NULL,
+    email TEXT NOT NULL,
+    role TEXT NOT NULL,
+    token TEXT NOT NULL,
+    invited_by INTEGER NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (token),
+    UNIQUE (organization_id, email),
+    CONSTRAINT fk_organization_invitations_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner_id INTEGER NOT NULL,
+    organization_id INTEGER NULL,
+    source_template_id INTEGER NULL,
+    source_locale TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    tags_text TEXT NOT NULL DEFAULT '',
+    headline TEXT NOT NULL DEFAULT '',
+    summary TEXT NOT NULL DEFAULT '',
+    reply_soft TEXT NOT NULL,
+    reply_neutral TEXT NOT NULL,
+    reply_firm TEXT NOT NULL,
+    when_not_to_use TEXT NOT NULL,
+    best_practices TEXT NOT NULL,
+    variables TEXT NOT NULL DEFAULT '[]',
+    source_snapshot TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_templates_owner FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_templates_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_templates_source FOREIGN KEY (source_template_id) REFERENCES templates(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS ix_user_templates_owner ON user_templates (owner_id, updated_at);
+CREATE INDEX IF NOT EXISTS ix_user_templates_source ON user_templates (source_template_id);
+CREATE INDEX IF NOT EXISTS ix_user_templates_organization ON user_templates (organization_id, updated_at);
+
+CREATE TABLE IF NOT EXISTS template_favorites (
+    user_id INTEGER NOT NULL,
+    template_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, template_id),
+    CONSTRAINT fk_template_favorites_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_template_favorites_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ix_template_favorites_template ON template_favorites (template_id);
+
+CREATE TABLE IF NOT EXISTS template_activities (
+    user_id INTEGER NOT NULL,
+    template_id INTEGER NOT NULL,
+    view_count INTEGER NOT NULL DEFAULT 0,
+    copy_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at DATETIME NULL,
+    last_copied_at DATETIME NULL,
+    last_used_at DATETIME NOT NULL,
+    PRIMARY KEY (user_id, template_id),
+    CONSTRAINT fk_template_activities_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_template_activities_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ix_template_activities_viewed ON template_activities (user_id, last_viewed_at);
+
+CREATE TABLE IF NOT EXISTS template_copies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    template_id INTEGER NOT NULL,
+    tone TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS ix_template_copies_template ON template_copies (template_id, created_at);
+CREATE INDEX IF NOT EXISTS ix_template_copies_user ON template_copies (user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS template_ratings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    template_id INTEGER NOT NULL,
+    tone TEXT NOT NULL,
+    vote INTEGER NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    comment_status TEXT NOT NULL DEFAULT '',
+    moderated_by INTEGER NOT NULL DEFAULT 0,
+    moderated_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, template_id, tone),
+    CONSTRAINT fk_template_ratings_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_template_ratings_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ix_template_ratings_template ON template_ratings (template_id, tone);
+CREATE INDEX IF NOT EXISTS ix_template_ratings_comment ON template_ratings (comment_status, updated_at);
+
+CREATE TABLE IF NOT EXISTS template_shares (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    token TEXT NOT NULL,
+    user_id INTEGER NOT NULL,
+    template_id INTEGER NOT NULL,
+    locale TEXT NOT NULL,
+    tone TEXT NOT NULL DEFAULT '',
+    variable_values TEXT NOT NULL,
+    view_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at DATETIME NULL,
+    expires_at DATETIME NULL,
+    revoked_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (token),
+    CONSTRAINT fk_template_shares_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_template_shares_template FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ix_template_shares_user ON template_shares (user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS referrals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    referrer_id INTEGER NOT NULL,
+    referee_id INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    signup_ip TEXT NOT NULL DEFAULT '',
+    reward_days INTEGER NOT NULL DEFAULT 0,
+    transaction_id TEXT NOT NULL DEFAULT '',
+    rewarded_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (referee_id),
+    CONSTRAINT fk_referrals_referrer FOREIGN KEY (referrer_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_referrals_referee FOREIGN KEY (referee_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ix_referrals_referrer ON referrals (referrer_id, created_at);
@@ -0,0 +1,41 @@
+-- Admin API: staff role on users, billing history and audit trail.
+
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'user' AFTER is_pro;
+
+CREATE TABLE billing_events
+(
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    event_id        VARCHAR(64)  NOT NULL,
+    event_type      VARCHAR(64)  NOT NULL,
+    user_id         BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    email           VARCHAR(320) NOT NULL DEFAULT '',
+    transaction_id  VARCHAR(64)  NOT NULL DEFAULT '',
+    subscription_id VARCHAR(64)  NOT NULL DEFAULT '',
+    status          VARCHAR(32)  NOT NULL DEFAULT '',
+    amount          VARCHAR(32)  NOT NULL DEFAULT '',
+    currency        VARCHAR(8)   NOT NULL DEFAULT '',
+    payload         MEDIUMTEXT   NOT NULL,
+    occurred_at     DATETIME(3)  NULL,
+    created_at      DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY ux_billing_events_event (event_id),
+    KEY             ix_billing_events_user (user_id, created_at),
+    KEY             ix_billing_events_email (email)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE audit_logs
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    actor_id    BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    action      VARCHAR(64)  NOT NULL,
+    target_type VARCHAR(32)  NOT NULL DEFAULT '',
+    target_id   VARCHAR(64)  NOT NULL DEFAULT '',
+    detail      TEXT         NOT NULL,
+    ip          VARCHAR(64)  NOT NULL DEFAULT '',
+    user_agent  VARCHAR(512) NOT NULL DEFAULT '',
+    created_at  DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    KEY         ix_audit_logs_actor (actor_id, created_at),
+    KEY         ix_audit_logs_target (target_type, target_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
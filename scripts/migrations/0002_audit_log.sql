@@ -0,0 +1,8 @@
+-- Audit log: actor type, request correlation and before/after diffs.
+
+ALTER TABLE audit_logs
+    ADD COLUMN actor_type  VARCHAR(16) NOT NULL DEFAULT 'user' AFTER actor_id,
+    ADD COLUMN before_data TEXT        NULL AFTER target_id,
+    ADD COLUMN after_data  TEXT        NULL AFTER before_data,
+    ADD COLUMN request_id  VARCHAR(64) NOT NULL DEFAULT '' AFTER user_agent,
+    ADD KEY ix_audit_logs_action (action, created_at);
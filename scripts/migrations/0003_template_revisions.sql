@@ -0,0 +1,17 @@
+-- Template revision history with draft/published states.
+
+CREATE TABLE template_revisions
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    template_id BIGINT UNSIGNED NOT NULL,
+    status      VARCHAR(16)  NOT NULL,
+    snapshot    MEDIUMTEXT   NOT NULL,
+    author_id   BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    note        VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    KEY         ix_template_revisions_template (template_id, id),
+    CONSTRAINT fk_template_revisions_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,11 @@
+-- Scheduled publishing window for categories and templates.
+
+ALTER TABLE categories
+    ADD COLUMN publish_at   DATETIME NULL AFTER is_active,
+    ADD COLUMN unpublish_at DATETIME NULL AFTER publish_at;
+
+ALTER TABLE templates
+    ADD COLUMN publish_at   DATETIME NULL AFTER is_active,
+    ADD COLUMN unpublish_at DATETIME NULL AFTER publish_at,
+    ADD KEY idx_templates_publish (publish_at),
+    ADD KEY idx_templates_unpublish (unpublish_at);
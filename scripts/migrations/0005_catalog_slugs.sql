@@ -0,0 +1,16 @@
+-- Stable slugs for categories and templates, used by catalog import/export.
+-- Existing rows get an id-based slug; editors can rename them afterwards.
+
+ALTER TABLE categories
+    ADD COLUMN slug VARCHAR(96) NOT NULL DEFAULT '' AFTER name;
+UPDATE categories SET slug = CONCAT('category-', id) WHERE slug = '';
+ALTER TABLE categories
+    ALTER COLUMN slug DROP DEFAULT,
+    ADD UNIQUE KEY uk_categories_slug (slug);
+
+ALTER TABLE templates
+    ADD COLUMN slug VARCHAR(96) NOT NULL DEFAULT '' AFTER title;
+UPDATE templates SET slug = CONCAT('template-', id) WHERE slug = '';
+ALTER TABLE templates
+    ALTER COLUMN slug DROP DEFAULT,
+    ADD UNIQUE KEY uk_templates_slug (slug);
@@ -0,0 +1,13 @@
+-- Old template slugs kept after a rename so existing links can redirect.
+CREATE TABLE template_slug_redirects
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    old_slug    VARCHAR(96)     NOT NULL,
+    template_id BIGINT UNSIGNED NOT NULL,
+    created_at  DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_template_slug_redirects_slug (old_slug),
+    CONSTRAINT fk_template_slug_redirects_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
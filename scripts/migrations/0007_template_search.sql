@@ -0,0 +1,9 @@
+-- FULLTEXT indexes backing GET /sayright/templates/search on MySQL. The ngram
+-- parser splits text into bigrams, so Chinese and Japanese, which have no
+-- spaces between words, are searchable too.
+
+ALTER TABLE templates
+    ADD FULLTEXT KEY ft_templates_search (title, description, tags_text) WITH PARSER ngram;
+
+ALTER TABLE template_details
+    ADD FULLTEXT KEY ft_template_details_search (headline, summary, reply_soft, reply_neutral, reply_firm) WITH PARSER ngram;
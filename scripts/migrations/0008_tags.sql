@@ -0,0 +1,30 @@
+-- Tags as their own table, linked to templates many-to-many.
+-- The server links existing templates to tags from their tags_text on
+-- startup; `api tags backfill` re-links every template by hand.
+CREATE TABLE tags
+(
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    slug       VARCHAR(96)  NOT NULL,               -- 由名称生成的稳定标识
+    name       VARCHAR(64)  NOT NULL,               -- 显示名称
+    color      VARCHAR(16)  NOT NULL DEFAULT '',    -- 如 #FF8800
+    labels     TEXT         NOT NULL,               -- 本地化名称 JSON，如 {"zh-CN":"打断"}
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_tags_slug (slug)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE template_tags
+(
+    template_id BIGINT UNSIGNED NOT NULL,
+    tag_id      BIGINT UNSIGNED NOT NULL,
+    sort_order  INT             NOT NULL DEFAULT 0, -- 标签在模板内的顺序
+    PRIMARY KEY (template_id, tag_id),
+    KEY         ix_template_tags_tag (tag_id),
+    CONSTRAINT fk_template_tags_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_template_tags_tag
+        FOREIGN KEY (tag_id) REFERENCES tags (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,37 @@
+-- Parent/child categories and editor-curated collections.
+
+ALTER TABLE categories
+    ADD COLUMN parent_id BIGINT UNSIGNED DEFAULT NULL AFTER slug,
+    ADD KEY ix_categories_parent (parent_id, sort_order),
+    ADD CONSTRAINT fk_categories_parent
+        FOREIGN KEY (parent_id) REFERENCES categories (id)
+            ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE TABLE collections
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    slug        VARCHAR(96)  NOT NULL,
+    title       VARCHAR(128) NOT NULL,
+    description VARCHAR(512) NOT NULL DEFAULT '',
+    sort_order  INT          NOT NULL DEFAULT 0,
+    is_active   TINYINT(1)   NOT NULL DEFAULT 1,
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_collections_slug (slug)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE collection_items
+(
+    collection_id BIGINT UNSIGNED NOT NULL,
+    template_id   BIGINT UNSIGNED NOT NULL,
+    sort_order    INT             NOT NULL DEFAULT 0,
+    PRIMARY KEY (collection_id, template_id),
+    KEY         ix_collection_items_template (template_id),
+    CONSTRAINT fk_collection_items_collection
+        FOREIGN KEY (collection_id) REFERENCES collections (id)
+            ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_collection_items_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,44 @@
+-- Per-locale translations of catalog text and the user's content language.
+
+ALTER TABLE users
+    ADD COLUMN locale VARCHAR(16) NOT NULL DEFAULT '' AFTER role;
+
+CREATE TABLE category_translations
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    category_id BIGINT UNSIGNED NOT NULL,
+    locale      VARCHAR(16)  NOT NULL,
+    name        VARCHAR(64)  NOT NULL DEFAULT '',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_category_translations_locale (category_id, locale),
+    CONSTRAINT fk_category_translations_category
+        FOREIGN KEY (category_id) REFERENCES categories (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE template_translations
+(
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    template_id     BIGINT UNSIGNED NOT NULL,
+    locale          VARCHAR(16)  NOT NULL,
+    title           VARCHAR(128) NOT NULL DEFAULT '',
+    description     VARCHAR(512) NOT NULL DEFAULT '',
+    headline        VARCHAR(128) NOT NULL DEFAULT '',
+    summary         VARCHAR(512) NOT NULL DEFAULT '',
+    reply_soft      TEXT         NOT NULL,
+    reply_neutral   TEXT         NOT NULL,
+    reply_firm      TEXT         NOT NULL,
+    when_not_to_use TEXT         NOT NULL,
+    best_practices  TEXT         NOT NULL,
+    created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_template_translations_locale (template_id, locale),
+    KEY         ix_template_translations_locale (locale),
+    CONSTRAINT fk_template_translations_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,6 @@
+-- Declared {{placeholder}} variables for template replies, stored as JSON.
+
+ALTER TABLE template_details
+    ADD COLUMN variables TEXT NOT NULL AFTER best_practices;
+
+UPDATE template_details SET variables = '[]' WHERE variables = '';
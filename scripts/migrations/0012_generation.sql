@@ -0,0 +1,24 @@
+-- Prompt templates for AI generation and a log of every generation call.
+
+ALTER TABLE template_details
+    ADD COLUMN prompt TEXT NOT NULL AFTER variables;
+
+CREATE TABLE generation_usages
+(
+    id            BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    user_id       BIGINT UNSIGNED NOT NULL,
+    template_id   BIGINT UNSIGNED NOT NULL,
+    provider      VARCHAR(32)  NOT NULL,
+    model         VARCHAR(128) NOT NULL DEFAULT '',
+    tone          VARCHAR(16)  NOT NULL DEFAULT '',
+    status        VARCHAR(16)  NOT NULL,
+    input_tokens  INT          NOT NULL DEFAULT 0,
+    output_tokens INT          NOT NULL DEFAULT 0,
+    cost_micros   BIGINT       NOT NULL DEFAULT 0,
+    latency_ms    INT          NOT NULL DEFAULT 0,
+    error         VARCHAR(512) NOT NULL DEFAULT '',
+    created_at    DATETIME(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    KEY           ix_generation_usages_user (user_id, created_at),
+    KEY           ix_generation_usages_created (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
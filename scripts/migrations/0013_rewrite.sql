@@ -0,0 +1,5 @@
+-- Free-form rewrites are metered and costed alongside template generation.
+
+ALTER TABLE generation_usages
+    MODIFY COLUMN template_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    ADD COLUMN kind VARCHAR(16) NOT NULL DEFAULT 'generate' AFTER template_id;
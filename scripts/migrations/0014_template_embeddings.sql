@@ -0,0 +1,18 @@
+-- Stored template embeddings for situation-based suggestions.
+
+CREATE TABLE template_embeddings
+(
+    id           BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    template_id  BIGINT UNSIGNED NOT NULL,
+    model        VARCHAR(128) NOT NULL,
+    content_hash CHAR(64)     NOT NULL,
+    dimensions   INT          NOT NULL,
+    vector       MEDIUMBLOB   NOT NULL,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_template_embeddings_model (template_id, model),
+    CONSTRAINT fk_template_embeddings_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
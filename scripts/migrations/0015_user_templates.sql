@@ -0,0 +1,25 @@
+-- Private templates written by users, shaped like a template plus its detail.
+
+CREATE TABLE user_templates
+(
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    owner_id        BIGINT UNSIGNED NOT NULL,
+    title           VARCHAR(128) NOT NULL,
+    description     VARCHAR(512) NOT NULL DEFAULT '',
+    tags_text       VARCHAR(512) NOT NULL DEFAULT '',
+    headline        VARCHAR(128) NOT NULL DEFAULT '',
+    summary         VARCHAR(512) NOT NULL DEFAULT '',
+    reply_soft      TEXT         NOT NULL,
+    reply_neutral   TEXT         NOT NULL,
+    reply_firm      TEXT         NOT NULL,
+    when_not_to_use TEXT         NOT NULL,
+    best_practices  TEXT         NOT NULL,
+    variables       TEXT         NOT NULL,
+    created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY ix_user_templates_owner (owner_id, updated_at),
+    CONSTRAINT fk_user_templates_owner
+        FOREIGN KEY (owner_id) REFERENCES users (id)
+            ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,11 @@
+-- Private templates forked from the catalog keep a reference to their source
+-- and the source content they were last synced with.
+
+ALTER TABLE user_templates
+    ADD COLUMN source_template_id BIGINT UNSIGNED NULL AFTER owner_id,
+    ADD COLUMN source_locale      VARCHAR(16) NOT NULL DEFAULT '' AFTER source_template_id,
+    ADD COLUMN source_snapshot    TEXT        NOT NULL AFTER variables,
+    ADD KEY ix_user_templates_source (source_template_id),
+    ADD CONSTRAINT fk_user_templates_source
+        FOREIGN KEY (source_template_id) REFERENCES templates (id)
+            ON DELETE SET NULL;
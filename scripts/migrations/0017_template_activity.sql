@@ -0,0 +1,47 @@
+-- Per-user template state: favorites, views and copied replies.
+
+CREATE TABLE template_favorites
+(
+    user_id     BIGINT UNSIGNED NOT NULL,
+    template_id BIGINT UNSIGNED NOT NULL,
+    created_at  TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, template_id),
+    KEY         ix_template_favorites_template (template_id),
+    CONSTRAINT fk_template_favorites_user
+        FOREIGN KEY (user_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_template_favorites_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE template_activities
+(
+    user_id        BIGINT UNSIGNED NOT NULL,
+    template_id    BIGINT UNSIGNED NOT NULL,
+    view_count     INT             NOT NULL DEFAULT 0,
+    copy_count     INT             NOT NULL DEFAULT 0,
+    last_viewed_at DATETIME(3)     NULL,
+    last_copied_at DATETIME(3)     NULL,
+    last_used_at   DATETIME(3)     NOT NULL,
+    PRIMARY KEY (user_id, template_id),
+    KEY         ix_template_activities_viewed (user_id, last_viewed_at),
+    CONSTRAINT fk_template_activities_user
+        FOREIGN KEY (user_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_template_activities_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE template_copies
+(
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    user_id     BIGINT UNSIGNED NOT NULL,
+    template_id BIGINT UNSIGNED NOT NULL,
+    tone        VARCHAR(16)     NOT NULL,
+    created_at  DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    KEY         ix_template_copies_template (template_id, created_at),
+    KEY         ix_template_copies_user (user_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,26 @@
+-- Per-tone thumbs up/down on templates with optional, moderated comments.
+
+CREATE TABLE template_ratings
+(
+    id             BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    user_id        BIGINT UNSIGNED NOT NULL,
+    template_id    BIGINT UNSIGNED NOT NULL,
+    tone           VARCHAR(16)     NOT NULL,
+    vote           TINYINT         NOT NULL,
+    comment        VARCHAR(1000)   NOT NULL DEFAULT '',
+    comment_status VARCHAR(16)     NOT NULL DEFAULT '',
+    moderated_by   BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    moderated_at   DATETIME(3)     NULL,
+    created_at     DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at     DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_template_ratings_user (user_id, template_id, tone),
+    KEY         ix_template_ratings_template (template_id, tone),
+    KEY         ix_template_ratings_comment (comment_status, updated_at),
+    CONSTRAINT fk_template_ratings_user
+        FOREIGN KEY (user_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_template_ratings_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,26 @@
+-- Public share links for a template reply, optionally with variables filled in.
+
+CREATE TABLE template_shares
+(
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    token           VARCHAR(32)     NOT NULL,
+    user_id         BIGINT UNSIGNED NOT NULL,
+    template_id     BIGINT UNSIGNED NOT NULL,
+    locale          VARCHAR(16)     NOT NULL,
+    tone            VARCHAR(16)     NOT NULL DEFAULT '',
+    variable_values TEXT            NOT NULL,
+    view_count      INT UNSIGNED    NOT NULL DEFAULT 0,
+    last_viewed_at  DATETIME(3)     NULL,
+    expires_at      DATETIME(3)     NULL,
+    revoked_at      DATETIME(3)     NULL,
+    created_at      DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_template_shares_token (token),
+    KEY         ix_template_shares_user (user_id, created_at),
+    CONSTRAINT fk_template_shares_user
+        FOREIGN KEY (user_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_template_shares_template
+        FOREIGN KEY (template_id) REFERENCES templates (id)
+            ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
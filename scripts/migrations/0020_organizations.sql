@@ -0,0 +1,60 @@
+-- Organizations with members, email invitations and a seat-based
+-- subscription that gives every member Pro, plus a team template library.
+
+CREATE TABLE organizations
+(
+    id                  BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name                VARCHAR(128)    NOT NULL,
+    seats               INT UNSIGNED    NOT NULL DEFAULT 0,
+    subscription_id     VARCHAR(64)     NOT NULL DEFAULT '',
+    subscription_status VARCHAR(32)     NOT NULL DEFAULT '',
+    current_period_end  DATETIME(3)     NULL,
+    created_at          DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at          DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    KEY         ix_organizations_subscription (subscription_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE organization_members
+(
+    organization_id BIGINT UNSIGNED NOT NULL,
+    user_id         BIGINT UNSIGNED NOT NULL,
+    role            VARCHAR(16)     NOT NULL,
+    created_at      DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (organization_id, user_id),
+    KEY         ix_organization_members_user (user_id),
+    CONSTRAINT fk_organization_members_organization
+        FOREIGN KEY (organization_id) REFERENCES organizations (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_organization_members_user
+        FOREIGN KEY (user_id) REFERENCES users (id)
+            ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE organization_invitations
+(
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    organization_id BIGINT UNSIGNED NOT NULL,
+    email           VARCHAR(320)    NOT NULL,
+    role            VARCHAR(16)     NOT NULL,
+    token           VARCHAR(32)     NOT NULL,
+    invited_by      BIGINT UNSIGNED NOT NULL,
+    expires_at      DATETIME(3)     NOT NULL,
+    created_at      DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_organization_invitations_token (token),
+    UNIQUE KEY uk_organization_invitations_email (organization_id, email),
+    CONSTRAINT fk_organization_invitations_organization
+        FOREIGN KEY (organization_id) REFERENCES organizations (id)
+            ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+ALTER TABLE users
+    ADD COLUMN team_pro TINYINT(1) NOT NULL DEFAULT 0 AFTER is_pro;
+
+ALTER TABLE user_templates
+    ADD COLUMN organization_id BIGINT UNSIGNED NULL AFTER owner_id,
+    ADD KEY ix_user_templates_organization (organization_id, updated_at),
+    ADD CONSTRAINT fk_user_templates_organization
+        FOREIGN KEY (organization_id) REFERENCES organizations (id)
+            ON DELETE CASCADE;
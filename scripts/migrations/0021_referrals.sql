@@ -0,0 +1,45 @@
+-- Referral codes, attributed sign-ups and the Pro time they earn.
+
+ALTER TABLE users
+    ADD COLUMN referral_code VARCHAR(16) NULL AFTER locale,
+    ADD COLUMN pro_until     DATETIME(3) NULL AFTER team_pro,
+    ADD UNIQUE KEY ux_users_referral_code (referral_code);
+
+-- Give existing users a code from the same alphabet new users get. A
+-- duplicate fails the whole statement on the unique key; run it again then,
+-- it only fills codes that are still empty.
+UPDATE users
+SET referral_code = CONCAT(
+        SUBSTRING('23456789ABCDEFGHJKLMNPQRSTUVWXYZ', FLOOR(1 + RAND() * 32), 1),
+        SUBSTRING('23456789ABCDEFGHJKLMNPQRSTUVWXYZ', FLOOR(1 + RAND() * 32), 1),
+        SUBSTRING('23456789ABCDEFGHJKLMNPQRSTUVWXYZ', FLOOR(1 + RAND() * 32), 1),
+        SUBSTRING('23456789ABCDEFGHJKLMNPQRSTUVWXYZ', FLOOR(1 + RAND() * 32), 1),
+        SUBSTRING('23456789ABCDEFGHJKLMNPQRSTUVWXYZ', FLOOR(1 + RAND() * 32), 1),
+        SUBSTRING('23456789ABCDEFGHJKLMNPQRSTUVWXYZ', FLOOR(1 + RAND() * 32), 1),
+        SUBSTRING('23456789ABCDEFGHJKLMNPQRSTUVWXYZ', FLOOR(1 + RAND() * 32), 1),
+        SUBSTRING('23456789ABCDEFGHJKLMNPQRSTUVWXYZ', FLOOR(1 + RAND() * 32), 1))
+WHERE referral_code IS NULL;
+
+CREATE TABLE referrals
+(
+    id             BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    referrer_id    BIGINT UNSIGNED NOT NULL,
+    referee_id     BIGINT UNSIGNED NOT NULL,
+    status         VARCHAR(16)     NOT NULL,
+    reason         VARCHAR(32)     NOT NULL DEFAULT '',
+    signup_ip      VARCHAR(64)     NOT NULL DEFAULT '',
+    reward_days    INT UNSIGNED    NOT NULL DEFAULT 0,
+    transaction_id VARCHAR(64)     NOT NULL DEFAULT '',
+    rewarded_at    DATETIME(3)     NULL,
+    created_at     DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at     DATETIME(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_referrals_referee (referee_id),
+    KEY         ix_referrals_referrer (referrer_id, created_at),
+    CONSTRAINT fk_referrals_referrer
+        FOREIGN KEY (referrer_id) REFERENCES users (id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_referrals_referee
+        FOREIGN KEY (referee_id) REFERENCES users (id)
+            ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,12 @@
+-- Point each template at its pending draft, so edits that do not touch
+-- content (flags, reordering) no longer make a saved draft look published.
+
+ALTER TABLE templates
+    ADD COLUMN draft_revision_id BIGINT UNSIGNED NULL AFTER unpublish_at;
+
+-- A draft was pending when it was the template's newest revision
+UPDATE templates t
+    JOIN template_revisions r
+    ON r.id = (SELECT MAX(id) FROM template_revisions WHERE template_id = t.id)
+SET t.draft_revision_id = r.id
+WHERE r.status = 'draft';
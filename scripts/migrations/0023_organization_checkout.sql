@@ -0,0 +1,7 @@
+-- Bind team subscriptions to organizations through checkouts the server
+-- issued, and remember the newest webhook applied so late deliveries of
+-- older events are ignored.
+
+ALTER TABLE organizations
+    ADD COLUMN checkout_token        VARCHAR(32) NOT NULL DEFAULT '' AFTER current_period_end,
+    ADD COLUMN subscription_event_at DATETIME(3) NULL AFTER checkout_token;